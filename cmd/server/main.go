@@ -3,16 +3,19 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/hohotang/shortlink-core/internal/auth"
 	"github.com/hohotang/shortlink-core/internal/config"
 	"github.com/hohotang/shortlink-core/internal/logger"
 	"github.com/hohotang/shortlink-core/internal/middleware"
 	"github.com/hohotang/shortlink-core/internal/otel"
+	"github.com/hohotang/shortlink-core/internal/ratelimit"
 	"github.com/hohotang/shortlink-core/internal/service"
 	"github.com/hohotang/shortlink-core/proto"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -67,6 +70,51 @@ func main() {
 		log.Fatal("Failed to listen", zap.Error(err))
 	}
 
+	// Create URL service; the key store may share its storage
+	urlService, err := service.NewURLService(cfg, log)
+	if err != nil {
+		log.Fatal("Failed to create URL service", zap.Error(err))
+	}
+
+	// Build the interceptor chains; both start with panic recovery so a failing
+	// handler never takes the process down. Authentication runs after the tenant
+	// is known and before the logger, so log lines name the caller. Rate limiting
+	// runs last, so it can key on the caller and rejections are logged
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		middleware.PanicRecoveryInterceptor(log),
+		middleware.TenantInterceptor(),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		middleware.PanicRecoveryStreamInterceptor(log),
+		middleware.TenantStreamInterceptor(),
+	}
+	if cfg.Auth.Enabled {
+		keys, err := auth.NewKeyStore(cfg, urlService.Storage())
+		if err != nil {
+			log.Fatal("Failed to create API key store", zap.Error(err))
+		}
+		if closer, ok := keys.(io.Closer); ok {
+			defer closer.Close()
+		}
+		unaryInterceptors = append(unaryInterceptors, middleware.AuthInterceptor(keys))
+		streamInterceptors = append(streamInterceptors, middleware.AuthStreamInterceptor(keys))
+		log.Info("API key authentication enabled", zap.String("source", cfg.Auth.Source))
+	}
+	unaryInterceptors = append(unaryInterceptors, middleware.LoggerInterceptor(log))
+	streamInterceptors = append(streamInterceptors, middleware.LoggerStreamInterceptor(log))
+	if cfg.RateLimit.Enabled {
+		limiter, err := ratelimit.New(cfg)
+		if err != nil {
+			log.Fatal("Failed to create rate limiter", zap.Error(err))
+		}
+		defer limiter.Close()
+		unaryInterceptors = append(unaryInterceptors, middleware.RateLimitInterceptor(limiter, cfg.RateLimit, log))
+		streamInterceptors = append(streamInterceptors, middleware.RateLimitStreamInterceptor(limiter, cfg.RateLimit, log))
+		log.Info("Rate limiting enabled",
+			zap.String("backend", cfg.RateLimit.Backend),
+			zap.String("keyBy", cfg.RateLimit.KeyBy))
+	}
+
 	// Create gRPC server with OpenTelemetry integration if enabled
 	var grpcServer *grpc.Server
 	if cfg.Telemetry.Enabled {
@@ -78,29 +126,19 @@ func main() {
 					propagation.Baggage{},
 				)),
 			)),
-			grpc.ChainUnaryInterceptor(
-				middleware.PanicRecoveryInterceptor(log),
-				middleware.LoggerInterceptor(log),
-			),
+			grpc.ChainUnaryInterceptor(unaryInterceptors...),
+			grpc.ChainStreamInterceptor(streamInterceptors...),
 		)
 		log.Info("gRPC server created with OpenTelemetry integration and interceptors")
 	} else {
 		// Without OpenTelemetry
 		grpcServer = grpc.NewServer(
-			grpc.ChainUnaryInterceptor(
-				middleware.PanicRecoveryInterceptor(log),
-				middleware.LoggerInterceptor(log),
-			),
+			grpc.ChainUnaryInterceptor(unaryInterceptors...),
+			grpc.ChainStreamInterceptor(streamInterceptors...),
 		)
 		log.Info("gRPC server created with interceptors")
 	}
 
-	// Create URL service
-	urlService, err := service.NewURLService(cfg, log)
-	if err != nil {
-		log.Fatal("Failed to create URL service", zap.Error(err))
-	}
-
 	// Register service
 	proto.RegisterURLServiceServer(grpcServer, urlService)
 
@@ -118,6 +156,10 @@ func main() {
 	<-quit
 
 	log.Info("Shutting down server...")
+	urlService.CloseStreams()
 	grpcServer.GracefulStop()
+	if err := urlService.Close(); err != nil {
+		log.Warn("Error closing URL service", zap.Error(err))
+	}
 	log.Info("Server stopped")
 }
@@ -1,19 +1,154 @@
 -- Create URLs table
+-- Links are namespaced per tenant; the same short ID may exist in several tenants
 CREATE TABLE IF NOT EXISTS urls (
-    short_id VARCHAR(255) PRIMARY KEY,
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    short_id VARCHAR(255) NOT NULL,
     original_url TEXT NOT NULL,
+    canonical_url TEXT NOT NULL DEFAULT '', -- normalized original_url that dedup compares
+    dedup BOOLEAN NOT NULL DEFAULT TRUE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-    last_accessed TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+    last_accessed TIMESTAMP WITH TIME ZONE, -- NULL until the link first resolves
+    expires_at TIMESTAMP WITH TIME ZONE,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    click_count BIGINT NOT NULL DEFAULT 0,
+    password_hash TEXT NOT NULL DEFAULT '',
+    max_clicks BIGINT NOT NULL DEFAULT 0, -- 0 means unlimited
+    remaining_clicks BIGINT NOT NULL DEFAULT 0,
+    quarantine_reason TEXT NOT NULL DEFAULT '', -- set when a reputation check flags the link; it then no longer resolves
+    campaign_id VARCHAR(64) NOT NULL DEFAULT '', -- campaign whose UTM parameters are added when the link resolves
+    targeting JSONB NOT NULL DEFAULT '[]', -- ordered rules that send some visitors to another destination
+    variants JSONB NOT NULL DEFAULT '[]', -- weighted destinations that split the visitors no targeting rule matches
+    not_before TIMESTAMP WITH TIME ZONE, -- the link only resolves from this moment; NULL resolves from creation
+    PRIMARY KEY (tenant_id, short_id)
 );
 
--- Add unique index to original_url for reverse lookup
-CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_original_url ON urls (original_url);
+-- Upgrade a urls table created by an earlier version; each step is a no-op on a current one
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS canonical_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS dedup BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS click_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS password_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS max_clicks BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS remaining_clicks BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS quarantine_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS campaign_id VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS targeting JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS variants JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS not_before TIMESTAMP WITH TIME ZONE;
+ALTER TABLE urls ALTER COLUMN last_accessed DROP DEFAULT;
+
+-- Links stored before canonicalization dedup on their URL as submitted
+UPDATE urls SET canonical_url = original_url WHERE canonical_url = '';
+
+-- Dedup used to be unique per URL across all links; it is now per tenant and canonical URL
+DROP INDEX IF EXISTS idx_urls_original_url;
+
+-- Short IDs used to be unique on their own; they are now unique per tenant,
+-- and the created_at index leads with the tenant to serve per-tenant listings
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM pg_index i
+        JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+        WHERE i.indrelid = 'urls'::regclass AND i.indisprimary AND a.attname = 'tenant_id'
+    ) THEN
+        ALTER TABLE urls DROP CONSTRAINT IF EXISTS urls_pkey;
+        ALTER TABLE urls ADD PRIMARY KEY (tenant_id, short_id);
+    END IF;
+    IF EXISTS (
+        SELECT 1 FROM pg_indexes
+        WHERE tablename = 'urls' AND indexname = 'idx_urls_created_at' AND indexdef NOT LIKE '%tenant_id%'
+    ) THEN
+        DROP INDEX idx_urls_created_at;
+    END IF;
+END $$;
+
+-- Add unique index to canonical_url for reverse lookup
+-- Only dedup links are unique per URL and tenant; custom aliases may share a destination
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_canonical_url ON urls (tenant_id, canonical_url) WHERE dedup;
 
 -- Add index on created_at for date-based queries
-CREATE INDEX IF NOT EXISTS idx_urls_created_at ON urls (created_at);
+CREATE INDEX IF NOT EXISTS idx_urls_created_at ON urls (tenant_id, created_at);
 
 -- Add index on last_accessed for cleanup/analytics
 CREATE INDEX IF NOT EXISTS idx_urls_last_accessed ON urls (last_accessed);
 
+-- Create clicks table for analytics
+-- Clicks are kept when their link is deleted, so there is no foreign key
+CREATE TABLE IF NOT EXISTS clicks (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    short_id VARCHAR(255) NOT NULL,
+    clicked_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    referrer TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    client_ip TEXT NOT NULL DEFAULT '',
+    variant VARCHAR(32) NOT NULL DEFAULT '' -- variant served by a split link
+);
+
+-- Upgrade a clicks table created by an earlier version
+ALTER TABLE clicks ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE clicks ADD COLUMN IF NOT EXISTS variant VARCHAR(32) NOT NULL DEFAULT '';
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM pg_indexes
+        WHERE tablename = 'clicks' AND indexname = 'idx_clicks_short_id_clicked_at' AND indexdef NOT LIKE '%tenant_id%'
+    ) THEN
+        DROP INDEX idx_clicks_short_id_clicked_at;
+    END IF;
+END $$;
+
+-- Add index for per-link click queries
+CREATE INDEX IF NOT EXISTS idx_clicks_short_id_clicked_at ON clicks (tenant_id, short_id, clicked_at);
+
+-- Create API keys table; only the SHA-256 of each key is stored.
+-- An empty tenant_id lets the key act on any tenant
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(64) PRIMARY KEY,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create tenant usage table for link quotas
+-- day is the UTC day daily_links counts; it resets when the first link of a new day is created
+CREATE TABLE IF NOT EXISTS tenant_usage (
+    tenant_id VARCHAR(64) PRIMARY KEY,
+    day DATE NOT NULL,
+    daily_links BIGINT NOT NULL DEFAULT 0,
+    total_links BIGINT NOT NULL DEFAULT 0
+);
+
+-- Create tags table; the primary key serves listing links by tag
+-- Tags go away with their link
+CREATE TABLE IF NOT EXISTS url_tags (
+    tenant_id VARCHAR(64) NOT NULL,
+    short_id VARCHAR(255) NOT NULL,
+    tag VARCHAR(64) NOT NULL,
+    PRIMARY KEY (tenant_id, tag, short_id),
+    FOREIGN KEY (tenant_id, short_id) REFERENCES urls (tenant_id, short_id) ON DELETE CASCADE
+);
+
+-- Add index for looking up the tags of a link
+CREATE INDEX IF NOT EXISTS idx_url_tags_short_id ON url_tags (tenant_id, short_id);
+
+-- Create campaigns table; params holds the utm_* parameters as a JSON object
+-- Links refer to campaigns by ID, so editing a campaign never rewrites them
+CREATE TABLE IF NOT EXISTS campaigns (
+    tenant_id VARCHAR(64) NOT NULL,
+    id VARCHAR(64) NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    params JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, id)
+);
+
 -- Grant permissions (adjust as needed)
-GRANT ALL PRIVILEGES ON TABLE urls TO postgres; 
\ No newline at end of file
+GRANT ALL PRIVILEGES ON TABLE urls, clicks, api_keys, tenant_usage, url_tags, campaigns TO postgres; 
\ No newline at end of file
@@ -0,0 +1,175 @@
+package analytics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"go.uber.org/zap"
+)
+
+// AccessTracker counts link resolutions off the request path. Counts are
+// merged per short ID in memory and written with one RecordAccess per tenant
+// every flush interval, so a popular link costs one update per interval
+// rather than one per redirect. Tracking never blocks: once BufferSize
+// distinct links are waiting, accesses to further links are dropped and counted
+type AccessTracker struct {
+	store  storage.URLStorage
+	cfg    Config
+	stop   chan struct{}
+	done   chan struct{}
+	logger *zap.Logger
+
+	// mutex guards pending, size and closed
+	mutex   sync.Mutex
+	pending map[string]map[string]int64 // tenant -> short ID -> resolutions
+	size    int
+	closed  bool
+
+	// flushMutex serialises writes, so Flush and the background writer never overlap
+	flushMutex sync.Mutex
+
+	dropped  atomic.Uint64
+	reported uint64
+}
+
+// NewAccessTracker creates an AccessTracker and starts its background writer
+// BatchSize is not used; each tenant's counts are written in one statement
+func NewAccessTracker(store storage.URLStorage, cfg Config, log *zap.Logger) *AccessTracker {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 10000
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+
+	t := &AccessTracker{
+		store:   store,
+		cfg:     cfg,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		logger:  log,
+		pending: make(map[string]map[string]int64),
+	}
+	go t.run()
+	return t
+}
+
+// Track counts one resolution of each short ID in the context's tenant
+// It returns false if any of them was dropped
+func (t *AccessTracker) Track(ctx context.Context, shortIDs ...string) bool {
+	tenantID := tenant.FromContext(ctx)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.closed {
+		t.dropped.Add(uint64(len(shortIDs)))
+		return len(shortIDs) == 0
+	}
+
+	counts, ok := t.pending[tenantID]
+	if !ok {
+		counts = make(map[string]int64)
+		t.pending[tenantID] = counts
+	}
+
+	tracked := true
+	for _, shortID := range shortIDs {
+		if _, ok := counts[shortID]; !ok {
+			if t.size >= t.cfg.BufferSize {
+				t.dropped.Add(1)
+				tracked = false
+				continue
+			}
+			t.size++
+		}
+		counts[shortID]++
+	}
+	return tracked
+}
+
+// Dropped returns the number of accesses dropped so far
+func (t *AccessTracker) Dropped() uint64 {
+	return t.dropped.Load()
+}
+
+// Flush writes the counts gathered so far and waits until they are stored
+func (t *AccessTracker) Flush() {
+	t.flushMutex.Lock()
+	defer t.flushMutex.Unlock()
+
+	t.mutex.Lock()
+	pending := t.pending
+	t.pending = make(map[string]map[string]int64, len(pending))
+	t.size = 0
+	t.mutex.Unlock()
+
+	for tenantID, counts := range pending {
+		t.write(tenantID, counts)
+	}
+}
+
+// Close stops accepting accesses and waits until the gathered ones are written
+func (t *AccessTracker) Close() {
+	t.mutex.Lock()
+	if !t.closed {
+		t.closed = true
+		close(t.stop)
+	}
+	t.mutex.Unlock()
+
+	<-t.done
+}
+
+// run writes the gathered counts every flush interval until the tracker is closed
+func (t *AccessTracker) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			t.Flush()
+			t.logger.Info("Access tracker stopped", zap.Uint64("dropped", t.Dropped()))
+			return
+		case <-ticker.C:
+			t.Flush()
+			t.reportDropped()
+		}
+	}
+}
+
+// write stores the counts of one tenant; failed writes are logged and discarded
+func (t *AccessTracker) write(tenantID string, counts map[string]int64) {
+	if len(counts) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(tenant.WithContext(context.Background(), tenantID), flushTimeout)
+	defer cancel()
+
+	if err := t.store.RecordAccess(ctx, counts); err != nil {
+		t.logger.Error("Failed to record access", zap.Error(err),
+			zap.String("tenant", tenantID), zap.Int("count", len(counts)))
+		return
+	}
+	t.logger.Debug("Accesses recorded", zap.String("tenant", tenantID), zap.Int("count", len(counts)))
+}
+
+// reportDropped logs accesses dropped since the last report
+func (t *AccessTracker) reportDropped() {
+	dropped := t.Dropped()
+	if dropped == t.reported {
+		return
+	}
+	t.logger.Warn("Access buffer full, accesses dropped",
+		zap.Uint64("dropped", dropped-t.reported),
+		zap.Uint64("droppedTotal", dropped))
+	t.reported = dropped
+}
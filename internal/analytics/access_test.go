@@ -0,0 +1,75 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"go.uber.org/zap"
+)
+
+func TestAccessTracker_MergesCounts(t *testing.T) {
+	acme := tenant.WithContext(context.Background(), "acme")
+	store := storage.NewMemoryStorage()
+	for _, shortID := range []string{"abc", "def"} {
+		if err := store.StoreWithID(acme, &models.URL{ShortID: shortID, OriginalURL: "https://example.com/" + shortID}); err != nil {
+			t.Fatalf("StoreWithID returned unexpected error: %v", err)
+		}
+	}
+
+	tracker := NewAccessTracker(store, Config{BufferSize: 10, FlushInterval: time.Hour}, zap.NewNop())
+	tracker.Track(acme, "abc")
+	tracker.Track(acme, "abc", "def")
+
+	// Nothing is written before a flush
+	link, err := store.GetInfo(acme, "abc")
+	if err != nil {
+		t.Fatalf("GetInfo returned unexpected error: %v", err)
+	}
+	if link.ClickCount != 0 {
+		t.Fatalf("Expected no clicks before a flush, got %d", link.ClickCount)
+	}
+
+	// Close writes what was gathered
+	tracker.Close()
+
+	for shortID, want := range map[string]int64{"abc": 2, "def": 1} {
+		link, err := store.GetInfo(acme, shortID)
+		if err != nil {
+			t.Fatalf("GetInfo returned unexpected error: %v", err)
+		}
+		if link.ClickCount != want || link.LastAccessed == nil {
+			t.Errorf("Expected %d clicks and a last access for %s, got %d and %v", want, shortID, link.ClickCount, link.LastAccessed)
+		}
+	}
+
+	if tracker.Track(acme, "abc") {
+		t.Errorf("Expected Track to drop accesses after Close")
+	}
+}
+
+func TestAccessTracker_DropsWhenFull(t *testing.T) {
+	tracker := NewAccessTracker(storage.NewMemoryStorage(), Config{BufferSize: 1, FlushInterval: time.Hour}, zap.NewNop())
+	defer tracker.Close()
+	ctx := context.Background()
+
+	// Repeat accesses to a waiting link are merged and never dropped
+	if !tracker.Track(ctx, "abc") || !tracker.Track(ctx, "abc") {
+		t.Fatalf("Expected accesses to a waiting link to be tracked")
+	}
+	if tracker.Track(ctx, "def") {
+		t.Errorf("Expected an access to a new link to be dropped when full")
+	}
+	if tracker.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped access, got %d", tracker.Dropped())
+	}
+
+	// A flush makes room again
+	tracker.Flush()
+	if !tracker.Track(ctx, "def") {
+		t.Errorf("Expected room after a flush")
+	}
+}
@@ -0,0 +1,155 @@
+package analytics
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/utils"
+)
+
+var (
+	// ErrSlowSubscriber is reported when a subscriber's buffer overflowed
+	ErrSlowSubscriber = errors.New("subscriber too slow, click feed fell behind")
+	// ErrHubClosed is reported when the hub was closed while subscribed
+	ErrHubClosed = errors.New("click feed closed")
+)
+
+// ClickFilter selects the clicks a subscriber receives; empty fields match every click
+type ClickFilter struct {
+	// TenantID keeps only clicks on links of this tenant
+	TenantID string
+	// ShortID keeps only clicks on this short ID
+	ShortID string
+	// Domain keeps only clicks whose destination host equals it, ignoring case
+	Domain string
+}
+
+// Matches reports whether a click passes the filter
+func (f ClickFilter) Matches(click *models.Click) bool {
+	if f.TenantID != "" && click.TenantID != f.TenantID {
+		return false
+	}
+	if f.ShortID != "" && click.ShortID != f.ShortID {
+		return false
+	}
+	if f.Domain != "" && utils.Hostname(click.OriginalURL) != f.Domain {
+		return false
+	}
+	return true
+}
+
+// Subscription is a live feed of clicks from a ClickHub
+type Subscription struct {
+	filter ClickFilter
+	events chan *models.Click
+	err    error
+}
+
+// Events returns the channel of matching clicks
+// It is closed when the subscription ends; Err then tells why
+func (s *Subscription) Events() <-chan *models.Click {
+	return s.events
+}
+
+// Err returns why the subscription ended, or nil if it was unsubscribed
+// It must only be called after Events is closed
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// ClickHub fans out published clicks to subscribers
+// Each subscriber has its own buffer; a subscriber whose buffer is full is
+// disconnected, so publishing never blocks
+type ClickHub struct {
+	bufferSize  int
+	mutex       sync.Mutex
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// NewClickHub creates a ClickHub with the given per-subscriber buffer size
+func NewClickHub(bufferSize int) *ClickHub {
+	if bufferSize <= 0 {
+		bufferSize = 100
+	}
+	return &ClickHub{
+		bufferSize:  bufferSize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe starts a feed of the clicks that match the filter
+func (h *ClickHub) Subscribe(filter ClickFilter) *Subscription {
+	filter.Domain = strings.ToLower(filter.Domain)
+	sub := &Subscription{
+		filter: filter,
+		events: make(chan *models.Click, h.bufferSize),
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.closed {
+		h.end(sub, ErrHubClosed)
+		return sub
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe ends a subscription; it is safe to call more than once
+func (h *ClickHub) Unsubscribe(sub *Subscription) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		h.end(sub, nil)
+	}
+}
+
+// Publish delivers a click to every matching subscriber without blocking
+func (h *ClickHub) Publish(click *models.Click) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for sub := range h.subscribers {
+		if !sub.filter.Matches(click) {
+			continue
+		}
+		select {
+		case sub.events <- click:
+		default:
+			delete(h.subscribers, sub)
+			h.end(sub, ErrSlowSubscriber)
+		}
+	}
+}
+
+// Subscribers returns the number of active subscriptions
+func (h *ClickHub) Subscribers() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return len(h.subscribers)
+}
+
+// Close ends all subscriptions; later subscriptions end immediately
+func (h *ClickHub) Close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		h.end(sub, ErrHubClosed)
+	}
+}
+
+// end records why a subscription ended and closes its channel
+// The caller must hold the mutex and have removed the subscription
+func (h *ClickHub) end(sub *Subscription, err error) {
+	sub.err = err
+	close(sub.events)
+}
@@ -0,0 +1,80 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+)
+
+func TestClickHub_Filters(t *testing.T) {
+	hub := NewClickHub(10)
+	all := hub.Subscribe(ClickFilter{})
+	byID := hub.Subscribe(ClickFilter{ShortID: "abc"})
+	byDomain := hub.Subscribe(ClickFilter{Domain: "Example.com"})
+
+	hub.Publish(&models.Click{ShortID: "abc", OriginalURL: "https://other.example.org/"})
+	hub.Publish(&models.Click{ShortID: "def", OriginalURL: "https://EXAMPLE.com:8443/x"})
+
+	for name, test := range map[string]struct {
+		sub      *Subscription
+		expected []string
+	}{
+		"all":      {all, []string{"abc", "def"}},
+		"byID":     {byID, []string{"abc"}},
+		"byDomain": {byDomain, []string{"def"}},
+	} {
+		if len(test.sub.Events()) != len(test.expected) {
+			t.Errorf("%s: expected %d clicks, got %d", name, len(test.expected), len(test.sub.Events()))
+			continue
+		}
+		for _, shortID := range test.expected {
+			if click := <-test.sub.Events(); click.ShortID != shortID {
+				t.Errorf("%s: expected click on %s, got %s", name, shortID, click.ShortID)
+			}
+		}
+	}
+}
+
+func TestClickHub_DisconnectsSlowSubscriber(t *testing.T) {
+	hub := NewClickHub(1)
+	slow := hub.Subscribe(ClickFilter{})
+	other := hub.Subscribe(ClickFilter{ShortID: "def"})
+
+	hub.Publish(&models.Click{ShortID: "abc"})
+	hub.Publish(&models.Click{ShortID: "abc"})
+
+	// The buffered click is still delivered before the channel closes
+	if _, ok := <-slow.Events(); !ok {
+		t.Fatalf("Expected the buffered click")
+	}
+	if _, ok := <-slow.Events(); ok {
+		t.Fatalf("Expected the slow subscription to end")
+	}
+	if slow.Err() != ErrSlowSubscriber {
+		t.Errorf("Expected ErrSlowSubscriber, got %v", slow.Err())
+	}
+	if hub.Subscribers() != 1 {
+		t.Errorf("Expected the other subscriber to stay connected, got %d subscribers", hub.Subscribers())
+	}
+
+	hub.Unsubscribe(other)
+	hub.Unsubscribe(other)
+	if _, ok := <-other.Events(); ok || other.Err() != nil {
+		t.Errorf("Expected a clean end after Unsubscribe, got %v", other.Err())
+	}
+}
+
+func TestClickHub_Close(t *testing.T) {
+	hub := NewClickHub(1)
+	sub := hub.Subscribe(ClickFilter{})
+
+	hub.Close()
+	if _, ok := <-sub.Events(); ok || sub.Err() != ErrHubClosed {
+		t.Errorf("Expected ErrHubClosed, got %v", sub.Err())
+	}
+
+	late := hub.Subscribe(ClickFilter{})
+	if _, ok := <-late.Events(); ok || late.Err() != ErrHubClosed {
+		t.Errorf("Expected a subscription after Close to end immediately, got %v", late.Err())
+	}
+}
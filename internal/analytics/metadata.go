@@ -0,0 +1,69 @@
+package analytics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys the caller uses to describe the visitor behind a request
+const (
+	// MetadataReferrer carries the visitor's Referer header
+	MetadataReferrer = "x-referrer"
+	// MetadataUserAgent carries the visitor's User-Agent header
+	MetadataUserAgent = "x-user-agent"
+	// MetadataClientIP carries the visitor's IP address
+	MetadataClientIP = "x-client-ip"
+	// MetadataForwardedFor carries the X-Forwarded-For chain; its first entry is the visitor
+	MetadataForwardedFor = "x-forwarded-for"
+	// MetadataAcceptLanguage carries the visitor's Accept-Language header
+	MetadataAcceptLanguage = "x-accept-language"
+	// MetadataCountry carries the ISO 3166-1 alpha-2 country of the visitor
+	MetadataCountry = "x-country-code"
+	// MetadataVisitorID carries a stable ID of the visitor, such as a cookie value
+	MetadataVisitorID = "x-visitor-id"
+)
+
+// ClickFromContext builds a click on a short ID from the incoming request
+// metadata and the request's tenant
+func ClickFromContext(ctx context.Context, shortID string, originalURL string) *models.Click {
+	click := &models.Click{
+		TenantID:    tenant.FromContext(ctx),
+		ShortID:     shortID,
+		OriginalURL: originalURL,
+		ClickedAt:   time.Now().UTC(),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		click.Referrer = firstValue(md, MetadataReferrer)
+		click.UserAgent = firstValue(md, MetadataUserAgent)
+	}
+	click.ClientIP = ClientIPFromContext(ctx)
+	return click
+}
+
+// ClientIPFromContext returns the visitor's IP address forwarded by the
+// caller, from x-client-ip or else the first x-forwarded-for entry
+// It returns an empty string when the caller forwarded neither
+func ClientIPFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if ip := strings.TrimSpace(firstValue(md, MetadataClientIP)); ip != "" {
+		return ip
+	}
+	forwarded, _, _ := strings.Cut(firstValue(md, MetadataForwardedFor), ",")
+	return strings.TrimSpace(forwarded)
+}
+
+// firstValue returns the first value of a metadata key, or an empty string
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package analytics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"go.uber.org/zap"
+)
+
+// flushTimeout bounds a single write of a batch of clicks
+const flushTimeout = 5 * time.Second
+
+// Config holds configuration for the click tracker
+type Config struct {
+	// BufferSize is the number of clicks that may wait to be written; further clicks are dropped
+	BufferSize int
+	// BatchSize is the maximum number of clicks written in one round trip
+	BatchSize int
+	// FlushInterval is how long a partial batch may wait before it is written
+	FlushInterval time.Duration
+}
+
+// ClickTracker collects click events off the request path and writes them to
+// storage in batches. Tracking never blocks: when the buffer is full the click
+// is dropped and counted instead
+type ClickTracker struct {
+	store  storage.ClickStorage
+	cfg    Config
+	events chan *models.Click
+	done   chan struct{}
+	logger *zap.Logger
+
+	// mutex guards closed against Track racing with Close
+	mutex  sync.RWMutex
+	closed bool
+
+	dropped  atomic.Uint64
+	reported uint64
+}
+
+// NewClickTracker creates a ClickTracker and starts its background writer
+func NewClickTracker(store storage.ClickStorage, cfg Config, log *zap.Logger) *ClickTracker {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 10000
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+
+	t := &ClickTracker{
+		store:  store,
+		cfg:    cfg,
+		events: make(chan *models.Click, cfg.BufferSize),
+		done:   make(chan struct{}),
+		logger: log,
+	}
+	go t.run()
+
+	log.Info("Click tracker started",
+		zap.Int("bufferSize", cfg.BufferSize),
+		zap.Int("batchSize", cfg.BatchSize),
+		zap.Duration("flushInterval", cfg.FlushInterval))
+	return t
+}
+
+// Track queues a click for writing without blocking
+// It returns false if the click was dropped because the buffer is full or
+// the tracker is closed
+func (t *ClickTracker) Track(click *models.Click) bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.closed {
+		t.dropped.Add(1)
+		return false
+	}
+
+	select {
+	case t.events <- click:
+		return true
+	default:
+		t.dropped.Add(1)
+		return false
+	}
+}
+
+// Dropped returns the number of clicks dropped so far
+func (t *ClickTracker) Dropped() uint64 {
+	return t.dropped.Load()
+}
+
+// Close stops accepting clicks and waits until the queued ones are written
+func (t *ClickTracker) Close() {
+	t.mutex.Lock()
+	if !t.closed {
+		t.closed = true
+		close(t.events)
+	}
+	t.mutex.Unlock()
+
+	<-t.done
+}
+
+// run batches queued clicks and writes them until the tracker is closed
+func (t *ClickTracker) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*models.Click, 0, t.cfg.BatchSize)
+	for {
+		select {
+		case click, ok := <-t.events:
+			if !ok {
+				t.flush(batch)
+				t.logger.Info("Click tracker stopped", zap.Uint64("dropped", t.Dropped()))
+				return
+			}
+			batch = append(batch, click)
+			if len(batch) >= t.cfg.BatchSize {
+				t.flush(batch)
+				batch = make([]*models.Click, 0, t.cfg.BatchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				t.flush(batch)
+				batch = make([]*models.Click, 0, t.cfg.BatchSize)
+			}
+			t.reportDropped()
+		}
+	}
+}
+
+// flush writes a batch of clicks; failed batches are logged and discarded
+func (t *ClickTracker) flush(batch []*models.Click) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := t.store.StoreClicks(ctx, batch); err != nil {
+		t.logger.Error("Failed to write clicks", zap.Error(err), zap.Int("count", len(batch)))
+		return
+	}
+	t.logger.Debug("Clicks written", zap.Int("count", len(batch)))
+}
+
+// reportDropped logs clicks dropped since the last report
+func (t *ClickTracker) reportDropped() {
+	dropped := t.Dropped()
+	if dropped == t.reported {
+		return
+	}
+	t.logger.Warn("Click buffer full, clicks dropped",
+		zap.Uint64("dropped", dropped-t.reported),
+		zap.Uint64("droppedTotal", dropped))
+	t.reported = dropped
+}
@@ -0,0 +1,137 @@
+package analytics
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+// recordingStore is a ClickStorage that remembers every batch it receives
+type recordingStore struct {
+	mutex   sync.Mutex
+	batches [][]*models.Click
+	started chan struct{} // receives once per StoreClicks call, if set
+	release chan struct{} // StoreClicks waits on it, if set
+}
+
+func (s *recordingStore) StoreClicks(ctx context.Context, clicks []*models.Click) error {
+	if s.started != nil {
+		s.started <- struct{}{}
+	}
+	if s.release != nil {
+		<-s.release
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.batches = append(s.batches, clicks)
+	return nil
+}
+
+func (s *recordingStore) batchSizes() []int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sizes := make([]int, len(s.batches))
+	for i, batch := range s.batches {
+		sizes[i] = len(batch)
+	}
+	return sizes
+}
+
+func TestClickTracker_Batches(t *testing.T) {
+	store := &recordingStore{}
+	tracker := NewClickTracker(store, Config{BufferSize: 10, BatchSize: 2, FlushInterval: time.Hour}, zap.NewNop())
+
+	for i := 0; i < 5; i++ {
+		if !tracker.Track(&models.Click{ShortID: "abc"}) {
+			t.Fatalf("Track dropped click %d", i)
+		}
+	}
+
+	// Close writes the partial batch that is still waiting for the interval
+	tracker.Close()
+
+	sizes := store.batchSizes()
+	if len(sizes) != 3 || sizes[0] != 2 || sizes[1] != 2 || sizes[2] != 1 {
+		t.Errorf("Expected batches of 2, 2 and 1 clicks, got %v", sizes)
+	}
+	if tracker.Track(&models.Click{ShortID: "abc"}) {
+		t.Errorf("Expected Track to fail after Close")
+	}
+}
+
+func TestClickTracker_FlushInterval(t *testing.T) {
+	store := &recordingStore{started: make(chan struct{}, 1)}
+	tracker := NewClickTracker(store, Config{BufferSize: 10, BatchSize: 100, FlushInterval: 10 * time.Millisecond}, zap.NewNop())
+	defer tracker.Close()
+
+	tracker.Track(&models.Click{ShortID: "abc"})
+
+	select {
+	case <-store.started:
+	case <-time.After(time.Second):
+		t.Fatalf("Partial batch was not written after the flush interval")
+	}
+}
+
+func TestClickTracker_DropsWhenFull(t *testing.T) {
+	store := &recordingStore{started: make(chan struct{}, 1), release: make(chan struct{})}
+	tracker := NewClickTracker(store, Config{BufferSize: 1, BatchSize: 1, FlushInterval: time.Hour}, zap.NewNop())
+
+	// The first click is taken off the buffer and blocks in the store
+	tracker.Track(&models.Click{ShortID: "first"})
+	<-store.started
+
+	// The second one fills the buffer and the third one is dropped
+	if !tracker.Track(&models.Click{ShortID: "second"}) {
+		t.Errorf("Expected the second click to be buffered")
+	}
+	if tracker.Track(&models.Click{ShortID: "third"}) {
+		t.Errorf("Expected the third click to be dropped")
+	}
+	if tracker.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped click, got %d", tracker.Dropped())
+	}
+
+	close(store.release)
+	<-store.started
+	tracker.Close()
+
+	if sizes := store.batchSizes(); len(sizes) != 2 {
+		t.Errorf("Expected 2 written batches, got %v", sizes)
+	}
+}
+
+func TestClickFromContext(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		MetadataReferrer, "https://news.example.com/",
+		MetadataUserAgent, "Mozilla/5.0",
+		MetadataClientIP, "203.0.113.7",
+	))
+
+	click := ClickFromContext(ctx, "abc", "https://example.com/")
+	if click.ShortID != "abc" || click.OriginalURL != "https://example.com/" || click.ClickedAt.IsZero() {
+		t.Errorf("Unexpected click: %+v", click)
+	}
+	if click.Referrer != "https://news.example.com/" || click.UserAgent != "Mozilla/5.0" || click.ClientIP != "203.0.113.7" {
+		t.Errorf("Metadata not copied into click: %+v", click)
+	}
+
+	// Without x-client-ip the first X-Forwarded-For entry is the visitor
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataForwardedFor, "198.51.100.1, 10.0.0.1"))
+	if click := ClickFromContext(ctx, "abc", "https://example.com/"); click.ClientIP != "198.51.100.1" {
+		t.Errorf("Expected the forwarded client IP, got %q", click.ClientIP)
+	}
+
+	// Missing metadata leaves the fields empty
+	click = ClickFromContext(context.Background(), "abc", "https://example.com/")
+	if click.Referrer != "" || click.UserAgent != "" || click.ClientIP != "" {
+		t.Errorf("Expected empty visitor fields, got %+v", click)
+	}
+}
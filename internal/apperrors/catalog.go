@@ -0,0 +1,142 @@
+package apperrors
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"google.golang.org/grpc/codes"
+)
+
+// Domain is the ErrorInfo domain of every error this service returns
+const Domain = "shortlink-core"
+
+// Reason is a machine-readable error code, sent as google.rpc.ErrorInfo.reason
+type Reason string
+
+// Error reasons, grouped by the status code they are returned with
+const (
+	// InvalidArgument
+	ReasonInvalidArgument  Reason = "INVALID_ARGUMENT"
+	ReasonInvalidURL       Reason = "INVALID_URL"
+	ReasonInvalidAlias     Reason = "INVALID_ALIAS"
+	ReasonInvalidExpiry    Reason = "INVALID_EXPIRY"
+	ReasonBatchTooLarge    Reason = "BATCH_TOO_LARGE"
+	ReasonInvalidPageToken Reason = "INVALID_PAGE_TOKEN"
+	ReasonInvalidPassword  Reason = "INVALID_PASSWORD"
+	ReasonInvalidMaxClicks Reason = "INVALID_MAX_CLICKS"
+	ReasonInvalidTenant    Reason = "INVALID_TENANT"
+	ReasonURLBlocked       Reason = "URL_BLOCKED"
+	ReasonURLFlagged       Reason = "URL_FLAGGED"
+	ReasonInvalidTag       Reason = "INVALID_TAG"
+	ReasonInvalidCampaign  Reason = "INVALID_CAMPAIGN"
+	ReasonInvalidTargeting Reason = "INVALID_TARGETING"
+	ReasonInvalidVariants  Reason = "INVALID_VARIANTS"
+	ReasonInvalidSchedule  Reason = "INVALID_SCHEDULE"
+
+	// NotFound
+	ReasonURLNotFound      Reason = "URL_NOT_FOUND"
+	ReasonCampaignNotFound Reason = "CAMPAIGN_NOT_FOUND"
+
+	// AlreadyExists
+	ReasonShortIDTaken  Reason = "SHORT_ID_TAKEN"
+	ReasonAliasTaken    Reason = "ALIAS_TAKEN"
+	ReasonCampaignTaken Reason = "CAMPAIGN_TAKEN"
+
+	// Unauthenticated
+	ReasonMissingAPIKey Reason = "MISSING_API_KEY"
+	ReasonInvalidAPIKey Reason = "INVALID_API_KEY"
+
+	// PermissionDenied
+	ReasonPasswordRequired Reason = "PASSWORD_REQUIRED"
+	ReasonWrongPassword    Reason = "WRONG_PASSWORD"
+	ReasonScopeDenied      Reason = "SCOPE_DENIED"
+	ReasonTenantDenied     Reason = "TENANT_DENIED"
+
+	// FailedPrecondition
+	ReasonURLExpired      Reason = "URL_EXPIRED"
+	ReasonURLDisabled     Reason = "URL_DISABLED"
+	ReasonURLExhausted    Reason = "URL_EXHAUSTED"
+	ReasonURLQuarantined  Reason = "URL_QUARANTINED"
+	ReasonURLNotYetActive Reason = "URL_NOT_YET_ACTIVE"
+
+	// ResourceExhausted
+	ReasonSlowConsumer    Reason = "SLOW_CONSUMER"
+	ReasonTooManyAttempts Reason = "TOO_MANY_ATTEMPTS"
+	ReasonRateLimited     Reason = "RATE_LIMITED"
+	ReasonQuotaExceeded   Reason = "QUOTA_EXCEEDED"
+
+	// Internal
+	ReasonInternal Reason = "INTERNAL"
+
+	// Unimplemented
+	ReasonNotSupported Reason = "NOT_SUPPORTED"
+
+	// Unavailable
+	ReasonBackendUnavailable Reason = "BACKEND_UNAVAILABLE"
+	ReasonShortIDExhausted   Reason = "SHORT_ID_EXHAUSTED"
+	ReasonShuttingDown       Reason = "SHUTTING_DOWN"
+	ReasonCheckUnavailable   Reason = "CHECK_UNAVAILABLE"
+)
+
+// FromStorage maps an error returned by URLStorage onto the catalog
+// shortID, if set, names the link in the message and the ErrorInfo metadata.
+// Errors that are already catalog errors are returned unchanged; anything
+// unrecognised is treated as a backend outage
+func FromStorage(err error, shortID string) error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var mapped *Error
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		mapped = NotFound(ReasonURLNotFound, "short URL not found: %s", shortID)
+	case errors.Is(err, storage.ErrInvalidURL):
+		mapped = InvalidArgument(ReasonInvalidURL, "invalid URL")
+	case errors.Is(err, storage.ErrAlreadyExists):
+		mapped = AlreadyExists(ReasonShortIDTaken, "short ID already taken: %s", shortID)
+	case errors.Is(err, storage.ErrClicksExhausted):
+		mapped = FailedPrecondition(ReasonURLExhausted, "short URL click limit reached: %s", shortID)
+	case errors.Is(err, storage.ErrQuotaExceeded):
+		mapped = ResourceExhausted(ReasonQuotaExceeded, "link quota exceeded")
+	case errors.Is(err, storage.ErrUnsupported):
+		mapped = New(codes.Unimplemented, ReasonNotSupported, "operation not supported by the configured storage")
+	case errors.Is(err, context.DeadlineExceeded):
+		mapped = New(codes.DeadlineExceeded, ReasonBackendUnavailable, "storage backend timed out")
+	case errors.Is(err, context.Canceled):
+		mapped = New(codes.Canceled, ReasonBackendUnavailable, "request canceled")
+	default:
+		mapped = Unavailable(ReasonBackendUnavailable, "storage backend unavailable")
+	}
+
+	if shortID != "" {
+		mapped.WithMetadata("short_id", shortID)
+	}
+	return mapped.WithCause(err)
+}
+
+// ForClient returns the reason and client-facing message of an error, for
+// places that report errors other than as a gRPC status, such as the items of
+// a batch. Errors outside the catalog are reported as internal without their text
+func ForClient(err error) (Reason, string) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.reason, appErr.message
+	}
+	return ReasonInternal, "internal error"
+}
+
+// ReasonOf returns the reason of a catalog error, or an empty string
+func ReasonOf(err error) Reason {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.reason
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package apperrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromStorage(t *testing.T) {
+	tests := []struct {
+		err    error
+		code   codes.Code
+		reason Reason
+	}{
+		{storage.ErrNotFound, codes.NotFound, ReasonURLNotFound},
+		{fmt.Errorf("lookup: %w", storage.ErrNotFound), codes.NotFound, ReasonURLNotFound},
+		{storage.ErrInvalidURL, codes.InvalidArgument, ReasonInvalidURL},
+		{storage.ErrAlreadyExists, codes.AlreadyExists, ReasonShortIDTaken},
+		{storage.ErrClicksExhausted, codes.FailedPrecondition, ReasonURLExhausted},
+		{storage.ErrQuotaExceeded, codes.ResourceExhausted, ReasonQuotaExceeded},
+		{storage.ErrUnsupported, codes.Unimplemented, ReasonNotSupported},
+		{context.DeadlineExceeded, codes.DeadlineExceeded, ReasonBackendUnavailable},
+		{errors.New("dial tcp: connection refused"), codes.Unavailable, ReasonBackendUnavailable},
+		{FailedPrecondition(ReasonURLExpired, "expired"), codes.FailedPrecondition, ReasonURLExpired},
+	}
+
+	for _, test := range tests {
+		err := FromStorage(test.err, "abc")
+		if code := status.Code(err); code != test.code {
+			t.Errorf("FromStorage(%v): expected code %v, got %v", test.err, test.code, code)
+		}
+		if reason := ReasonOf(err); reason != test.reason {
+			t.Errorf("FromStorage(%v): expected reason %s, got %s", test.err, test.reason, reason)
+		}
+	}
+
+	if FromStorage(nil, "abc") != nil {
+		t.Errorf("Expected nil for a nil error")
+	}
+}
+
+func TestError_GRPCStatus(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := Unavailable(ReasonBackendUnavailable, "storage backend unavailable").
+		WithMetadata("short_id", "abc").
+		WithCause(cause)
+
+	if !errors.Is(err, cause) {
+		t.Errorf("Expected the cause to be reachable with errors.Is")
+	}
+
+	st := status.Convert(err)
+	if st.Message() != "storage backend unavailable" {
+		t.Errorf("Expected the cause to stay out of the client message, got %q", st.Message())
+	}
+
+	// Wrapping keeps the code and details, as grpc-go unwraps errors itself
+	st = status.Convert(fmt.Errorf("handler: %w", err))
+	if st.Code() != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", st.Code())
+	}
+
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("Expected 1 status detail, got %d", len(details))
+	}
+	info, ok := details[0].(*errdetails.ErrorInfo)
+	if !ok {
+		t.Fatalf("Expected ErrorInfo detail, got %T", details[0])
+	}
+	if info.Reason != string(ReasonBackendUnavailable) || info.Domain != Domain || info.Metadata["short_id"] != "abc" {
+		t.Errorf("Unexpected ErrorInfo: %v", info)
+	}
+}
+
+func TestForClient(t *testing.T) {
+	err := FromStorage(errors.New("dial tcp 10.0.0.5:5432: connection refused"), "abc")
+	reason, message := ForClient(fmt.Errorf("batch: %w", err))
+	if reason != ReasonBackendUnavailable || message != "storage backend unavailable" {
+		t.Errorf("Expected the cause to stay out of the client message, got %s %q", reason, message)
+	}
+
+	reason, message = ForClient(errors.New("pq: relation \"urls\" does not exist"))
+	if reason != ReasonInternal || message != "internal error" {
+		t.Errorf("Expected an error outside the catalog to be reported as internal, got %s %q", reason, message)
+	}
+}
@@ -0,0 +1,132 @@
+package apperrors
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is a domain error with a gRPC status code and a machine-readable reason
+// Returned from a gRPC handler it becomes a status carrying a google.rpc.ErrorInfo
+// detail; the wrapped cause is logged but never sent to clients
+type Error struct {
+	code     codes.Code
+	reason   Reason
+	message  string
+	metadata map[string]string
+	cause    error
+}
+
+// New creates an error with a code, reason and client-facing message
+func New(code codes.Code, reason Reason, format string, args ...interface{}) *Error {
+	return &Error{
+		code:    code,
+		reason:  reason,
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// InvalidArgument creates an error for a malformed request
+func InvalidArgument(reason Reason, format string, args ...interface{}) *Error {
+	return New(codes.InvalidArgument, reason, format, args...)
+}
+
+// NotFound creates an error for a missing resource
+func NotFound(reason Reason, format string, args ...interface{}) *Error {
+	return New(codes.NotFound, reason, format, args...)
+}
+
+// AlreadyExists creates an error for a resource that cannot be created twice
+func AlreadyExists(reason Reason, format string, args ...interface{}) *Error {
+	return New(codes.AlreadyExists, reason, format, args...)
+}
+
+// Unauthenticated creates an error for a caller without valid credentials
+func Unauthenticated(reason Reason, format string, args ...interface{}) *Error {
+	return New(codes.Unauthenticated, reason, format, args...)
+}
+
+// PermissionDenied creates an error for a caller not allowed to use a resource
+func PermissionDenied(reason Reason, format string, args ...interface{}) *Error {
+	return New(codes.PermissionDenied, reason, format, args...)
+}
+
+// ResourceExhausted creates an error for a caller that hit a limit
+func ResourceExhausted(reason Reason, format string, args ...interface{}) *Error {
+	return New(codes.ResourceExhausted, reason, format, args...)
+}
+
+// FailedPrecondition creates an error for a resource in the wrong state
+func FailedPrecondition(reason Reason, format string, args ...interface{}) *Error {
+	return New(codes.FailedPrecondition, reason, format, args...)
+}
+
+// Internal creates an error for a failure the client cannot do anything about
+func Internal(reason Reason, format string, args ...interface{}) *Error {
+	return New(codes.Internal, reason, format, args...)
+}
+
+// Unavailable creates an error for a transient failure the client may retry
+func Unavailable(reason Reason, format string, args ...interface{}) *Error {
+	return New(codes.Unavailable, reason, format, args...)
+}
+
+// WithMetadata attaches a key/value pair to the ErrorInfo detail
+func (e *Error) WithMetadata(key string, value string) *Error {
+	if e.metadata == nil {
+		e.metadata = make(map[string]string)
+	}
+	e.metadata[key] = value
+	return e
+}
+
+// WithCause records the underlying error for logs and errors.Is
+func (e *Error) WithCause(err error) *Error {
+	e.cause = err
+	return e
+}
+
+// Error implements error; it includes the cause, unlike the client-facing message
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.message + ": " + e.cause.Error()
+	}
+	return e.message
+}
+
+// Unwrap returns the cause
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Code returns the gRPC status code
+func (e *Error) Code() codes.Code {
+	return e.code
+}
+
+// Message returns the client-facing message, which never includes the cause
+func (e *Error) Message() string {
+	return e.message
+}
+
+// Reason returns the machine-readable reason
+func (e *Error) Reason() Reason {
+	return e.reason
+}
+
+// GRPCStatus converts the error into a gRPC status with an ErrorInfo detail
+// grpc-go calls it for errors returned from handlers
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.code, e.message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   string(e.reason),
+		Domain:   Domain,
+		Metadata: e.metadata,
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
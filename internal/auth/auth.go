@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/proto"
+)
+
+// MetadataKey is the gRPC metadata key that carries the API key,
+// either bare or as "Bearer <key>"
+const MetadataKey = "authorization"
+
+// Scopes a key can be granted; models.ScopeAll grants all of them
+const (
+	ScopeShorten = "shorten"
+	ScopeExpand  = "expand"
+	ScopeRead    = "read"
+	ScopeManage  = "manage"
+)
+
+// Sources of API keys for config.AuthConfig.Source
+const (
+	SourceConfig   = "config"
+	SourcePostgres = "postgres"
+)
+
+// MethodScopes maps each RPC to the scope it needs
+// Methods missing from the map need models.ScopeAll
+var MethodScopes = map[string]string{
+	proto.URLService_ShortenURL_FullMethodName:       ScopeShorten,
+	proto.URLService_BatchShortenURLs_FullMethodName: ScopeShorten,
+	proto.URLService_ExpandURL_FullMethodName:        ScopeExpand,
+	proto.URLService_BatchExpandURLs_FullMethodName:  ScopeExpand,
+	proto.URLService_GetURLInfo_FullMethodName:       ScopeRead,
+	proto.URLService_ListURLs_FullMethodName:         ScopeRead,
+	proto.URLService_WatchClicks_FullMethodName:      ScopeRead,
+	proto.URLService_GetUsage_FullMethodName:         ScopeRead,
+	proto.URLService_ListURLsByTag_FullMethodName:    ScopeRead,
+	proto.URLService_GetCampaign_FullMethodName:      ScopeRead,
+	proto.URLService_DeleteURL_FullMethodName:        ScopeManage,
+	proto.URLService_DisableURL_FullMethodName:       ScopeManage,
+	proto.URLService_EnableURL_FullMethodName:        ScopeManage,
+	proto.URLService_UpdateURL_FullMethodName:        ScopeManage,
+	proto.URLService_AddTags_FullMethodName:          ScopeManage,
+	proto.URLService_RemoveTags_FullMethodName:       ScopeManage,
+	proto.URLService_CreateCampaign_FullMethodName:   ScopeManage,
+	proto.URLService_UpdateCampaign_FullMethodName:   ScopeManage,
+	proto.URLService_SetURLCampaign_FullMethodName:   ScopeManage,
+	proto.URLService_SetURLTargeting_FullMethodName:  ScopeManage,
+	proto.URLService_SetURLVariants_FullMethodName:   ScopeManage,
+}
+
+// ScopeFor returns the scope needed to call a method
+func ScopeFor(fullMethod string) string {
+	if scope, ok := MethodScopes[fullMethod]; ok {
+		return scope
+	}
+	return models.ScopeAll
+}
+
+// HashKey returns the hex-encoded SHA-256 of an API key, as stored
+// Keys are random and long, so a plain digest is enough to keep them secret
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+type contextKey struct{}
+
+// WithIdentity returns a context carrying the key that authenticated the request
+func WithIdentity(ctx context.Context, key *models.APIKey) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// FromContext returns the key that authenticated the request, if any
+func FromContext(ctx context.Context) (*models.APIKey, bool) {
+	key, ok := ctx.Value(contextKey{}).(*models.APIKey)
+	return key, ok && key != nil
+}
+
+// NewKeyStore creates the key store selected by the auth configuration
+// PostgreSQL keys are read through the link storage's connection pool when
+// shared keeps them, and through a pool of their own otherwise; either way
+// lookups are cached for cfg.Auth.CacheTTL
+func NewKeyStore(cfg *config.Config, shared storage.URLStorage) (storage.APIKeyStorage, error) {
+	switch cfg.Auth.Source {
+	case SourcePostgres:
+		if keys, ok := shared.(storage.APIKeyStorage); ok {
+			return NewCachedKeyStore(keys, cfg.Auth.CacheTTL), nil
+		}
+		store, err := storage.NewPostgresStorage(cfg)
+		if err != nil {
+			return nil, err
+		}
+		cached := NewCachedKeyStore(store, cfg.Auth.CacheTTL)
+		cached.closer = store
+		return cached, nil
+	case SourceConfig, "":
+		store, err := NewStaticKeyStore(cfg.Auth.Keys)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown auth source: %s", cfg.Auth.Source)
+	}
+}
+
+// StaticKeyStore serves API keys listed in the configuration
+type StaticKeyStore struct {
+	keys map[string]*models.APIKey
+}
+
+// NewStaticKeyStore creates a StaticKeyStore, rejecting malformed entries
+func NewStaticKeyStore(entries []config.APIKeyConfig) (*StaticKeyStore, error) {
+	keys := make(map[string]*models.APIKey, len(entries))
+	for _, entry := range entries {
+		if entry.ID == "" {
+			return nil, fmt.Errorf("api key without id")
+		}
+		hash := strings.ToLower(entry.Hash)
+		if raw, err := hex.DecodeString(hash); err != nil || len(raw) != sha256.Size {
+			return nil, fmt.Errorf("api key %s: hash must be a hex-encoded SHA-256", entry.ID)
+		}
+		for _, scope := range entry.Scopes {
+			if !validScope(scope) {
+				return nil, fmt.Errorf("api key %s: unknown scope %q", entry.ID, scope)
+			}
+		}
+
+		keys[hash] = &models.APIKey{
+			ID:       entry.ID,
+			KeyHash:  hash,
+			TenantID: entry.Tenant,
+			Scopes:   entry.Scopes,
+		}
+	}
+	return &StaticKeyStore{keys: keys}, nil
+}
+
+// GetAPIKey implements storage.APIKeyStorage.GetAPIKey
+func (s *StaticKeyStore) GetAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	key, ok := s.keys[keyHash]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return key, nil
+}
+
+// validScope reports whether a scope is one the server checks
+func validScope(scope string) bool {
+	switch scope {
+	case ScopeShorten, ScopeExpand, ScopeRead, ScopeManage, models.ScopeAll:
+		return true
+	}
+	return false
+}
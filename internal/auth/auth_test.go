@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/proto"
+)
+
+func TestStaticKeyStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewStaticKeyStore([]config.APIKeyConfig{
+		{ID: "gateway", Hash: strings.ToUpper(HashKey("secret")), Scopes: []string{ScopeShorten, ScopeExpand}},
+	})
+	if err != nil {
+		t.Fatalf("NewStaticKeyStore returned unexpected error: %v", err)
+	}
+
+	// Hashes are matched regardless of case
+	key, err := store.GetAPIKey(ctx, HashKey("secret"))
+	if err != nil || key.ID != "gateway" {
+		t.Fatalf("GetAPIKey() = %v, %v; expected gateway", key, err)
+	}
+	if _, err := store.GetAPIKey(ctx, HashKey("guess")); err != storage.ErrNotFound {
+		t.Errorf("Expected ErrNotFound for unknown key, got %v", err)
+	}
+}
+
+func TestNewStaticKeyStore_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry config.APIKeyConfig
+	}{
+		{"missing id", config.APIKeyConfig{Hash: HashKey("secret")}},
+		{"plaintext key", config.APIKeyConfig{ID: "gateway", Hash: "secret"}},
+		{"unknown scope", config.APIKeyConfig{ID: "gateway", Hash: HashKey("secret"), Scopes: []string{"delete"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewStaticKeyStore([]config.APIKeyConfig{tt.entry}); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestScopeFor(t *testing.T) {
+	key := &models.APIKey{ID: "gateway", Scopes: []string{ScopeExpand}}
+
+	if !key.HasScope(ScopeFor(proto.URLService_ExpandURL_FullMethodName)) {
+		t.Errorf("Expected expand scope to allow ExpandURL")
+	}
+	if key.HasScope(ScopeFor(proto.URLService_ShortenURL_FullMethodName)) {
+		t.Errorf("Expected expand scope to deny ShortenURL")
+	}
+
+	// Unknown methods need every scope
+	if key.HasScope(ScopeFor("/grpc.health.v1.Health/Check")) {
+		t.Errorf("Expected unknown methods to be denied")
+	}
+	admin := &models.APIKey{ID: "admin", Scopes: []string{models.ScopeAll}}
+	if !admin.HasScope(ScopeFor("/grpc.health.v1.Health/Check")) {
+		t.Errorf("Expected the * scope to allow every method")
+	}
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+)
+
+// maxCachedKeys bounds the cache before expired entries are swept
+const maxCachedKeys = 10000
+
+// CachedKeyStore remembers the answers of another key store for a while, so
+// authenticating a request rarely costs a database round trip. Unknown keys
+// are cached too, so repeated bad keys do not reach the database either.
+// A revoked key keeps working until its entry expires
+type CachedKeyStore struct {
+	keys   storage.APIKeyStorage
+	ttl    time.Duration
+	now    func() time.Time
+	closer io.Closer
+
+	mutex   sync.Mutex
+	entries map[string]cachedKey
+}
+
+// cachedKey is a cached lookup; a nil key means the key is unknown
+type cachedKey struct {
+	key       *models.APIKey
+	expiresAt time.Time
+}
+
+// NewCachedKeyStore creates a CachedKeyStore in front of keys
+// A non-positive ttl passes every lookup through
+func NewCachedKeyStore(keys storage.APIKeyStorage, ttl time.Duration) *CachedKeyStore {
+	return &CachedKeyStore{
+		keys:    keys,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cachedKey),
+	}
+}
+
+// GetAPIKey implements storage.APIKeyStorage.GetAPIKey
+// Lookups that fail for other reasons than an unknown key are not cached
+func (s *CachedKeyStore) GetAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	if s.ttl <= 0 {
+		return s.keys.GetAPIKey(ctx, keyHash)
+	}
+
+	s.mutex.Lock()
+	entry, ok := s.entries[keyHash]
+	s.mutex.Unlock()
+
+	if ok && s.now().Before(entry.expiresAt) {
+		if entry.key == nil {
+			return nil, storage.ErrNotFound
+		}
+		return entry.key, nil
+	}
+
+	key, err := s.keys.GetAPIKey(ctx, keyHash)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+	s.store(keyHash, key)
+	return key, err
+}
+
+// store caches the answer for a key hash
+func (s *CachedKeyStore) store(keyHash string, key *models.APIKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	if len(s.entries) >= maxCachedKeys {
+		for hash, entry := range s.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(s.entries, hash)
+			}
+		}
+		// Still full of live entries, most likely random keys; start over
+		if len(s.entries) >= maxCachedKeys {
+			s.entries = make(map[string]cachedKey)
+		}
+	}
+	s.entries[keyHash] = cachedKey{key: key, expiresAt: now.Add(s.ttl)}
+}
+
+// Close closes the key store behind the cache if the cache owns it
+func (s *CachedKeyStore) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
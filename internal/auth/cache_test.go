@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+)
+
+// countingKeyStore serves one key and counts its lookups
+type countingKeyStore struct {
+	key     *models.APIKey
+	err     error
+	lookups int
+}
+
+func (s *countingKeyStore) GetAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	s.lookups++
+	if s.err != nil {
+		return nil, s.err
+	}
+	if keyHash != s.key.KeyHash {
+		return nil, storage.ErrNotFound
+	}
+	return s.key, nil
+}
+
+func TestCachedKeyStore(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingKeyStore{key: &models.APIKey{ID: "gateway", KeyHash: HashKey("secret")}}
+	cached := NewCachedKeyStore(inner, time.Minute)
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+
+	// Known and unknown keys are both looked up once
+	for i := 0; i < 3; i++ {
+		if key, err := cached.GetAPIKey(ctx, HashKey("secret")); err != nil || key.ID != "gateway" {
+			t.Fatalf("Expected the gateway key, got %v (%v)", key, err)
+		}
+		if _, err := cached.GetAPIKey(ctx, HashKey("nope")); !errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("Expected ErrNotFound for an unknown key, got %v", err)
+		}
+	}
+	if inner.lookups != 2 {
+		t.Errorf("Expected 2 lookups, got %d", inner.lookups)
+	}
+
+	// Entries expire after the TTL
+	now = now.Add(time.Minute)
+	if _, err := cached.GetAPIKey(ctx, HashKey("secret")); err != nil {
+		t.Fatalf("GetAPIKey returned unexpected error: %v", err)
+	}
+	if inner.lookups != 3 {
+		t.Errorf("Expected an expired entry to be looked up again, got %d lookups", inner.lookups)
+	}
+
+	// Backend failures are not cached
+	inner.err = errors.New("connection refused")
+	for i := 0; i < 2; i++ {
+		if _, err := cached.GetAPIKey(ctx, HashKey("other")); err == nil || errors.Is(err, storage.ErrNotFound) {
+			t.Fatalf("Expected the backend error, got %v", err)
+		}
+	}
+	if inner.lookups != 5 {
+		t.Errorf("Expected failed lookups to be retried, got %d lookups", inner.lookups)
+	}
+}
+
+func TestNewKeyStore_SharesStorage(t *testing.T) {
+	inner := &countingKeyStore{key: &models.APIKey{ID: "gateway", KeyHash: HashKey("secret")}}
+	shared := struct {
+		storage.URLStorage
+		*countingKeyStore
+	}{storage.NewMemoryStorage(), inner}
+
+	cfg := &config.Config{Auth: config.AuthConfig{Source: SourcePostgres, CacheTTL: time.Minute}}
+	keys, err := NewKeyStore(cfg, shared)
+	if err != nil {
+		t.Fatalf("NewKeyStore returned unexpected error: %v", err)
+	}
+	if _, err := keys.GetAPIKey(context.Background(), HashKey("secret")); err != nil {
+		t.Fatalf("GetAPIKey returned unexpected error: %v", err)
+	}
+	if inner.lookups != 1 {
+		t.Errorf("Expected the shared storage to serve the key, got %d lookups", inner.lookups)
+	}
+}
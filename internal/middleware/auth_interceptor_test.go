@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/auth"
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthInterceptor(t *testing.T) {
+	keys, err := auth.NewStaticKeyStore([]config.APIKeyConfig{
+		{ID: "gateway", Hash: auth.HashKey("gw-secret"), Scopes: []string{auth.ScopeShorten, auth.ScopeExpand}},
+		{ID: "acme", Hash: auth.HashKey("acme-secret"), Tenant: "acme", Scopes: []string{"*"}},
+	})
+	if err != nil {
+		t.Fatalf("NewStaticKeyStore returned unexpected error: %v", err)
+	}
+	interceptor := AuthInterceptor(keys)
+
+	tests := []struct {
+		name       string
+		md         metadata.MD
+		method     string
+		wantCode   codes.Code
+		wantKey    string
+		wantTenant string
+	}{
+		{"missing key", metadata.Pairs(), proto.URLService_ExpandURL_FullMethodName, codes.Unauthenticated, "", ""},
+		{"wrong key", metadata.Pairs("authorization", "nope"), proto.URLService_ExpandURL_FullMethodName, codes.Unauthenticated, "", ""},
+		{"bare key", metadata.Pairs("authorization", "gw-secret"), proto.URLService_ExpandURL_FullMethodName, codes.OK, "gateway", tenant.DefaultID},
+		{"bearer key", metadata.Pairs("authorization", "Bearer gw-secret"), proto.URLService_ShortenURL_FullMethodName, codes.OK, "gateway", tenant.DefaultID},
+		{"missing scope", metadata.Pairs("authorization", "gw-secret"), proto.URLService_DeleteURL_FullMethodName, codes.PermissionDenied, "", ""},
+		{"pinned tenant", metadata.Pairs("authorization", "acme-secret"), proto.URLService_DeleteURL_FullMethodName, codes.OK, "acme", "acme"},
+		{"other tenant", metadata.Pairs("authorization", "acme-secret", "x-tenant-id", "globex"), proto.URLService_ExpandURL_FullMethodName, codes.PermissionDenied, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			var gotKey, gotTenant string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if key, ok := auth.FromContext(ctx); ok {
+					gotKey = key.ID
+				}
+				gotTenant = tenant.FromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Expected %v, got %v", tt.wantCode, err)
+			}
+			if gotKey != tt.wantKey || gotTenant != tt.wantTenant {
+				t.Errorf("Handler saw key %q tenant %q; expected %q %q", gotKey, gotTenant, tt.wantKey, tt.wantTenant)
+			}
+		})
+	}
+}
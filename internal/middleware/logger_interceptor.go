@@ -6,10 +6,14 @@ import (
 	"context"
 	"time"
 
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/auth"
 	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/tenant"
 	"github.com/hohotang/shortlink-core/proto"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
@@ -23,14 +27,8 @@ func LoggerInterceptor(baseLogger *zap.Logger) grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		// Extract request metadata
-		requestID := extractRequestID(ctx)
-
 		// Create a request-scoped logger with additional fields
-		reqLogger := baseLogger.With(
-			zap.String("requestID", requestID),
-			zap.String("method", info.FullMethod),
-		)
+		reqLogger := requestLogger(ctx, baseLogger, info.FullMethod)
 
 		// Add request type and basic info
 		reqLogger = addRequestInfo(reqLogger, req)
@@ -47,32 +45,98 @@ func LoggerInterceptor(baseLogger *zap.Logger) grpc.UnaryServerInterceptor {
 		// Execute the handler
 		resp, err := handler(ctxWithLogger, req)
 
-		// Calculate duration
-		duration := time.Since(startTime)
+		logResult(reqLogger, err, time.Since(startTime))
 
-		// Determine status code
-		statusCode := "OK"
-		if err != nil {
-			st, _ := status.FromError(err)
-			statusCode = st.Code().String()
-		}
+		return resp, err
+	}
+}
 
-		// Log completion with appropriate level based on error
-		if err != nil {
-			reqLogger.Error("Request failed",
-				zap.Error(err),
-				zap.String("status", statusCode),
-				zap.Duration("duration", duration),
-			)
-		} else {
-			reqLogger.Info("Request completed",
-				zap.String("status", statusCode),
-				zap.Duration("duration", duration),
-			)
-		}
+// LoggerStreamInterceptor is the streaming counterpart of LoggerInterceptor
+// The stream handler sees the request-scoped logger through the stream context,
+// and the completion line is written when the stream ends
+func LoggerStreamInterceptor(baseLogger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := stream.Context()
+		reqLogger := requestLogger(ctx, baseLogger, info.FullMethod)
 
-		return resp, err
+		startTime := time.Now()
+		reqLogger.Debug("Processing stream")
+
+		err := handler(srv, &contextStream{ServerStream: stream, ctx: logger.WithContext(ctx, reqLogger)})
+
+		logResult(reqLogger, err, time.Since(startTime))
+
+		return err
+	}
+}
+
+// requestLogger derives the request-scoped logger naming the request ID,
+// method, tenant and, when authenticated, the calling API key
+func requestLogger(ctx context.Context, baseLogger *zap.Logger, method string) *zap.Logger {
+	reqLogger := baseLogger.With(
+		zap.String("requestID", extractRequestID(ctx)),
+		zap.String("method", method),
+		zap.String("tenant", tenant.FromContext(ctx)),
+	)
+
+	// Name the caller when the request was authenticated
+	if key, ok := auth.FromContext(ctx); ok {
+		reqLogger = reqLogger.With(zap.String("apiKey", key.ID))
 	}
+	return reqLogger
+}
+
+// logResult logs the completion of a request with a level based on its error
+func logResult(reqLogger *zap.Logger, err error, duration time.Duration) {
+	// Determine status code; status.Code also sees through wrapped catalog errors
+	code := status.Code(err)
+	statusCode := code.String()
+
+	if err == nil {
+		reqLogger.Info("Request completed",
+			zap.String("status", statusCode),
+			zap.Duration("duration", duration),
+		)
+		return
+	}
+
+	fields := []zap.Field{
+		zap.Error(err),
+		zap.String("status", statusCode),
+		zap.Duration("duration", duration),
+	}
+	if reason := apperrors.ReasonOf(err); reason != "" {
+		fields = append(fields, zap.String("reason", string(reason)))
+	}
+
+	// Errors caused by the request are expected and not worth an alert
+	if isClientError(code) {
+		reqLogger.Warn("Request failed", fields...)
+	} else {
+		reqLogger.Error("Request failed", fields...)
+	}
+}
+
+// isClientError reports whether a status code blames the request rather than the server
+func isClientError(code codes.Code) bool {
+	switch code {
+	case codes.Canceled,
+		codes.InvalidArgument,
+		codes.NotFound,
+		codes.AlreadyExists,
+		codes.PermissionDenied,
+		codes.ResourceExhausted,
+		codes.FailedPrecondition,
+		codes.OutOfRange,
+		codes.Unauthenticated:
+		return true
+	}
+	return false
 }
 
 // extractRequestID gets the request ID from context metadata
@@ -94,10 +158,26 @@ func addRequestInfo(log *zap.Logger, req interface{}) *zap.Logger {
 		if typedReq.OriginalUrl != "" {
 			log = log.With(zap.String("originalUrl", typedReq.OriginalUrl))
 		}
+		if typedReq.CustomAlias != "" {
+			log = log.With(zap.String("customAlias", typedReq.CustomAlias))
+		}
+	case *proto.BatchShortenURLsRequest:
+		log = log.With(zap.Int("batchSize", len(typedReq.OriginalUrls)))
+	case *proto.BatchExpandURLsRequest:
+		log = log.With(zap.Int("batchSize", len(typedReq.ShortIds)))
+	case *proto.UpdateURLRequest:
+		log = log.With(
+			zap.String("shortId", typedReq.ShortId),
+			zap.String("originalUrl", typedReq.OriginalUrl))
 	case *proto.ExpandURLRequest:
 		if typedReq.ShortId != "" {
 			log = log.With(zap.String("shortId", typedReq.ShortId))
 		}
+	case interface{ GetShortId() string }:
+		// Management requests that address a single short URL
+		if shortID := typedReq.GetShortId(); shortID != "" {
+			log = log.With(zap.String("shortId", shortID))
+		}
 	}
 
 	return log
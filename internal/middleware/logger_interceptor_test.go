@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoggerStreamInterceptor(t *testing.T) {
+	base := zap.NewNop()
+	interceptor := LoggerStreamInterceptor(base)
+	info := &grpc.StreamServerInfo{FullMethod: proto.URLService_WatchClicks_FullMethodName, IsServerStream: true}
+
+	var got *zap.Logger
+	err := interceptor(nil, &fakeStream{ctx: context.Background()}, info, func(srv interface{}, stream grpc.ServerStream) error {
+		got = logger.FromContext(stream.Context())
+		return status.Error(codes.Canceled, "client went away")
+	})
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("Expected the handler error to pass through, got %v", err)
+	}
+	if got == nil || got == logger.L() {
+		t.Fatal("Expected the stream handler to see a request-scoped logger")
+	}
+}
@@ -25,26 +25,7 @@ func PanicRecoveryInterceptor(baseLogger *zap.Logger) grpc.UnaryServerIntercepto
 		// Recover from any panic
 		defer func() {
 			if r := recover(); r != nil {
-				// Get the request-scoped logger if it exists
-				log := logger.FromContext(ctx)
-				if log == nil {
-					log = baseLogger
-				}
-
-				// Log the panic with stack trace
-				stackTrace := string(debug.Stack())
-				log.Error("Panic recovered in gRPC handler",
-					zap.Any("panic", r),
-					zap.String("method", info.FullMethod),
-					zap.String("stack", stackTrace),
-				)
-
-				// Create an error that will be returned to the client
-				err = status.Errorf(
-					codes.Internal,
-					"Internal server error: %s",
-					"an unexpected error occurred, please contact support if the issue persists",
-				)
+				err = panicError(ctx, baseLogger, info.FullMethod, r)
 			}
 		}()
 
@@ -52,3 +33,47 @@ func PanicRecoveryInterceptor(baseLogger *zap.Logger) grpc.UnaryServerIntercepto
 		return handler(ctx, req)
 	}
 }
+
+// PanicRecoveryStreamInterceptor is the streaming counterpart of PanicRecoveryInterceptor
+// A panic ends the stream with code Internal instead of crashing the process
+func PanicRecoveryStreamInterceptor(baseLogger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = panicError(stream.Context(), baseLogger, info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, stream)
+	}
+}
+
+// panicError logs a recovered panic with its stack trace and returns the
+// error sent to the client in its place
+func panicError(ctx context.Context, baseLogger *zap.Logger, method string, r interface{}) error {
+	// Get the request-scoped logger if it exists
+	log := logger.FromContext(ctx)
+	if log == nil {
+		log = baseLogger
+	}
+
+	// Log the panic with stack trace
+	stackTrace := string(debug.Stack())
+	log.Error("Panic recovered in gRPC handler",
+		zap.Any("panic", r),
+		zap.String("method", method),
+		zap.String("stack", stackTrace),
+	)
+
+	// Create an error that will be returned to the client
+	return status.Errorf(
+		codes.Internal,
+		"Internal server error: %s",
+		"an unexpected error occurred, please contact support if the issue persists",
+	)
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStream is a server stream that only carries a context
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestPanicRecoveryStreamInterceptor(t *testing.T) {
+	interceptor := PanicRecoveryStreamInterceptor(zap.NewNop())
+	info := &grpc.StreamServerInfo{FullMethod: proto.URLService_WatchClicks_FullMethodName, IsServerStream: true}
+
+	err := interceptor(nil, &fakeStream{ctx: context.Background()}, info, func(srv interface{}, stream grpc.ServerStream) error {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("Expected Internal after a panic, got %v", err)
+	}
+}
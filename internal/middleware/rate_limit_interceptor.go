@@ -0,0 +1,156 @@
+// internal/middleware/rate_limit_interceptor.go
+
+package middleware
+
+import (
+	"context"
+	"math"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/auth"
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/ratelimit"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// RetryAfterKey is the response header telling a limited client how many
+// seconds to wait before retrying
+const RetryAfterKey = "retry-after"
+
+// Ways of telling clients apart for config.RateLimitConfig.KeyBy
+const (
+	RateLimitByAPIKey = "api_key"
+	RateLimitByTenant = "tenant"
+	RateLimitByIP     = "ip"
+)
+
+// RateLimitInterceptor creates a gRPC interceptor that gives every client a
+// token bucket per method and rejects requests once it is empty
+// Limiter failures let requests through rather than taking the service down
+func RateLimitInterceptor(limiter ratelimit.Limiter, cfg config.RateLimitConfig, log *zap.Logger) grpc.UnaryServerInterceptor {
+	limits := newRateLimits(limiter, cfg, log)
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if retryAfter, err := limits.check(ctx, info.FullMethod); err != nil {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterKey, retryAfter))
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamInterceptor is the streaming counterpart of RateLimitInterceptor
+// Only opening a stream takes a token
+func RateLimitStreamInterceptor(limiter ratelimit.Limiter, cfg config.RateLimitConfig, log *zap.Logger) grpc.StreamServerInterceptor {
+	limits := newRateLimits(limiter, cfg, log)
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if retryAfter, err := limits.check(stream.Context(), info.FullMethod); err != nil {
+			_ = stream.SetHeader(metadata.Pairs(RetryAfterKey, retryAfter))
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// rateLimits resolves the bucket and limit of a request
+type rateLimits struct {
+	limiter  ratelimit.Limiter
+	keyBy    string
+	fallback ratelimit.Limit
+	methods  map[string]ratelimit.Limit
+	log      *zap.Logger
+}
+
+// newRateLimits converts the configured limits, keyed by lowercase method name
+func newRateLimits(limiter ratelimit.Limiter, cfg config.RateLimitConfig, log *zap.Logger) *rateLimits {
+	methods := make(map[string]ratelimit.Limit, len(cfg.Methods))
+	for name, limit := range cfg.Methods {
+		methods[strings.ToLower(name)] = ratelimit.Limit{Rate: limit.Rate, Burst: limit.Burst}
+	}
+	return &rateLimits{
+		limiter:  limiter,
+		keyBy:    cfg.KeyBy,
+		fallback: ratelimit.Limit{Rate: cfg.Default.Rate, Burst: cfg.Default.Burst},
+		methods:  methods,
+		log:      log,
+	}
+}
+
+// check takes a token for the request, returning the retry-after seconds and
+// a ResourceExhausted error if the client is over its limit
+func (l *rateLimits) check(ctx context.Context, fullMethod string) (string, error) {
+	method := strings.ToLower(path.Base(fullMethod))
+	limit, ok := l.methods[method]
+	if !ok {
+		limit = l.fallback
+	}
+	if limit.Unlimited() {
+		return "", nil
+	}
+
+	client := l.clientKey(ctx)
+	allowed, wait, err := l.limiter.Allow(ctx, method+":"+client, limit)
+	if err != nil {
+		l.log.Warn("Rate limiter unavailable, letting request through",
+			zap.String("method", fullMethod),
+			zap.Error(err))
+		return "", nil
+	}
+	if allowed {
+		return "", nil
+	}
+
+	retryAfter := strconv.Itoa(int(math.Max(1, math.Ceil(wait.Seconds()))))
+	l.log.Warn("Request rate limited",
+		zap.String("method", fullMethod),
+		zap.String("client", client),
+		zap.Duration("wait", wait.Round(time.Millisecond)))
+	return retryAfter, apperrors.ResourceExhausted(apperrors.ReasonRateLimited, "rate limit exceeded for %s", path.Base(fullMethod)).
+		WithMetadata("method", path.Base(fullMethod)).
+		WithMetadata("retry_after_seconds", retryAfter)
+}
+
+// clientKey names the bucket owner of a request
+// Requests without an API key are limited by peer IP
+func (l *rateLimits) clientKey(ctx context.Context) string {
+	switch l.keyBy {
+	case RateLimitByTenant:
+		return "tenant:" + tenant.FromContext(ctx)
+	case RateLimitByAPIKey:
+		if key, ok := auth.FromContext(ctx); ok {
+			return "key:" + key.ID
+		}
+	}
+	return "ip:" + peerIP(ctx)
+}
+
+// peerIP returns the IP address of the caller, without the port
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/auth"
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/ratelimit"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitInterceptor(t *testing.T) {
+	interceptor := RateLimitInterceptor(ratelimit.NewMemoryLimiter(), config.RateLimitConfig{
+		KeyBy: RateLimitByAPIKey,
+		Methods: map[string]config.RateLimit{
+			"shortenurl": {Rate: 0.01, Burst: 2},
+		},
+	}, zap.NewNop())
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(ctx context.Context, method string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	gateway := auth.WithIdentity(context.Background(), &models.APIKey{ID: "gateway"})
+	for i := 0; i < 2; i++ {
+		if err := call(gateway, proto.URLService_ShortenURL_FullMethodName); err != nil {
+			t.Fatalf("Request %d returned unexpected error: %v", i+1, err)
+		}
+	}
+
+	err := call(gateway, proto.URLService_ShortenURL_FullMethodName)
+	if status.Code(err) != codes.ResourceExhausted || apperrors.ReasonOf(err) != apperrors.ReasonRateLimited {
+		t.Fatalf("Expected ResourceExhausted RATE_LIMITED, got %v", err)
+	}
+	var info *errdetails.ErrorInfo
+	for _, detail := range status.Convert(err).Details() {
+		if d, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = d
+		}
+	}
+	if info == nil || info.Metadata["retry_after_seconds"] != "100" {
+		t.Errorf("Expected retry_after_seconds 100, got %v", info)
+	}
+
+	// Methods without a limit and other clients are not affected
+	if err := call(gateway, proto.URLService_ExpandURL_FullMethodName); err != nil {
+		t.Errorf("Unlimited method returned unexpected error: %v", err)
+	}
+	other := auth.WithIdentity(context.Background(), &models.APIKey{ID: "batch-job"})
+	if err := call(other, proto.URLService_ShortenURL_FullMethodName); err != nil {
+		t.Errorf("Another API key returned unexpected error: %v", err)
+	}
+
+	// Unauthenticated callers fall back to their IP
+	anonymous := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4321}})
+	if got := newRateLimits(nil, config.RateLimitConfig{KeyBy: RateLimitByAPIKey}, zap.NewNop()).clientKey(anonymous); got != "ip:10.0.0.1" {
+		t.Errorf("Expected client key ip:10.0.0.1, got %s", got)
+	}
+}
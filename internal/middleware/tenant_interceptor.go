@@ -0,0 +1,72 @@
+// internal/middleware/tenant_interceptor.go
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TenantInterceptor creates a gRPC interceptor that scopes each request to the
+// tenant named in the x-tenant-id metadata, or the default tenant if absent
+func TenantInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := withTenant(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// TenantStreamInterceptor is the streaming counterpart of TenantInterceptor
+func TenantStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := withTenant(stream.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// withTenant reads the tenant from the incoming metadata and stores it in the context
+func withTenant(ctx context.Context) (context.Context, error) {
+	tenantID := tenant.DefaultID
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tenant.MetadataKey); len(values) > 0 && values[0] != "" {
+			tenantID = values[0]
+		}
+	}
+
+	if err := tenant.Validate(tenantID); err != nil {
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidTenant, "%s", err.Error()).
+			WithMetadata("tenant_id", tenantID)
+	}
+	return tenant.WithContext(ctx, tenantID), nil
+}
+
+// contextStream is a server stream whose context was replaced by an interceptor
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the replaced context
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,29 @@
+package models
+
+// ScopeAll grants every scope
+const ScopeAll = "*"
+
+// APIKey is a caller credential. Only the SHA-256 of the key is kept
+type APIKey struct {
+	// ID names the key in logs; it is not secret
+	ID string `json:"id"`
+
+	// KeyHash is the hex-encoded SHA-256 of the key
+	KeyHash string `json:"key_hash"`
+
+	// TenantID pins the key to one tenant, empty if it may act on any tenant
+	TenantID string `json:"tenant_id,omitempty"`
+
+	// Scopes lists what the key may do, such as "shorten" or "expand"
+	Scopes []string `json:"scopes"`
+}
+
+// HasScope reports whether the key was granted a scope, directly or through ScopeAll
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope || granted == ScopeAll {
+			return true
+		}
+	}
+	return false
+}
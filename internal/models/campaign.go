@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Campaign is a named set of UTM parameters shared by many links
+// The parameters are added to the destination when a link resolves, so
+// changing a campaign affects every link attached to it
+type Campaign struct {
+	// ID is the caller-chosen identifier, unique per tenant
+	ID string `json:"id"`
+
+	// Name is a human-readable label
+	Name string `json:"name,omitempty"`
+
+	// Params are the utm_* query parameters keyed by lowercase name
+	Params map[string]string `json:"params"`
+
+	// CreatedAt and UpdatedAt are set by the storage
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Click is a single resolution of a short link
+type Click struct {
+	// TenantID is the namespace of the link
+	TenantID string `json:"tenant_id"`
+
+	// ShortID is the link that was resolved
+	ShortID string `json:"short_id"`
+
+	// OriginalURL is the destination the visitor was sent to
+	OriginalURL string `json:"original_url"`
+
+	// ClickedAt is the moment the link was resolved
+	ClickedAt time.Time `json:"clicked_at"`
+
+	// Referrer, UserAgent and ClientIP describe the visitor as reported by
+	// the caller; any of them may be empty
+	Referrer  string `json:"referrer,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	ClientIP  string `json:"client_ip,omitempty"`
+
+	// Variant is the ID of the variant served by a split link, empty otherwise
+	Variant string `json:"variant,omitempty"`
+}
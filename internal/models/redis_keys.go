@@ -1,10 +1,83 @@
 package models
 
+import "github.com/hohotang/shortlink-core/internal/tenant"
+
 // Redis key constants for URL shortener
 const (
-	// ReverseURLsKey is the hash that maps original URLs to short IDs
+	// ReverseURLsKey is the hash that maps canonical URLs to short IDs
 	ReverseURLsKey = "reverse_urls"
 
 	// ShortIDKeyPrefix is the prefix for keys that store short ID data
 	ShortIDKeyPrefix = "url:"
+
+	// RemainingClicksKeyPrefix is the prefix for the click countdown of click-limited links
+	RemainingClicksKeyPrefix = "remaining_clicks:"
+
+	// AccessKeyPrefix is the prefix for the click count and last access time of a link
+	AccessKeyPrefix = "access:"
+
+	// RateLimitKeyPrefix is the prefix for the token buckets of the rate limiter
+	RateLimitKeyPrefix = "rate_limit:"
+
+	// UsageKeyPrefix is the prefix for the link quota counters of a tenant
+	UsageKeyPrefix = "usage:"
+
+	// TagKeyPrefix is the prefix for the sorted sets of short IDs carrying a tag
+	TagKeyPrefix = "tag:"
+
+	// LinkTagsKeyPrefix is the prefix for the sets of tags on a link
+	LinkTagsKeyPrefix = "tags:"
+
+	// CampaignKeyPrefix is the prefix for keys that store campaigns
+	CampaignKeyPrefix = "campaign:"
 )
+
+// ShortIDKey returns the key of a link in a tenant's namespace
+func ShortIDKey(tenantID string, shortID string) string {
+	return ShortIDKeyPrefix + tenantSegment(tenantID) + shortID
+}
+
+// ReverseURLsKeyFor returns the reverse mapping hash of a tenant
+func ReverseURLsKeyFor(tenantID string) string {
+	if tenantID == tenant.DefaultID {
+		return ReverseURLsKey
+	}
+	return ReverseURLsKey + ":" + tenantID
+}
+
+// RemainingClicksKey returns the click countdown key of a link in a tenant's namespace
+func RemainingClicksKey(tenantID string, shortID string) string {
+	return RemainingClicksKeyPrefix + tenantSegment(tenantID) + shortID
+}
+
+// AccessKey returns the access stats key of a link in a tenant's namespace
+func AccessKey(tenantID string, shortID string) string {
+	return AccessKeyPrefix + tenantSegment(tenantID) + shortID
+}
+
+// TagKey returns the index of short IDs carrying a tag in a tenant's namespace
+// Tags never contain ':', so they cannot be mistaken for a tenant segment
+func TagKey(tenantID string, tag string) string {
+	return TagKeyPrefix + tenantSegment(tenantID) + tag
+}
+
+// LinkTagsKey returns the set of tags on a link in a tenant's namespace
+func LinkTagsKey(tenantID string, shortID string) string {
+	return LinkTagsKeyPrefix + tenantSegment(tenantID) + shortID
+}
+
+// CampaignKey returns the key of a campaign in a tenant's namespace
+// Campaign IDs never contain ':', so they cannot be mistaken for a tenant segment
+func CampaignKey(tenantID string, campaignID string) string {
+	return CampaignKeyPrefix + tenantSegment(tenantID) + campaignID
+}
+
+// tenantSegment returns the key segment that scopes a key to a tenant
+// The default tenant keeps the unscoped keys used before tenants existed;
+// short IDs never contain ':', so the two cannot collide
+func tenantSegment(tenantID string) string {
+	if tenantID == tenant.DefaultID {
+		return ""
+	}
+	return tenantID + ":"
+}
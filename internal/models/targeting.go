@@ -0,0 +1,30 @@
+package models
+
+// TargetingRule sends the visitors it matches to another destination
+// A visitor matches when every condition that is set lists one of its
+// attributes; a rule sets at least one condition
+type TargetingRule struct {
+	// Devices are device classes such as "ios", "android" or "desktop"
+	Devices []string `json:"devices,omitempty"`
+
+	// Languages are lowercase language tags; "en" also matches "en-us"
+	Languages []string `json:"languages,omitempty"`
+
+	// Countries are uppercase ISO 3166-1 alpha-2 country codes
+	Countries []string `json:"countries,omitempty"`
+
+	// DestinationURL is where matching visitors are sent
+	DestinationURL string `json:"destination_url"`
+}
+
+// Variant is one destination of a link whose traffic is split between several
+type Variant struct {
+	// ID names the variant in click events
+	ID string `json:"id"`
+
+	// DestinationURL is where visitors served this variant are sent
+	DestinationURL string `json:"destination_url"`
+
+	// Weight is the variant's share of the traffic relative to the other variants
+	Weight uint32 `json:"weight"`
+}
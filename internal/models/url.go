@@ -0,0 +1,117 @@
+package models
+
+import "time"
+
+// URL is a short link record as kept by the storage backends
+type URL struct {
+	// ShortID is the generated or caller-chosen identifier of the link
+	ShortID string `json:"short_id"`
+
+	// OriginalURL is the destination the short ID resolves to
+	OriginalURL string `json:"original_url"`
+
+	// CanonicalURL is the normalized form of OriginalURL that dedup compares,
+	// so different spellings of the same destination share a short ID
+	CanonicalURL string `json:"canonical_url,omitempty"`
+
+	// Dedup marks the link as the shared short ID for its original URL.
+	// Only dedup links are returned by Find, so a plain ShortenURL call
+	// never hands out a custom alias that was created for someone else.
+	Dedup bool `json:"dedup"`
+
+	// ExpiresAt is the moment the link stops resolving, nil if it never expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// NotBefore is the moment the link starts resolving, nil if it resolves
+	// from creation. With ExpiresAt it forms the link's activation window
+	NotBefore *time.Time `json:"not_before,omitempty"`
+
+	// Disabled links are kept but no longer resolve
+	Disabled bool `json:"disabled,omitempty"`
+
+	// CreatedAt is set by the storage when the link is first stored
+	CreatedAt time.Time `json:"created_at"`
+
+	// LastAccessed is the last time the link was resolved, nil if never
+	LastAccessed *time.Time `json:"last_accessed,omitempty"`
+
+	// ClickCount is the number of times the link was resolved
+	ClickCount int64 `json:"click_count,omitempty"`
+
+	// PasswordHash is the salted hash of the password needed to resolve the
+	// link, empty if the link is not protected. The plaintext is never stored.
+	PasswordHash string `json:"password_hash,omitempty"`
+
+	// MaxClicks is the number of times the link may resolve, 0 if unlimited
+	MaxClicks int64 `json:"max_clicks,omitempty"`
+
+	// RemainingClicks counts down from MaxClicks as the link resolves. It is
+	// set by the storage and only changed through ConsumeClick, so cached
+	// copies may be stale
+	RemainingClicks int64 `json:"remaining_clicks,omitempty"`
+
+	// QuarantineReason says why a reputation check flagged the destination,
+	// empty if it was never flagged. Quarantined links are kept but no longer resolve
+	QuarantineReason string `json:"quarantine_reason,omitempty"`
+
+	// CampaignID names the campaign whose UTM parameters are added to the
+	// destination when the link resolves, empty if the link has none
+	CampaignID string `json:"campaign_id,omitempty"`
+
+	// Targeting is the ordered list of rules that pick another destination
+	// for some visitors. The first matching rule wins; visitors no rule
+	// matches are sent to OriginalURL
+	Targeting []TargetingRule `json:"targeting,omitempty"`
+
+	// Variants split the visitors no targeting rule matches between several
+	// destinations by weight, in place of OriginalURL
+	Variants []Variant `json:"variants,omitempty"`
+
+	// Tags are the sorted labels of the link. Backends keep tags apart from
+	// the record, so this is only set where tags are read with the link
+	Tags []string `json:"-"`
+}
+
+// DedupKey returns the URL that dedup compares for this link
+// Links stored without a canonical form fall back to the original URL
+func (u *URL) DedupKey() string {
+	if u.CanonicalURL != "" {
+		return u.CanonicalURL
+	}
+	return u.OriginalURL
+}
+
+// ClickLimited reports whether the link may only resolve a limited number of times
+func (u *URL) ClickLimited() bool {
+	return u.MaxClicks > 0
+}
+
+// Quarantined reports whether a reputation check flagged the destination
+func (u *URL) Quarantined() bool {
+	return u.QuarantineReason != ""
+}
+
+// Targeted reports whether some visitors may be sent elsewhere than OriginalURL
+func (u *URL) Targeted() bool {
+	return len(u.Targeting) > 0
+}
+
+// Split reports whether the link splits its traffic between variants
+func (u *URL) Split() bool {
+	return len(u.Variants) > 0
+}
+
+// PasswordProtected reports whether resolving the link needs a password
+func (u *URL) PasswordProtected() bool {
+	return u.PasswordHash != ""
+}
+
+// Expired reports whether the link has passed its expiry at the given time
+func (u *URL) Expired(now time.Time) bool {
+	return u.ExpiresAt != nil && !now.Before(*u.ExpiresAt)
+}
+
+// Scheduled reports whether the link's activation window has not opened yet at the given time
+func (u *URL) Scheduled(now time.Time) bool {
+	return u.NotBefore != nil && now.Before(*u.NotBefore)
+}
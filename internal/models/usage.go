@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Quota caps how many links a tenant may create; zero limits are unlimited
+type Quota struct {
+	// Daily limits the links created per UTC day
+	Daily int64 `json:"daily,omitempty"`
+
+	// Total limits the links created over the tenant's lifetime
+	Total int64 `json:"total,omitempty"`
+}
+
+// Usage counts the links a tenant has created
+// Deleting a link does not give its count back
+type Usage struct {
+	// Day is the UTC day DailyLinks counts
+	Day time.Time `json:"day"`
+
+	// DailyLinks is the number of links created on Day
+	DailyLinks int64 `json:"daily_links"`
+
+	// TotalLinks is the number of links created since metering started
+	TotalLinks int64 `json:"total_links"`
+}
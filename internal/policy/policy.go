@@ -0,0 +1,135 @@
+package policy
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/hohotang/shortlink-core/internal/config"
+)
+
+// Rules a URL can break, reported in the "rule" metadata of rejections
+const (
+	RuleMaxLength   = "max_length"
+	RuleScheme      = "scheme"
+	RuleDomainDeny  = "domain_deny"
+	RuleDomainAllow = "domain_allow"
+	RulePrivateIP   = "private_ip"
+)
+
+// Violation is returned by Check when a URL breaks a rule
+type Violation struct {
+	Rule   string
+	Detail string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s: %s", v.Rule, v.Detail)
+}
+
+// Policy decides which destinations may be shortened
+type Policy struct {
+	maxLength        int
+	schemes          map[string]bool
+	allowedDomains   []string
+	deniedDomains    []string
+	rejectPrivateIPs bool
+}
+
+// New creates a policy from its configuration
+// An empty scheme or allowed domain list places no restriction
+func New(cfg config.PolicyConfig) *Policy {
+	p := &Policy{
+		maxLength:        cfg.MaxURLLength,
+		allowedDomains:   normalizeDomains(cfg.AllowedDomains),
+		deniedDomains:    normalizeDomains(cfg.DeniedDomains),
+		rejectPrivateIPs: cfg.RejectPrivateIPs,
+	}
+	if len(cfg.AllowedSchemes) > 0 {
+		p.schemes = make(map[string]bool, len(cfg.AllowedSchemes))
+		for _, scheme := range cfg.AllowedSchemes {
+			p.schemes[strings.ToLower(scheme)] = true
+		}
+	}
+	return p
+}
+
+// Check returns a *Violation naming the first rule the URL breaks, or nil
+// Rules are checked in order: length, scheme, denied domains, allowed
+// domains, private addresses. Hostnames are not resolved, so only IP
+// literals and localhost names count as private
+func (p *Policy) Check(rawURL string) error {
+	if p.maxLength > 0 && len(rawURL) > p.maxLength {
+		return &Violation{Rule: RuleMaxLength, Detail: fmt.Sprintf("URL is longer than %d characters", p.maxLength)}
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	if p.schemes != nil && !p.schemes[scheme] {
+		return &Violation{Rule: RuleScheme, Detail: fmt.Sprintf("scheme %q is not allowed", scheme)}
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if pattern, ok := matchDomain(host, p.deniedDomains); ok {
+		return &Violation{Rule: RuleDomainDeny, Detail: fmt.Sprintf("host %q matches denied domain %q", host, pattern)}
+	}
+	if len(p.allowedDomains) > 0 {
+		if _, ok := matchDomain(host, p.allowedDomains); !ok {
+			return &Violation{Rule: RuleDomainAllow, Detail: fmt.Sprintf("host %q is not an allowed domain", host)}
+		}
+	}
+
+	if p.rejectPrivateIPs && isPrivateHost(host) {
+		return &Violation{Rule: RulePrivateIP, Detail: fmt.Sprintf("host %q is a private or local address", host)}
+	}
+	return nil
+}
+
+// matchDomain returns the first pattern matching host
+// "*.example.com" matches any subdomain of example.com but not example.com itself
+func matchDomain(host string, patterns []string) (string, bool) {
+	if host == "" {
+		return "", false
+	}
+	for _, pattern := range patterns {
+		if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+			if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return pattern, true
+			}
+		} else if host == pattern {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// normalizeDomains lowercases domain patterns and drops trailing dots
+func normalizeDomains(domains []string) []string {
+	normalized := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+		if domain != "" {
+			normalized = append(normalized, domain)
+		}
+	}
+	return normalized
+}
+
+// isPrivateHost reports whether host is a localhost name or a loopback,
+// private, link-local or unspecified IP address
+func isPrivateHost(host string) bool {
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified()
+}
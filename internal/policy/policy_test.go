@@ -0,0 +1,97 @@
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/config"
+)
+
+func TestPolicy_Check(t *testing.T) {
+	p := New(config.PolicyConfig{
+		MaxURLLength:     64,
+		AllowedSchemes:   []string{"http", "HTTPS"},
+		DeniedDomains:    []string{"evil.com", "*.tracker.net"},
+		RejectPrivateIPs: true,
+	})
+
+	tests := []struct {
+		name string
+		url  string
+		rule string
+	}{
+		{name: "Allowed", url: "https://example.com/a", rule: ""},
+		{name: "Uppercase scheme", url: "HTTP://example.com/a", rule: ""},
+		{name: "Too long", url: "https://example.com/" + strings.Repeat("a", 64), rule: RuleMaxLength},
+		{name: "JavaScript scheme", url: "javascript:alert(1)", rule: RuleScheme},
+		{name: "File scheme", url: "file:///etc/passwd", rule: RuleScheme},
+		{name: "Data scheme", url: "data:text/html,hi", rule: RuleScheme},
+		{name: "Denied domain", url: "https://EVIL.com./a", rule: RuleDomainDeny},
+		{name: "Subdomain of exact deny", url: "https://www.evil.com/a", rule: ""},
+		{name: "Wildcard deny", url: "https://a.b.tracker.net/a", rule: RuleDomainDeny},
+		{name: "Wildcard apex", url: "https://tracker.net/a", rule: ""},
+		{name: "Localhost", url: "http://localhost:8080/admin", rule: RulePrivateIP},
+		{name: "Localhost subdomain", url: "http://api.localhost/", rule: RulePrivateIP},
+		{name: "Loopback", url: "http://127.0.0.1/", rule: RulePrivateIP},
+		{name: "RFC1918", url: "http://192.168.1.10/", rule: RulePrivateIP},
+		{name: "Link-local", url: "http://169.254.169.254/latest/meta-data", rule: RulePrivateIP},
+		{name: "IPv6 loopback", url: "http://[::1]/", rule: RulePrivateIP},
+		{name: "Unspecified", url: "http://0.0.0.0/", rule: RulePrivateIP},
+		{name: "Public IP", url: "http://8.8.8.8/", rule: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Check(tt.url)
+			if tt.rule == "" {
+				if err != nil {
+					t.Errorf("Check(%q) returned unexpected error: %v", tt.url, err)
+				}
+				return
+			}
+
+			var violation *Violation
+			if !errors.As(err, &violation) {
+				t.Fatalf("Check(%q) expected a violation of %s, got %v", tt.url, tt.rule, err)
+			}
+			if violation.Rule != tt.rule {
+				t.Errorf("Check(%q) broke rule %s, expected %s", tt.url, violation.Rule, tt.rule)
+			}
+		})
+	}
+}
+
+func TestPolicy_AllowedDomains(t *testing.T) {
+	p := New(config.PolicyConfig{
+		AllowedDomains: []string{"example.com", "*.example.com"},
+		DeniedDomains:  []string{"bad.example.com"},
+	})
+
+	for url, rule := range map[string]string{
+		"https://example.com/":     "",
+		"https://www.example.com/": "",
+		"https://example.org/":     RuleDomainAllow,
+		"https://notexample.com/":  RuleDomainAllow,
+		"https://bad.example.com/": RuleDomainDeny,
+	} {
+		err := p.Check(url)
+		var violation *Violation
+		switch {
+		case rule == "" && err != nil:
+			t.Errorf("Check(%q) returned unexpected error: %v", url, err)
+		case rule != "" && (!errors.As(err, &violation) || violation.Rule != rule):
+			t.Errorf("Check(%q) expected a violation of %s, got %v", url, rule, err)
+		}
+	}
+}
+
+func TestPolicy_Empty(t *testing.T) {
+	p := New(config.PolicyConfig{})
+
+	for _, url := range []string{"ftp://example.com/a", "http://localhost/", "https://example.com/" + strings.Repeat("a", 5000)} {
+		if err := p.Check(url); err != nil {
+			t.Errorf("Check(%q) returned unexpected error with an empty policy: %v", url, err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxTrackedBuckets bounds the limiter state before full buckets are swept
+const maxTrackedBuckets = 100000
+
+// MemoryLimiter keeps buckets in memory, so each instance limits on its own
+type MemoryLimiter struct {
+	now func() time.Time
+
+	mutex   sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket is the state of one token bucket
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+// NewMemoryLimiter creates a new MemoryLimiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow implements Limiter.Allow
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	if limit.Unlimited() {
+		return true, 0, nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxTrackedBuckets {
+			l.sweep(now)
+		}
+		b = &bucket{tokens: limit.capacity(), last: now}
+		l.buckets[key] = b
+	}
+
+	var allowed bool
+	var wait time.Duration
+	b.tokens, allowed, wait = take(b.tokens, now.Sub(b.last), limit)
+	b.last = now
+	b.limit = limit
+	return allowed, wait, nil
+}
+
+// sweep drops buckets that have refilled, since a new bucket starts full anyway
+// Callers must hold the mutex
+func (l *MemoryLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if refill(b.tokens, now.Sub(b.last), b.limit) >= b.limit.capacity() {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Close implements Limiter.Close
+func (l *MemoryLimiter) Close() error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	l := NewMemoryLimiter()
+	l.now = func() time.Time { return now }
+	limit := Limit{Rate: 2, Burst: 3}
+
+	// A new bucket starts full
+	for i := 0; i < 3; i++ {
+		if allowed, _, _ := l.Allow(ctx, "client", limit); !allowed {
+			t.Fatalf("Request %d should be allowed within the burst", i+1)
+		}
+	}
+
+	allowed, wait, err := l.Allow(ctx, "client", limit)
+	if err != nil || allowed {
+		t.Fatalf("Allow() = %v, %v; expected the bucket to be empty", allowed, err)
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("Expected a 500ms wait at 2 requests per second, got %v", wait)
+	}
+
+	// Other clients have their own bucket
+	if allowed, _, _ := l.Allow(ctx, "other", limit); !allowed {
+		t.Errorf("Another client should not be limited")
+	}
+
+	// Tokens come back at the configured rate
+	now = now.Add(500 * time.Millisecond)
+	if allowed, _, _ := l.Allow(ctx, "client", limit); !allowed {
+		t.Errorf("Request should be allowed after a token was refilled")
+	}
+	if allowed, _, _ := l.Allow(ctx, "client", limit); allowed {
+		t.Errorf("Only one token should have been refilled")
+	}
+}
+
+func TestMemoryLimiter_Unlimited(t *testing.T) {
+	l := NewMemoryLimiter()
+	for i := 0; i < 100; i++ {
+		if allowed, _, _ := l.Allow(context.Background(), "client", Limit{}); !allowed {
+			t.Fatalf("A zero limit should let every request through")
+		}
+	}
+	if len(l.buckets) != 0 {
+		t.Errorf("Unlimited requests should not create buckets")
+	}
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/config"
+)
+
+// Backends for config.RateLimitConfig.Backend
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Limit is a token bucket: Rate tokens are added per second, up to Burst
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether the limit lets every request through
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+// capacity returns the bucket size, at least one token so requests can pass
+func (l Limit) capacity() float64 {
+	if l.Burst < 1 {
+		return math.Max(1, math.Ceil(l.Rate))
+	}
+	return float64(l.Burst)
+}
+
+// Limiter takes tokens from buckets identified by key
+type Limiter interface {
+	// Allow takes one token from the bucket of key. If the bucket is empty
+	// it returns false and how long until a token is available
+	Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+
+	// Close releases any connections
+	Close() error
+}
+
+// New creates the limiter selected by the rate limit configuration
+func New(cfg *config.Config) (Limiter, error) {
+	switch cfg.RateLimit.Backend {
+	case BackendRedis:
+		limiter, err := NewRedisLimiter(cfg.Storage.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		return limiter, nil
+	case BackendMemory, "":
+		return NewMemoryLimiter(), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend: %s", cfg.RateLimit.Backend)
+	}
+}
+
+// refill returns the tokens of a bucket that held tokens elapsed ago
+func refill(tokens float64, elapsed time.Duration, limit Limit) float64 {
+	return math.Min(limit.capacity(), tokens+math.Max(0, elapsed.Seconds())*limit.Rate)
+}
+
+// take refills a bucket holding tokens after elapsed and takes one token
+// It returns the tokens left and, if none could be taken, the wait for one
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, bool, time.Duration) {
+	tokens = refill(tokens, elapsed, limit)
+	if tokens >= 1 {
+		return tokens - 1, true, 0
+	}
+	wait := time.Duration((1 - tokens) / limit.Rate * float64(time.Second))
+	return tokens, false, wait
+}
@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"go.uber.org/zap"
+)
+
+// RedisLimiter keeps buckets in Redis, so limits hold across all instances
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisLimiter creates a new RedisLimiter
+func NewRedisLimiter(redisURL string) (*RedisLimiter, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	logger.L().Info("Rate limiter connected to Redis", zap.String("address", opts.Addr))
+	return &RedisLimiter{client: client}, nil
+}
+
+// Allow implements Limiter.Allow
+// The bucket is refilled and taken from in one script, using the Redis clock
+// so instances with skewed clocks agree
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	if limit.Unlimited() {
+		return true, 0, nil
+	}
+
+	// Idle buckets expire once they would have refilled
+	ttl := int64(math.Ceil(limit.capacity() / limit.Rate * 1000))
+
+	result, err := l.client.Eval(ctx, tokenBucketScript, []string{models.RateLimitKeyPrefix + key},
+		strconv.FormatFloat(limit.Rate, 'f', -1, 64),
+		strconv.FormatFloat(limit.capacity(), 'f', -1, 64),
+		ttl,
+	).Slice()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+
+	allowed, _ := result[0].(int64)
+	waitSeconds, _ := strconv.ParseFloat(fmt.Sprint(result[1]), 64)
+	return allowed == 1, time.Duration(waitSeconds * float64(time.Second)), nil
+}
+
+// Close implements Limiter.Close
+func (l *RedisLimiter) Close() error {
+	return l.client.Close()
+}
+
+// tokenBucketScript refills a bucket and takes one token from it
+// It returns {1, "0"} if a token was taken, or {0, seconds until one is available}.
+// The wait is returned as a string since Redis truncates Lua numbers to integers
+const tokenBucketScript = `
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1]) or capacity
+local last = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - last) * rate)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = (1 - tokens) / rate
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(now))
+redis.call("PEXPIRE", KEYS[1], ARGV[3])
+return {allowed, tostring(wait)}`
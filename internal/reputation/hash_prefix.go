@@ -0,0 +1,148 @@
+package reputation
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	// minPrefixLength and maxPrefixLength bound the hex length of listed
+	// prefixes: 4 bytes up to a full SHA-256
+	minPrefixLength = 8
+	maxPrefixLength = 64
+
+	// maxHostSuffixes bounds the parent domains hashed for a URL
+	maxHostSuffixes = 4
+
+	// defaultThreat is the verdict reason of prefixes listed without one
+	defaultThreat = "listed"
+)
+
+// HashPrefixChecker flags URLs whose SHA-256 starts with a listed prefix
+// Like Safe Browsing lists, it hashes several expressions of a URL: the host
+// with and without the query and path, and up to four parent domains, so
+// listing "example.com/" flags every URL on example.com and its subdomains
+type HashPrefixChecker struct {
+	prefixes map[string]string // hex prefix -> threat
+	lengths  []int             // distinct prefix lengths, ascending
+}
+
+// NewHashPrefixChecker loads a hash prefix list from a file
+func NewHashPrefixChecker(path string) (*HashPrefixChecker, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadHashPrefixes(file)
+}
+
+// LoadHashPrefixes reads a hash prefix list: one hex prefix per line,
+// optionally followed by the threat it stands for. Blank lines and lines
+// starting with '#' are skipped
+func LoadHashPrefixes(r io.Reader) (*HashPrefixChecker, error) {
+	c := &HashPrefixChecker{prefixes: make(map[string]string)}
+	lengths := make(map[int]bool)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		prefix := strings.ToLower(fields[0])
+		if len(prefix) < minPrefixLength || len(prefix) > maxPrefixLength || len(prefix)%2 != 0 {
+			return nil, fmt.Errorf("line %d: prefix must be 4 to 32 bytes of hex", line)
+		}
+		if _, err := hex.DecodeString(prefix); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		threat := defaultThreat
+		if len(fields) > 1 {
+			threat = fields[1]
+		}
+		c.prefixes[prefix] = threat
+		lengths[len(prefix)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for length := range lengths {
+		c.lengths = append(c.lengths, length)
+	}
+	sort.Ints(c.lengths)
+	return c, nil
+}
+
+// Len returns the number of listed prefixes
+func (c *HashPrefixChecker) Len() int {
+	return len(c.prefixes)
+}
+
+// Check implements URLChecker.Check
+func (c *HashPrefixChecker) Check(_ context.Context, rawURL string) (Verdict, error) {
+	for _, expression := range expressions(rawURL) {
+		sum := sha256.Sum256([]byte(expression))
+		hash := hex.EncodeToString(sum[:])
+		for _, length := range c.lengths {
+			if threat, ok := c.prefixes[hash[:length]]; ok {
+				return Verdict{Flagged: true, Reason: threat}, nil
+			}
+		}
+	}
+	return Verdict{}, nil
+}
+
+// expressions returns the host and path combinations of a URL that are
+// hashed, most specific first. Scheme, port, userinfo and fragment are ignored
+func expressions(rawURL string) []string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "" {
+		return nil
+	}
+
+	hosts := []string{host}
+	if net.ParseIP(host) == nil {
+		labels := strings.Split(host, ".")
+		for i := 1; i < len(labels)-1 && len(hosts) <= maxHostSuffixes; i++ {
+			hosts = append(hosts, strings.Join(labels[i:], "."))
+		}
+	}
+
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	paths := make([]string, 0, 3)
+	if parsed.RawQuery != "" {
+		paths = append(paths, path+"?"+parsed.RawQuery)
+	}
+	paths = append(paths, path)
+	if path != "/" {
+		paths = append(paths, "/")
+	}
+
+	exprs := make([]string, 0, len(hosts)*len(paths))
+	for _, h := range hosts {
+		for _, p := range paths {
+			exprs = append(exprs, h+p)
+		}
+	}
+	return exprs
+}
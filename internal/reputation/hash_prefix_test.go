@@ -0,0 +1,66 @@
+package reputation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// hashOf returns the hex SHA-256 of an expression, truncated to n hex characters
+func hashOf(expression string, n int) string {
+	sum := sha256.Sum256([]byte(expression))
+	return hex.EncodeToString(sum[:])[:n]
+}
+
+func TestHashPrefixChecker(t *testing.T) {
+	list := strings.Join([]string{
+		"# listed hosts and pages",
+		hashOf("evil.example/", 64) + " malware",
+		"",
+		hashOf("example.com/phish/login", 8) + " phishing",
+		hashOf("example.com/a?id=1", 16),
+	}, "\n")
+
+	checker, err := LoadHashPrefixes(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("LoadHashPrefixes returned unexpected error: %v", err)
+	}
+	if checker.Len() != 3 {
+		t.Errorf("Expected 3 prefixes, got %d", checker.Len())
+	}
+
+	tests := []struct {
+		url    string
+		reason string
+	}{
+		{url: "https://evil.example/", reason: "malware"},
+		{url: "http://EVIL.example:8080/any/path?q=1#top", reason: "malware"},
+		{url: "https://cdn.files.evil.example/x.exe", reason: "malware"},
+		{url: "https://example.com/phish/login", reason: "phishing"},
+		{url: "https://user@www.example.com/phish/login?next=/", reason: "phishing"},
+		{url: "https://example.com/a?id=1", reason: defaultThreat},
+		{url: "https://example.com/a?id=2", reason: ""},
+		{url: "https://example.com/phish", reason: ""},
+		{url: "https://notevil.example/", reason: ""},
+	}
+
+	for _, tt := range tests {
+		verdict, err := checker.Check(context.Background(), tt.url)
+		if err != nil {
+			t.Fatalf("Check(%s) returned unexpected error: %v", tt.url, err)
+		}
+		if verdict.Flagged != (tt.reason != "") || verdict.Reason != tt.reason {
+			t.Errorf("Check(%s) = %+v, expected reason %q", tt.url, verdict, tt.reason)
+		}
+	}
+}
+
+func TestLoadHashPrefixes_Invalid(t *testing.T) {
+	for _, list := range []string{"abc", "zzzzzzzz", "0123456", strings.Repeat("ab", 33)} {
+		if _, err := LoadHashPrefixes(strings.NewReader(list)); err == nil {
+			t.Errorf("LoadHashPrefixes(%q) expected error but got nil", list)
+		}
+	}
+}
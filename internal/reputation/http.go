@@ -0,0 +1,98 @@
+package reputation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultHTTPTimeout bounds a callout when no timeout is configured
+const defaultHTTPTimeout = 2 * time.Second
+
+// HTTPChecker asks an external service about each URL
+// It POSTs {"url": "..."} and expects a 200 response of the form
+// {"flagged": true, "reason": "phishing"}
+type HTTPChecker struct {
+	endpoint string
+	client   *http.Client
+	failOpen bool
+	logger   *zap.Logger
+}
+
+// NewHTTPChecker creates a checker that calls endpoint
+// When failOpen is set, URLs are let through if the service fails or times
+// out; otherwise Check returns ErrUnavailable
+func NewHTTPChecker(endpoint string, timeout time.Duration, failOpen bool, log *zap.Logger) *HTTPChecker {
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+	return &HTTPChecker{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+		failOpen: failOpen,
+		logger:   log,
+	}
+}
+
+type checkRequest struct {
+	URL string `json:"url"`
+}
+
+type checkResponse struct {
+	Flagged bool   `json:"flagged"`
+	Reason  string `json:"reason"`
+}
+
+// Check implements URLChecker.Check
+func (c *HTTPChecker) Check(ctx context.Context, rawURL string) (Verdict, error) {
+	verdict, err := c.call(ctx, rawURL)
+	if err == nil {
+		return verdict, nil
+	}
+
+	if c.failOpen {
+		c.logger.Warn("Reputation service unavailable, letting URL through",
+			zap.String("url", rawURL),
+			zap.Error(err))
+		return Verdict{}, nil
+	}
+	return Verdict{}, fmt.Errorf("%w: %v", ErrUnavailable, err)
+}
+
+// call sends one URL to the service and decodes its verdict
+func (c *HTTPChecker) call(ctx context.Context, rawURL string) (Verdict, error) {
+	body, err := json.Marshal(checkRequest{URL: rawURL})
+	if err != nil {
+		return Verdict{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return Verdict{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Verdict{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Verdict{}, fmt.Errorf("reputation service returned %s", resp.Status)
+	}
+
+	var decoded checkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return Verdict{}, fmt.Errorf("failed to decode reputation response: %w", err)
+	}
+	if decoded.Flagged && decoded.Reason == "" {
+		decoded.Reason = defaultThreat
+	}
+	return Verdict{Flagged: decoded.Flagged, Reason: decoded.Reason}, nil
+}
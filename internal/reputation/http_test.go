@@ -0,0 +1,100 @@
+package reputation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// newReputationServer flags every URL in flagged and fails with status for the rest, if set
+func newReputationServer(t *testing.T, flagged map[string]string, status int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req checkRequest
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&req) != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if reason, ok := flagged[req.URL]; ok {
+			_ = json.NewEncoder(w).Encode(checkResponse{Flagged: true, Reason: reason})
+			return
+		}
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(checkResponse{})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPChecker(t *testing.T) {
+	server := newReputationServer(t, map[string]string{"https://evil.example/": "phishing"}, 0)
+	checker := NewHTTPChecker(server.URL, time.Second, false, zap.NewNop())
+	ctx := context.Background()
+
+	verdict, err := checker.Check(ctx, "https://evil.example/")
+	if err != nil {
+		t.Fatalf("Check returned unexpected error: %v", err)
+	}
+	if !verdict.Flagged || verdict.Reason != "phishing" {
+		t.Errorf("Expected a phishing verdict, got %+v", verdict)
+	}
+
+	verdict, err = checker.Check(ctx, "https://example.com/")
+	if err != nil {
+		t.Fatalf("Check returned unexpected error: %v", err)
+	}
+	if verdict.Flagged {
+		t.Errorf("Expected a clean verdict, got %+v", verdict)
+	}
+}
+
+func TestHTTPChecker_FailureModes(t *testing.T) {
+	failing := newReputationServer(t, nil, http.StatusInternalServerError)
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(slow.Close)
+
+	for name, endpoint := range map[string]string{"Server error": failing.URL, "Timeout": slow.URL} {
+		t.Run(name, func(t *testing.T) {
+			closed := NewHTTPChecker(endpoint, 50*time.Millisecond, false, zap.NewNop())
+			if _, err := closed.Check(context.Background(), "https://example.com/"); !errors.Is(err, ErrUnavailable) {
+				t.Errorf("Fail-closed checker: expected ErrUnavailable, got %v", err)
+			}
+
+			open := NewHTTPChecker(endpoint, 50*time.Millisecond, true, zap.NewNop())
+			verdict, err := open.Check(context.Background(), "https://example.com/")
+			if err != nil || verdict.Flagged {
+				t.Errorf("Fail-open checker: expected a clean verdict, got %+v, %v", verdict, err)
+			}
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	failing := NewHTTPChecker(newReputationServer(t, nil, http.StatusBadGateway).URL, time.Second, false, zap.NewNop())
+	flagging := NewHTTPChecker(newReputationServer(t, map[string]string{"https://evil.example/": "malware"}, 0).URL, time.Second, false, zap.NewNop())
+	chain := Chain{failing, flagging}
+
+	verdict, err := chain.Check(context.Background(), "https://evil.example/")
+	if err != nil || verdict.Reason != "malware" {
+		t.Errorf("Expected a later checker to flag the URL, got %+v, %v", verdict, err)
+	}
+	if _, err := chain.Check(context.Background(), "https://example.com/"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected the error of the failing checker, got %v", err)
+	}
+}
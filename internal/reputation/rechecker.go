@@ -0,0 +1,136 @@
+package reputation
+
+import (
+	"context"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"go.uber.org/zap"
+)
+
+// defaultRecheckBatchSize is the number of links read per page when none is configured
+const defaultRecheckBatchSize = 500
+
+// Rechecker periodically runs a checker over every stored link, so
+// destinations that turn bad after they were shortened stop resolving.
+// Flagged links are quarantined, never deleted
+type Rechecker struct {
+	store     storage.QuarantineStorage
+	checker   URLChecker
+	interval  time.Duration
+	batchSize int
+	logger    *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewRechecker creates a Rechecker and starts its background loop
+func NewRechecker(store storage.QuarantineStorage, checker URLChecker, interval time.Duration, batchSize int, log *zap.Logger) *Rechecker {
+	if batchSize <= 0 {
+		batchSize = defaultRecheckBatchSize
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Rechecker{
+		store:     store,
+		checker:   checker,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    log,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	go r.run(ctx)
+
+	log.Info("Reputation rechecker started",
+		zap.Duration("interval", interval),
+		zap.Int("batchSize", batchSize))
+	return r
+}
+
+// Close stops the loop, abandoning a pass in progress, and waits for it to exit
+func (r *Rechecker) Close() {
+	r.cancel()
+	<-r.done
+}
+
+// run rechecks all links every interval until the context is canceled
+func (r *Rechecker) run(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Reputation rechecker stopped")
+			return
+		case <-ticker.C:
+			checked, quarantined, err := r.RecheckAll(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.logger.Error("Reputation recheck failed", zap.Error(err), zap.Int("checked", checked))
+				continue
+			}
+			r.logger.Info("Reputation recheck finished",
+				zap.Int("checked", checked),
+				zap.Int("quarantined", quarantined))
+		}
+	}
+}
+
+// RecheckAll checks every link that is not yet quarantined and quarantines
+// the flagged ones. Links the checker cannot judge are left alone. It returns
+// the number of links checked and quarantined
+func (r *Rechecker) RecheckAll(ctx context.Context) (int, int, error) {
+	checked, quarantined := 0, 0
+	afterTenantID, afterShortID := "", ""
+	for {
+		page, err := r.store.ScanURLs(ctx, afterTenantID, afterShortID, r.batchSize)
+		if err != nil {
+			return checked, quarantined, err
+		}
+
+		for _, scanned := range page {
+			link := scanned.Link
+			if link.Quarantined() {
+				continue
+			}
+
+			checked++
+			verdict, err := r.checker.Check(ctx, link.OriginalURL)
+			if err != nil {
+				if ctx.Err() != nil {
+					return checked, quarantined, ctx.Err()
+				}
+				r.logger.Warn("Cannot recheck URL",
+					zap.String("tenant", scanned.TenantID),
+					zap.String("shortID", link.ShortID),
+					zap.Error(err))
+				continue
+			}
+			if !verdict.Flagged {
+				continue
+			}
+
+			tenantCtx := tenant.WithContext(ctx, scanned.TenantID)
+			if err := r.store.Quarantine(tenantCtx, link.ShortID, verdict.Reason); err != nil && err != storage.ErrNotFound {
+				return checked, quarantined, err
+			}
+			quarantined++
+			r.logger.Warn("Flagged URL quarantined",
+				zap.String("tenant", scanned.TenantID),
+				zap.String("shortID", link.ShortID),
+				zap.String("url", link.OriginalURL),
+				zap.String("reason", verdict.Reason))
+		}
+
+		if len(page) < r.batchSize {
+			return checked, quarantined, nil
+		}
+		last := page[len(page)-1]
+		afterTenantID, afterShortID = last.TenantID, last.Link.ShortID
+	}
+}
@@ -0,0 +1,90 @@
+package reputation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"go.uber.org/zap"
+)
+
+// stubChecker flags the URLs in its map
+type stubChecker map[string]string
+
+func (c stubChecker) Check(_ context.Context, rawURL string) (Verdict, error) {
+	if reason, ok := c[rawURL]; ok {
+		return Verdict{Flagged: true, Reason: reason}, nil
+	}
+	return Verdict{}, nil
+}
+
+func TestRechecker_RecheckAll(t *testing.T) {
+	store := storage.NewMemoryStorage()
+	ctx := context.Background()
+	acme := tenant.WithContext(ctx, "acme")
+
+	for _, link := range []struct {
+		ctx     context.Context
+		shortID string
+		url     string
+	}{
+		{ctx, "a", "https://example.com/"},
+		{ctx, "b", "https://evil.example/"},
+		{acme, "a", "https://evil.example/"},
+		{acme, "c", "https://example.org/"},
+	} {
+		if err := store.StoreWithID(link.ctx, &models.URL{ShortID: link.shortID, OriginalURL: link.url, Dedup: true}); err != nil {
+			t.Fatalf("StoreWithID returned unexpected error: %v", err)
+		}
+	}
+
+	// A batch size of one pages through every link
+	r := &Rechecker{store: store, checker: stubChecker{"https://evil.example/": "malware"}, batchSize: 1, logger: zap.NewNop()}
+	checked, quarantined, err := r.RecheckAll(ctx)
+	if err != nil {
+		t.Fatalf("RecheckAll returned unexpected error: %v", err)
+	}
+	if checked != 4 || quarantined != 2 {
+		t.Errorf("Expected 4 links checked and 2 quarantined, got %d and %d", checked, quarantined)
+	}
+
+	for _, flagged := range []struct {
+		ctx     context.Context
+		shortID string
+	}{{ctx, "b"}, {acme, "a"}} {
+		link, err := store.GetInfo(flagged.ctx, flagged.shortID)
+		if err != nil {
+			t.Fatalf("GetInfo returned unexpected error: %v", err)
+		}
+		if link.QuarantineReason != "malware" || link.Dedup {
+			t.Errorf("Expected %s to be quarantined and out of dedup, got %+v", link.ShortID, link)
+		}
+	}
+	if _, err := store.Find(ctx, "https://evil.example/"); err != storage.ErrNotFound {
+		t.Errorf("Expected the quarantined link to leave dedup, got %v", err)
+	}
+
+	// Quarantined links are not checked again
+	checked, quarantined, err = r.RecheckAll(ctx)
+	if err != nil || checked != 2 || quarantined != 0 {
+		t.Errorf("Expected 2 links checked and none quarantined, got %d, %d, %v", checked, quarantined, err)
+	}
+}
+
+func TestRechecker_Close(t *testing.T) {
+	r := NewRechecker(storage.NewMemoryStorage(), stubChecker{}, time.Hour, 0, zap.NewNop())
+
+	closed := make(chan struct{})
+	go func() {
+		r.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not stop the rechecker")
+	}
+}
@@ -0,0 +1,72 @@
+package reputation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hohotang/shortlink-core/internal/config"
+	"go.uber.org/zap"
+)
+
+// ErrUnavailable is returned by fail-closed checkers that could not reach a verdict
+var ErrUnavailable = errors.New("reputation check unavailable")
+
+// Verdict is the outcome of checking a URL
+type Verdict struct {
+	Flagged bool
+	// Reason names the threat, such as "phishing"; set when Flagged
+	Reason string
+}
+
+// URLChecker decides whether a destination is a known phishing or malware URL
+type URLChecker interface {
+	Check(ctx context.Context, rawURL string) (Verdict, error)
+}
+
+// Chain runs checkers in order and returns the first flagged verdict
+// A checker error is returned only if no other checker flags the URL
+type Chain []URLChecker
+
+// Check implements URLChecker.Check
+func (c Chain) Check(ctx context.Context, rawURL string) (Verdict, error) {
+	var firstErr error
+	for _, checker := range c {
+		verdict, err := checker.Check(ctx, rawURL)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if verdict.Flagged {
+			return verdict, nil
+		}
+	}
+	return Verdict{}, firstErr
+}
+
+// New creates the checkers selected by the reputation configuration
+// It returns nil if none is configured
+func New(cfg config.ReputationConfig, log *zap.Logger) (URLChecker, error) {
+	var chain Chain
+	if cfg.HashPrefixFile != "" {
+		checker, err := NewHashPrefixChecker(cfg.HashPrefixFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load hash prefixes: %w", err)
+		}
+		chain = append(chain, checker)
+	}
+	if cfg.HTTP.URL != "" {
+		chain = append(chain, NewHTTPChecker(cfg.HTTP.URL, cfg.HTTP.Timeout, cfg.HTTP.FailOpen, log))
+	}
+
+	switch len(chain) {
+	case 0:
+		return nil, nil
+	case 1:
+		return chain[0], nil
+	default:
+		return chain, nil
+	}
+}
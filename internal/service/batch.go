@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// BatchShortenURLs implements the BatchShortenURLs RPC method
+// Invalid URLs fail individually; the rest of the batch is still shortened
+func (s *URLService) BatchShortenURLs(ctx context.Context, req *proto.BatchShortenURLsRequest) (*proto.BatchShortenURLsResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.BatchShortenURLs",
+		trace.WithAttributes(attribute.Int("batch_size", len(req.OriginalUrls))))
+	defer span.End()
+
+	if len(req.OriginalUrls) == 0 {
+		span.SetStatus(codes.Error, "empty batch")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "original_urls is required")
+	}
+	if err := s.checkBatchSize(ctx, len(req.OriginalUrls)); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Validate every URL and collect one link per distinct canonical form
+	results := make([]*proto.BatchShortenURLResult, len(req.OriginalUrls))
+	canonicalURLs := make([]string, len(req.OriginalUrls))
+	pending := make([]*models.URL, 0, len(req.OriginalUrls))
+	seen := make(map[string]bool, len(req.OriginalUrls))
+	invalid := 0
+	for i, originalURL := range req.OriginalUrls {
+		results[i] = &proto.BatchShortenURLResult{OriginalUrl: originalURL}
+		if err := s.checkURL(ctx, originalURL); err != nil {
+			results[i].Reason, results[i].Error = batchError(err)
+			invalid++
+			continue
+		}
+		canonicalURLs[i] = s.canonicalize(ctx, originalURL)
+		if !seen[canonicalURLs[i]] {
+			seen[canonicalURLs[i]] = true
+			pending = append(pending, &models.URL{
+				OriginalURL:  originalURL,
+				CanonicalURL: canonicalURLs[i],
+				Dedup:        true,
+			})
+		}
+	}
+	span.SetAttributes(attribute.Int("invalid_urls", invalid))
+
+	shortIDs, err := s.storeBatch(ctx, pending)
+	if shortIDs == nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error("Failed to store URL batch", zap.Error(err), zap.Int("batchSize", len(pending)))
+		return nil, err
+	}
+	if err != nil {
+		// Links stored by earlier attempts are kept and returned
+		span.RecordError(err)
+		log.Warn("URL batch only partly stored", zap.Error(err), zap.Int("stored", len(shortIDs)))
+	} else {
+		err = apperrors.Unavailable(apperrors.ReasonShortIDExhausted, "no free short ID after %d attempts", maxGenerateAttempts)
+	}
+
+	// Fill in the results in request order
+	for i, result := range results {
+		if result.Error != "" {
+			continue
+		}
+		shortID, ok := shortIDs[canonicalURLs[i]]
+		if !ok {
+			result.Reason, result.Error = batchError(err)
+			continue
+		}
+		result.ShortId = shortID
+		result.ShortUrl = s.baseURL + shortID
+	}
+
+	log.Info("URL batch shortened",
+		zap.Int("batchSize", len(req.OriginalUrls)),
+		zap.Int("invalid", invalid))
+	return &proto.BatchShortenURLsResponse{Results: results}, nil
+}
+
+// checkBatchSize rejects batches larger than the configured limit
+func (s *URLService) checkBatchSize(ctx context.Context, size int) error {
+	if s.maxBatchSize > 0 && size > s.maxBatchSize {
+		logger.FromContext(ctx).Warn("Batch too large",
+			zap.Int("batchSize", size),
+			zap.Int("maxBatchSize", s.maxBatchSize))
+		return apperrors.InvalidArgument(apperrors.ReasonBatchTooLarge, "batch of %d exceeds the limit of %d", size, s.maxBatchSize).
+			WithMetadata("max_batch_size", strconv.Itoa(s.maxBatchSize))
+	}
+	return nil
+}
+
+// batchError returns the catalog reason and client-facing message of the
+// error of one item of a batch; causes stay out of it, as for whole RPCs
+func batchError(err error) (string, string) {
+	reason, message := apperrors.ForClient(err)
+	return string(reason), message
+}
+
+// storeBatch returns the dedup short ID of each link keyed by canonical URL,
+// generating and storing new ones for links that have none. Links that still
+// have no short ID after maxGenerateAttempts are missing from the result.
+// A failure in the first attempt fails the whole batch with a nil map; a
+// failure in a later one returns the short IDs found so far along with the
+// error that stopped the remaining links
+func (s *URLService) storeBatch(ctx context.Context, batch []*models.URL) (map[string]string, error) {
+	log := logger.FromContext(ctx)
+	_, span := s.tracer.Start(ctx, "URLService.storeBatch")
+	defer span.End()
+
+	shortIDs := make(map[string]string, len(batch))
+	pending := batch
+	for attempt := 1; attempt <= maxGenerateAttempts && len(pending) > 0; attempt++ {
+		// Reuse existing short IDs, including ones stored concurrently since the last attempt
+		keys := make([]string, len(pending))
+		for i, link := range pending {
+			keys[i] = link.DedupKey()
+		}
+		found, err := s.storage.FindMany(ctx, keys)
+		if err != nil {
+			span.RecordError(err)
+			return partialBatch(shortIDs, attempt, apperrors.FromStorage(err, ""))
+		}
+
+		links := make([]*models.URL, 0, len(pending)-len(found))
+		for _, link := range pending {
+			if shortID, ok := found[link.DedupKey()]; ok {
+				shortIDs[link.DedupKey()] = shortID
+				continue
+			}
+			link.ShortID = s.generator.GenerateShortID()
+			links = append(links, link)
+		}
+		if len(links) == 0 {
+			break
+		}
+
+		// The whole batch is refused if the tenant's quota cannot take it
+		day, err := s.reserveLinks(ctx, int64(len(links)))
+		if err != nil {
+			span.RecordError(err)
+			return partialBatch(shortIDs, attempt, err)
+		}
+
+		stored, err := s.storage.StoreMany(ctx, links)
+		if err != nil {
+			s.releaseLinks(ctx, day, int64(len(links)))
+			span.RecordError(err)
+			return partialBatch(shortIDs, attempt, apperrors.FromStorage(err, ""))
+		}
+		s.releaseLinks(ctx, day, int64(len(links)-len(stored)))
+		isStored := make(map[string]bool, len(stored))
+		for _, shortID := range stored {
+			isStored[shortID] = true
+		}
+
+		// Links that lost a race or hit a taken ID are retried
+		pending = make([]*models.URL, 0, len(links)-len(stored))
+		for _, link := range links {
+			if isStored[link.ShortID] {
+				shortIDs[link.DedupKey()] = link.ShortID
+			} else {
+				pending = append(pending, link)
+			}
+		}
+
+		log.Debug("Stored URL batch",
+			zap.Int("attempt", attempt),
+			zap.Int("found", len(found)),
+			zap.Int("stored", len(stored)),
+			zap.Int("retry", len(pending)))
+	}
+
+	span.SetAttributes(attribute.Int("unresolved_urls", len(batch)-len(shortIDs)))
+	return shortIDs, nil
+}
+
+// partialBatch returns the result of storeBatch when an attempt fails
+func partialBatch(shortIDs map[string]string, attempt int, err error) (map[string]string, error) {
+	if attempt == 1 {
+		return nil, err
+	}
+	return shortIDs, err
+}
+
+// BatchExpandURLs implements the BatchExpandURLs RPC method
+// Unknown, disabled, expired, password-protected and used up short IDs fail
+// individually; the rest still resolve. Click-limited links count down, and
+// a click is recorded, once per occurrence in the batch
+func (s *URLService) BatchExpandURLs(ctx context.Context, req *proto.BatchExpandURLsRequest) (*proto.BatchExpandURLsResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.BatchExpandURLs",
+		trace.WithAttributes(attribute.Int("batch_size", len(req.ShortIds))))
+	defer span.End()
+
+	if len(req.ShortIds) == 0 {
+		span.SetStatus(codes.Error, "empty batch")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "short_ids is required")
+	}
+	if err := s.checkBatchSize(ctx, len(req.ShortIds)); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Get all distinct links in one storage call
+	shortIDs := make([]string, 0, len(req.ShortIds))
+	seen := make(map[string]bool, len(req.ShortIds))
+	for _, shortID := range req.ShortIds {
+		if shortID != "" && !seen[shortID] {
+			seen[shortID] = true
+			shortIDs = append(shortIDs, shortID)
+		}
+	}
+	links, err := s.storage.GetMany(ctx, shortIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error("Failed to retrieve URL batch", zap.Error(err), zap.Int("batchSize", len(shortIDs)))
+		return nil, apperrors.FromStorage(err, "")
+	}
+
+	// Fill in the results in request order
+	now := s.now()
+	results := make([]*proto.BatchExpandURLResult, len(req.ShortIds))
+	resolved := make([]string, 0, len(req.ShortIds))
+	unresolved := 0
+	for i, shortID := range req.ShortIds {
+		result := &proto.BatchExpandURLResult{ShortId: shortID}
+		results[i] = result
+
+		link, ok := links[shortID]
+		var err error
+		switch {
+		case !ok:
+			err = apperrors.FromStorage(storage.ErrNotFound, shortID)
+		case link.Disabled:
+			err = apperrors.FailedPrecondition(apperrors.ReasonURLDisabled, "short URL disabled: %s", shortID)
+		case link.Quarantined():
+			err = apperrors.FailedPrecondition(apperrors.ReasonURLQuarantined, "short URL quarantined: %s", shortID)
+		case link.Expired(now):
+			err = apperrors.FailedPrecondition(apperrors.ReasonURLExpired, "short URL expired: %s", shortID)
+		case link.Scheduled(now):
+			err = apperrors.FailedPrecondition(apperrors.ReasonURLNotYetActive, "short URL not active yet: %s", shortID)
+		case link.PasswordProtected():
+			err = apperrors.PermissionDenied(apperrors.ReasonPasswordRequired, "short URL password protected: %s", shortID)
+		case link.ClickLimited():
+			err = s.consumeBatchClick(ctx, shortID)
+		}
+		if err != nil {
+			result.Reason, result.Error = batchError(err)
+			unresolved++
+			continue
+		}
+
+		destination, variantID := s.destination(ctx, link)
+		s.trackClick(ctx, link, destination, variantID)
+		result.OriginalUrl = destination
+		resolved = append(resolved, shortID)
+	}
+	s.recordAccess(ctx, resolved...)
+	span.SetAttributes(attribute.Int("unresolved_short_ids", unresolved))
+
+	log.Info("URL batch expanded",
+		zap.Int("batchSize", len(req.ShortIds)),
+		zap.Int("unresolved", unresolved))
+	return &proto.BatchExpandURLsResponse{Results: results}, nil
+}
+
+// consumeBatchClick takes one click from a click-limited link in a batch
+// It returns the per-item error, or nil if the link may resolve
+func (s *URLService) consumeBatchClick(ctx context.Context, shortID string) error {
+	_, err := s.storage.ConsumeClick(ctx, shortID)
+	if err != nil && err != storage.ErrClicksExhausted {
+		logger.FromContext(ctx).Error("Failed to consume click", zap.Error(err), zap.String("shortID", shortID))
+	}
+	return apperrors.FromStorage(err, shortID)
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/analytics"
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/utils"
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatchShortenURLs(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	existing, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	resp, err := s.BatchShortenURLs(ctx, &proto.BatchShortenURLsRequest{
+		OriginalUrls: []string{
+			"https://example.com/a",
+			"https://example.com/b",
+			"not a url",
+			"https://example.com/b",
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchShortenURLs returned unexpected error: %v", err)
+	}
+	if len(resp.Results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(resp.Results))
+	}
+
+	// Results come back in request order
+	for i, originalURL := range []string{"https://example.com/a", "https://example.com/b", "not a url", "https://example.com/b"} {
+		if resp.Results[i].OriginalUrl != originalURL {
+			t.Errorf("Result %d is for %s, expected %s", i, resp.Results[i].OriginalUrl, originalURL)
+		}
+	}
+
+	if resp.Results[0].ShortId != existing.ShortId {
+		t.Errorf("Expected existing short ID %s, got %s", existing.ShortId, resp.Results[0].ShortId)
+	}
+	if resp.Results[1].ShortId == "" || resp.Results[1].Error != "" {
+		t.Errorf("Expected a new short ID, got %+v", resp.Results[1])
+	}
+	if resp.Results[1].ShortUrl != "http://sho.rt/"+resp.Results[1].ShortId {
+		t.Errorf("Unexpected short URL %s", resp.Results[1].ShortUrl)
+	}
+	if resp.Results[2].Error == "" || resp.Results[2].Reason != string(apperrors.ReasonInvalidURL) || resp.Results[2].ShortId != "" {
+		t.Errorf("Expected an INVALID_URL error for the invalid URL, got %+v", resp.Results[2])
+	}
+	if resp.Results[3].ShortId != resp.Results[1].ShortId {
+		t.Errorf("Expected duplicates in a batch to share a short ID, got %s and %s", resp.Results[1].ShortId, resp.Results[3].ShortId)
+	}
+
+	// Batch links take part in dedup with ShortenURL
+	single, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/b"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if single.ShortId != resp.Results[1].ShortId {
+		t.Errorf("Expected short ID %s, got %s", resp.Results[1].ShortId, single.ShortId)
+	}
+}
+
+func TestBatchShortenURLs_Canonical(t *testing.T) {
+	s := newTestService(t)
+	s.canonical = config.CanonicalConfig{Enabled: true, StripParams: []string{"utm_*"}}
+	ctx := context.Background()
+
+	resp, err := s.BatchShortenURLs(ctx, &proto.BatchShortenURLsRequest{
+		OriginalUrls: []string{
+			"https://example.com/a?utm_source=x",
+			"HTTPS://EXAMPLE.com:443/a",
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchShortenURLs returned unexpected error: %v", err)
+	}
+	if resp.Results[0].ShortId == "" || resp.Results[0].ShortId != resp.Results[1].ShortId {
+		t.Errorf("Expected spellings of one URL to share a short ID, got %+v", resp.Results)
+	}
+	if resp.Results[1].OriginalUrl != "HTTPS://EXAMPLE.com:443/a" {
+		t.Errorf("Expected the result to echo the submitted URL, got %s", resp.Results[1].OriginalUrl)
+	}
+
+	single, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if single.ShortId != resp.Results[0].ShortId {
+		t.Errorf("Expected short ID %s, got %s", resp.Results[0].ShortId, single.ShortId)
+	}
+}
+
+// scriptedGenerator hands out fixed short IDs and calls onNext before each one
+type scriptedGenerator struct {
+	utils.IDGenerator
+	ids    []string
+	onNext func(n int)
+	n      int
+}
+
+func (g *scriptedGenerator) GenerateShortID() string {
+	g.onNext(g.n)
+	id := g.ids[g.n]
+	g.n++
+	return id
+}
+
+func TestBatchShortenURLs_PartialFailure(t *testing.T) {
+	s := newTestService(t)
+	s.quotas = config.QuotaConfig{Default: config.QuotaLimits{Total: 10}}
+	ctx := context.Background()
+
+	if _, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/taken", CustomAlias: "taken"}); err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	// The first URL hits a taken short ID, and the quota runs out before its retry
+	s.generator = &scriptedGenerator{
+		IDGenerator: s.generator,
+		ids:         []string{"taken", "fresh", "retry"},
+		onNext: func(n int) {
+			if n == 2 {
+				s.quotas.Default.Total = 2
+			}
+		},
+	}
+	resp, err := s.BatchShortenURLs(ctx, &proto.BatchShortenURLsRequest{
+		OriginalUrls: []string{"https://example.com/a", "https://example.com/b"},
+	})
+	if err != nil {
+		t.Fatalf("Expected links stored before the failure to be returned, got %v", err)
+	}
+	if resp.Results[0].ShortId != "" || resp.Results[0].Reason != string(apperrors.ReasonQuotaExceeded) {
+		t.Errorf("Expected the retried URL to fail with QUOTA_EXCEEDED, got %+v", resp.Results[0])
+	}
+	if resp.Results[1].ShortId != "fresh" || resp.Results[1].Error != "" {
+		t.Errorf("Expected the stored URL to keep its short ID, got %+v", resp.Results[1])
+	}
+}
+
+func TestBatchShortenURLs_TooLarge(t *testing.T) {
+	s := newTestService(t)
+	s.maxBatchSize = 2
+
+	_, err := s.BatchShortenURLs(context.Background(), &proto.BatchShortenURLsRequest{
+		OriginalUrls: []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestBatchExpandURLs(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	active, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	disabled, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/b"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if _, err := s.DisableURL(ctx, &proto.DisableURLRequest{ShortId: disabled.ShortId}); err != nil {
+		t.Fatalf("DisableURL returned unexpected error: %v", err)
+	}
+
+	resp, err := s.BatchExpandURLs(ctx, &proto.BatchExpandURLsRequest{
+		ShortIds: []string{active.ShortId, "missing", disabled.ShortId, active.ShortId},
+	})
+	if err != nil {
+		t.Fatalf("BatchExpandURLs returned unexpected error: %v", err)
+	}
+	if len(resp.Results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(resp.Results))
+	}
+
+	for _, i := range []int{0, 3} {
+		if resp.Results[i].ShortId != active.ShortId || resp.Results[i].OriginalUrl != "https://example.com/a" {
+			t.Errorf("Unexpected result %d: %+v", i, resp.Results[i])
+		}
+	}
+	if resp.Results[1].Reason != string(apperrors.ReasonURLNotFound) || resp.Results[1].OriginalUrl != "" {
+		t.Errorf("Expected URL_NOT_FOUND for the unknown short ID, got %+v", resp.Results[1])
+	}
+	if resp.Results[2].Reason != string(apperrors.ReasonURLDisabled) || resp.Results[2].Error != "short URL disabled: "+disabled.ShortId {
+		t.Errorf("Expected URL_DISABLED for the disabled short ID, got %+v", resp.Results[2])
+	}
+}
+
+func TestBatchExpandURLs_MaxClicks(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	limited, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/download", MaxClicks: 1})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	// Each occurrence counts, so only the first one resolves
+	resp, err := s.BatchExpandURLs(ctx, &proto.BatchExpandURLsRequest{
+		ShortIds: []string{limited.ShortId, limited.ShortId},
+	})
+	if err != nil {
+		t.Fatalf("BatchExpandURLs returned unexpected error: %v", err)
+	}
+	if resp.Results[0].OriginalUrl != "https://example.com/download" {
+		t.Errorf("Expected the first occurrence to resolve, got %+v", resp.Results[0])
+	}
+	if resp.Results[1].Reason != string(apperrors.ReasonURLExhausted) || resp.Results[1].OriginalUrl != "" {
+		t.Errorf("Expected the second occurrence to fail with URL_EXHAUSTED, got %+v", resp.Results[1])
+	}
+}
+
+func TestBatchExpandURLs_TracksClicks(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	split, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/landing",
+		Variants: []*proto.Variant{
+			{VariantId: "a", DestinationUrl: "https://example.com/a", Weight: 1},
+			{VariantId: "b", DestinationUrl: "https://example.com/b", Weight: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	sub := s.clickHub.Subscribe(analytics.ClickFilter{})
+	defer s.clickHub.Unsubscribe(sub)
+
+	resp, err := s.BatchExpandURLs(ctx, &proto.BatchExpandURLsRequest{ShortIds: []string{split.ShortId, "missing", split.ShortId}})
+	if err != nil {
+		t.Fatalf("BatchExpandURLs returned unexpected error: %v", err)
+	}
+
+	// Only resolved items are clicks, each with the variant it was served
+	destinations := map[string]string{"a": "https://example.com/a", "b": "https://example.com/b"}
+	for _, i := range []int{0, 2} {
+		click := <-sub.Events()
+		if click.ShortID != split.ShortId || destinations[click.Variant] != resp.Results[i].OriginalUrl {
+			t.Errorf("Click %+v does not match result %+v", click, resp.Results[i])
+		}
+	}
+	select {
+	case click := <-sub.Events():
+		t.Errorf("Unexpected click for an unresolved item: %+v", click)
+	default:
+	}
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/utils"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateCampaign implements the CreateCampaign RPC method
+func (s *URLService) CreateCampaign(ctx context.Context, req *proto.CreateCampaignRequest) (*proto.CreateCampaignResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.CreateCampaign",
+		trace.WithAttributes(attribute.String("campaign_id", req.CampaignId)))
+	defer span.End()
+
+	campaign, err := s.newCampaign(req.CampaignId, req.Name, req.UtmParams)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.campaigns.CreateCampaign(ctx, campaign); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrAlreadyExists {
+			log.Warn("Campaign already exists", zap.String("campaignID", campaign.ID))
+		} else {
+			log.Error("Failed to create campaign", zap.Error(err), zap.String("campaignID", campaign.ID))
+		}
+		return nil, campaignError(err, campaign.ID)
+	}
+
+	log.Info("Campaign created",
+		zap.String("campaignID", campaign.ID),
+		zap.Int("params", len(campaign.Params)))
+	return &proto.CreateCampaignResponse{Campaign: toProtoCampaign(campaign)}, nil
+}
+
+// GetCampaign implements the GetCampaign RPC method
+func (s *URLService) GetCampaign(ctx context.Context, req *proto.GetCampaignRequest) (*proto.GetCampaignResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "URLService.GetCampaign",
+		trace.WithAttributes(attribute.String("campaign_id", req.CampaignId)))
+	defer span.End()
+
+	campaign, err := s.getCampaign(ctx, req.CampaignId)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return &proto.GetCampaignResponse{Campaign: toProtoCampaign(campaign)}, nil
+}
+
+// UpdateCampaign implements the UpdateCampaign RPC method
+func (s *URLService) UpdateCampaign(ctx context.Context, req *proto.UpdateCampaignRequest) (*proto.UpdateCampaignResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.UpdateCampaign",
+		trace.WithAttributes(attribute.String("campaign_id", req.CampaignId)))
+	defer span.End()
+
+	campaign, err := s.newCampaign(req.CampaignId, req.Name, req.UtmParams)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.campaigns.UpdateCampaign(ctx, campaign); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrNotFound {
+			log.Warn("Campaign not found", zap.String("campaignID", campaign.ID))
+		} else {
+			log.Error("Failed to update campaign", zap.Error(err), zap.String("campaignID", campaign.ID))
+		}
+		return nil, campaignError(err, campaign.ID)
+	}
+
+	log.Info("Campaign updated",
+		zap.String("campaignID", campaign.ID),
+		zap.Int("params", len(campaign.Params)))
+	return &proto.UpdateCampaignResponse{Campaign: toProtoCampaign(campaign)}, nil
+}
+
+// SetURLCampaign implements the SetURLCampaign RPC method
+func (s *URLService) SetURLCampaign(ctx context.Context, req *proto.SetURLCampaignRequest) (*proto.SetURLCampaignResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.SetURLCampaign",
+		trace.WithAttributes(
+			attribute.String("short_id", req.ShortId),
+			attribute.String("campaign_id", req.CampaignId)))
+	defer span.End()
+
+	if req.ShortId == "" {
+		span.SetStatus(codes.Error, "missing short_id")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "short_id is required")
+	}
+	if s.campaigns == nil {
+		err := apperrors.FromStorage(storage.ErrUnsupported, "")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if req.CampaignId != "" {
+		if _, err := s.getCampaign(ctx, req.CampaignId); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+	}
+
+	if err := s.campaigns.SetCampaign(ctx, req.ShortId, req.CampaignId); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrNotFound {
+			log.Warn("Short URL not found", zap.String("shortID", req.ShortId))
+		} else {
+			log.Error("Failed to set URL campaign", zap.Error(err), zap.String("shortID", req.ShortId))
+		}
+		return nil, apperrors.FromStorage(err, req.ShortId)
+	}
+
+	log.Info("URL campaign set",
+		zap.String("shortID", req.ShortId),
+		zap.String("campaignID", req.CampaignId))
+	return &proto.SetURLCampaignResponse{}, nil
+}
+
+// newCampaign validates a requested campaign
+func (s *URLService) newCampaign(campaignID string, name string, params map[string]string) (*models.Campaign, error) {
+	if s.campaigns == nil {
+		return nil, apperrors.FromStorage(storage.ErrUnsupported, "")
+	}
+	if err := utils.ValidateCampaignID(campaignID); err != nil {
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidCampaign, "%v", err).
+			WithMetadata("campaign_id", campaignID)
+	}
+	normalized, err := utils.NormalizeUTMParams(params)
+	if err != nil {
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidCampaign, "%v", err).
+			WithMetadata("campaign_id", campaignID)
+	}
+	return &models.Campaign{ID: campaignID, Name: name, Params: normalized}, nil
+}
+
+// getCampaign returns a campaign of the context's tenant as a catalog error
+// when it cannot be read
+func (s *URLService) getCampaign(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	if s.campaigns == nil {
+		return nil, apperrors.FromStorage(storage.ErrUnsupported, "")
+	}
+	if campaignID == "" {
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "campaign_id is required")
+	}
+
+	campaign, err := s.campaigns.GetCampaign(ctx, campaignID)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			logger.FromContext(ctx).Warn("Campaign not found", zap.String("campaignID", campaignID))
+		} else {
+			logger.FromContext(ctx).Error("Failed to get campaign", zap.Error(err), zap.String("campaignID", campaignID))
+		}
+		return nil, campaignError(err, campaignID)
+	}
+	return campaign, nil
+}
+
+// addCampaign returns target with the UTM parameters of the link's campaign
+// added. A campaign that cannot be read leaves target as it is, as a
+// redirect without tracking beats a failed one
+func (s *URLService) addCampaign(ctx context.Context, link *models.URL, target string) string {
+	if link.CampaignID == "" || s.campaigns == nil {
+		return target
+	}
+
+	log := logger.FromContext(ctx)
+	campaign, err := s.campaigns.GetCampaign(ctx, link.CampaignID)
+	if err != nil {
+		log.Warn("Cannot read link campaign, redirecting without it",
+			zap.String("shortID", link.ShortID),
+			zap.String("campaignID", link.CampaignID),
+			zap.Error(err))
+		return target
+	}
+
+	destination, err := utils.AddQueryParams(target, campaign.Params)
+	if err != nil {
+		log.Warn("Cannot add campaign parameters, redirecting without them",
+			zap.String("shortID", link.ShortID),
+			zap.String("campaignID", link.CampaignID),
+			zap.Error(err))
+		return target
+	}
+	return destination
+}
+
+// campaignError maps a storage error about a campaign onto the catalog
+func campaignError(err error, campaignID string) error {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return apperrors.NotFound(apperrors.ReasonCampaignNotFound, "campaign not found: %s", campaignID).
+			WithMetadata("campaign_id", campaignID)
+	case errors.Is(err, storage.ErrAlreadyExists):
+		return apperrors.AlreadyExists(apperrors.ReasonCampaignTaken, "campaign already exists: %s", campaignID).
+			WithMetadata("campaign_id", campaignID)
+	default:
+		return apperrors.FromStorage(err, "")
+	}
+}
+
+// toProtoCampaign converts a stored campaign into its API representation
+func toProtoCampaign(campaign *models.Campaign) *proto.Campaign {
+	return &proto.Campaign{
+		CampaignId: campaign.ID,
+		Name:       campaign.Name,
+		UtmParams:  campaign.Params,
+		CreatedAt:  timestamppb.New(campaign.CreatedAt),
+		UpdatedAt:  timestamppb.New(campaign.UpdatedAt),
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCampaign_ExpandAddsUTMParams(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	_, err := s.CreateCampaign(ctx, &proto.CreateCampaignRequest{
+		CampaignId: "spring-sale",
+		Name:       "Spring sale",
+		UtmParams:  map[string]string{"UTM_Source": "newsletter", "utm_medium": "email"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCampaign returned unexpected error: %v", err)
+	}
+
+	plain, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a?utm_medium=banner"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/a?utm_medium=banner",
+		CampaignId:  "spring-sale",
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if resp.ShortId == plain.ShortId {
+		t.Errorf("Expected a campaign link not to share the dedup link")
+	}
+
+	// Parameters the destination already has are kept
+	expanded, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	if want := "https://example.com/a?utm_medium=banner&utm_source=newsletter"; expanded.OriginalUrl != want {
+		t.Errorf("Expected %s, got %s", want, expanded.OriginalUrl)
+	}
+
+	// Updating the campaign changes the destination without touching the link
+	_, err = s.UpdateCampaign(ctx, &proto.UpdateCampaignRequest{
+		CampaignId: "spring-sale",
+		UtmParams:  map[string]string{"utm_source": "partner", "utm_campaign": "spring"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateCampaign returned unexpected error: %v", err)
+	}
+	batch, err := s.BatchExpandURLs(ctx, &proto.BatchExpandURLsRequest{ShortIds: []string{resp.ShortId}})
+	if err != nil {
+		t.Fatalf("BatchExpandURLs returned unexpected error: %v", err)
+	}
+	if want := "https://example.com/a?utm_medium=banner&utm_campaign=spring&utm_source=partner"; batch.Results[0].OriginalUrl != want {
+		t.Errorf("Expected %s after the update, got %s", want, batch.Results[0].OriginalUrl)
+	}
+
+	info, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if info.Info.OriginalUrl != "https://example.com/a?utm_medium=banner" || info.Info.CampaignId != "spring-sale" {
+		t.Errorf("Expected the stored link to be unchanged, got %s in campaign %q", info.Info.OriginalUrl, info.Info.CampaignId)
+	}
+
+	// Detached links resolve to the stored URL again
+	if _, err := s.SetURLCampaign(ctx, &proto.SetURLCampaignRequest{ShortId: resp.ShortId}); err != nil {
+		t.Fatalf("SetURLCampaign returned unexpected error: %v", err)
+	}
+	expanded, err = s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	if expanded.OriginalUrl != "https://example.com/a?utm_medium=banner" {
+		t.Errorf("Expected the stored URL after detaching, got %s", expanded.OriginalUrl)
+	}
+}
+
+func TestCampaign_Errors(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	created, err := s.CreateCampaign(ctx, &proto.CreateCampaignRequest{
+		CampaignId: "q3",
+		UtmParams:  map[string]string{"utm_source": "ads"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCampaign returned unexpected error: %v", err)
+	}
+	if created.Campaign.UtmParams["utm_source"] != "ads" || created.Campaign.CreatedAt == nil {
+		t.Errorf("Unexpected created campaign %v", created.Campaign)
+	}
+
+	_, err = s.CreateCampaign(ctx, &proto.CreateCampaignRequest{CampaignId: "q3"})
+	if status.Code(err) != codes.AlreadyExists || apperrors.ReasonOf(err) != apperrors.ReasonCampaignTaken {
+		t.Errorf("Expected AlreadyExists CAMPAIGN_TAKEN, got %v", err)
+	}
+
+	invalid := []*proto.CreateCampaignRequest{
+		{CampaignId: ""},
+		{CampaignId: "bad id"},
+		{CampaignId: "q4", UtmParams: map[string]string{"ref": "x"}},
+	}
+	for _, req := range invalid {
+		_, err := s.CreateCampaign(ctx, req)
+		if status.Code(err) != codes.InvalidArgument || apperrors.ReasonOf(err) != apperrors.ReasonInvalidCampaign {
+			t.Errorf("CreateCampaign(%v): expected InvalidArgument INVALID_CAMPAIGN, got %v", req, err)
+		}
+	}
+
+	_, err = s.GetCampaign(ctx, &proto.GetCampaignRequest{CampaignId: "missing"})
+	if status.Code(err) != codes.NotFound || apperrors.ReasonOf(err) != apperrors.ReasonCampaignNotFound {
+		t.Errorf("Expected NotFound CAMPAIGN_NOT_FOUND from GetCampaign, got %v", err)
+	}
+	_, err = s.UpdateCampaign(ctx, &proto.UpdateCampaignRequest{CampaignId: "missing"})
+	if status.Code(err) != codes.NotFound || apperrors.ReasonOf(err) != apperrors.ReasonCampaignNotFound {
+		t.Errorf("Expected NotFound CAMPAIGN_NOT_FOUND from UpdateCampaign, got %v", err)
+	}
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a", CampaignId: "missing"})
+	if status.Code(err) != codes.NotFound || apperrors.ReasonOf(err) != apperrors.ReasonCampaignNotFound {
+		t.Errorf("Expected NotFound CAMPAIGN_NOT_FOUND from ShortenURL, got %v", err)
+	}
+	_, err = s.SetURLCampaign(ctx, &proto.SetURLCampaignRequest{ShortId: "missing", CampaignId: "q3"})
+	if status.Code(err) != codes.NotFound || apperrors.ReasonOf(err) != apperrors.ReasonURLNotFound {
+		t.Errorf("Expected NotFound URL_NOT_FOUND from SetURLCampaign, got %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultPageSize is used when ListURLs is called without a page size
+	defaultPageSize = 50
+	// maxPageSize caps the page size of ListURLs
+	maxPageSize = 1000
+)
+
+// errInvalidPageToken is returned when a page token cannot be decoded
+var errInvalidPageToken = errors.New("invalid page_token")
+
+// ListURLs implements the ListURLs RPC method
+func (s *URLService) ListURLs(ctx context.Context, req *proto.ListURLsRequest) (*proto.ListURLsResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.ListURLs",
+		trace.WithAttributes(
+			attribute.Int("page_size", int(req.PageSize)),
+			attribute.String("domain", req.Domain)))
+	defer span.End()
+
+	opts, err := listOptions(req)
+	if err != nil {
+		log.Warn("Invalid list request", zap.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	pageSize := opts.Limit
+
+	// Fetch one extra link to learn whether there is a next page
+	opts.Limit++
+	links, err := s.storage.List(ctx, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrUnsupported {
+			log.Warn("Listing not supported by storage")
+		} else {
+			log.Error("Failed to list URLs", zap.Error(err))
+		}
+		return nil, apperrors.FromStorage(err, "")
+	}
+
+	response := &proto.ListURLsResponse{}
+	if len(links) > pageSize {
+		links = links[:pageSize]
+		last := links[pageSize-1]
+		response.NextPageToken = encodePageToken(last.CreatedAt, last.ShortID)
+	}
+	if err := s.loadTags(ctx, links); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	response.Urls = make([]*proto.URLInfo, len(links))
+	for i, link := range links {
+		response.Urls[i] = s.toURLInfo(link)
+	}
+
+	span.SetAttributes(attribute.Int("result_count", len(links)))
+	log.Debug("URLs listed",
+		zap.Int("count", len(links)),
+		zap.Bool("hasMore", response.NextPageToken != ""))
+	return response, nil
+}
+
+// listOptions validates a ListURLs request and turns it into storage list options
+func listOptions(req *proto.ListURLsRequest) (storage.ListOptions, error) {
+	opts := storage.ListOptions{Domain: req.Domain}
+
+	limit, err := resolvePageSize(req.PageSize)
+	if err != nil {
+		return opts, err
+	}
+	opts.Limit = limit
+
+	if req.PageToken != "" {
+		createdAt, shortID, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return opts, apperrors.InvalidArgument(apperrors.ReasonInvalidPageToken, "invalid page_token").WithCause(err)
+		}
+		opts.AfterCreatedAt = createdAt
+		opts.AfterShortID = shortID
+	}
+
+	bounds := []struct {
+		name  string
+		value *timestamppb.Timestamp
+		dest  **time.Time
+	}{
+		{"created_after", req.CreatedAfter, &opts.CreatedFrom},
+		{"created_before", req.CreatedBefore, &opts.CreatedTo},
+		{"accessed_after", req.AccessedAfter, &opts.AccessedFrom},
+		{"accessed_before", req.AccessedBefore, &opts.AccessedTo},
+	}
+	for _, bound := range bounds {
+		if bound.value == nil {
+			continue
+		}
+		if err := bound.value.CheckValid(); err != nil {
+			return opts, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "invalid %s: %v", bound.name, err)
+		}
+		t := bound.value.AsTime()
+		*bound.dest = &t
+	}
+
+	return opts, nil
+}
+
+// resolvePageSize applies the default and the cap to a requested page size
+func resolvePageSize(requested int32) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "page_size must not be negative")
+	case requested == 0:
+		return defaultPageSize, nil
+	case requested > maxPageSize:
+		return maxPageSize, nil
+	default:
+		return int(requested), nil
+	}
+}
+
+// encodePageToken builds an opaque page token pointing after the given link
+func encodePageToken(createdAt time.Time, shortID string) string {
+	raw := strconv.FormatInt(createdAt.UnixNano(), 10) + ":" + shortID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePageToken reverses encodePageToken
+func decodePageToken(token string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, "", errInvalidPageToken
+	}
+
+	nanos, shortID, found := strings.Cut(string(raw), ":")
+	if !found {
+		return time.Time{}, "", errInvalidPageToken
+	}
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, "", errInvalidPageToken
+	}
+	return time.Unix(0, unixNano).UTC(), shortID, nil
+}
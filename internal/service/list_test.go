@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListURLs_Pagination(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	var created []string
+	for _, originalURL := range []string{
+		"https://example.com/1",
+		"https://example.com/2",
+		"https://other.example.org/3",
+		"https://EXAMPLE.com/4",
+		"https://example.com/5",
+	} {
+		resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: originalURL})
+		if err != nil {
+			t.Fatalf("ShortenURL returned unexpected error: %v", err)
+		}
+		created = append(created, resp.ShortId)
+	}
+
+	// Walk all pages
+	var listed []string
+	token := ""
+	for pages := 0; ; pages++ {
+		if pages > len(created) {
+			t.Fatalf("Pagination did not terminate")
+		}
+		resp, err := s.ListURLs(ctx, &proto.ListURLsRequest{PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListURLs returned unexpected error: %v", err)
+		}
+		if len(resp.Urls) > 2 {
+			t.Errorf("Expected at most 2 URLs per page, got %d", len(resp.Urls))
+		}
+		for _, info := range resp.Urls {
+			listed = append(listed, info.ShortId)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		token = resp.NextPageToken
+	}
+
+	if len(listed) != len(created) {
+		t.Fatalf("Expected %d URLs, got %d", len(created), len(listed))
+	}
+	for i := range created {
+		if listed[i] != created[i] {
+			t.Errorf("URL %d: expected %s, got %s", i, created[i], listed[i])
+		}
+	}
+
+	// Domain filter matches the host, ignoring case
+	resp, err := s.ListURLs(ctx, &proto.ListURLsRequest{Domain: "Example.com"})
+	if err != nil {
+		t.Fatalf("ListURLs returned unexpected error: %v", err)
+	}
+	if len(resp.Urls) != 4 {
+		t.Errorf("Expected 4 URLs for example.com, got %d", len(resp.Urls))
+	}
+}
+
+func TestListURLs_Filters(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	first, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	cutoff := time.Now()
+	second, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/b"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	resp, err := s.ListURLs(ctx, &proto.ListURLsRequest{CreatedAfter: timestamppb.New(cutoff)})
+	if err != nil {
+		t.Fatalf("ListURLs returned unexpected error: %v", err)
+	}
+	if len(resp.Urls) != 1 || resp.Urls[0].ShortId != second.ShortId {
+		t.Errorf("Expected only %s created after the cutoff, got %v", second.ShortId, resp.Urls)
+	}
+
+	// Only accessed links match an access range
+	if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: first.ShortId}); err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	s.accesses.Flush()
+	resp, err = s.ListURLs(ctx, &proto.ListURLsRequest{AccessedAfter: timestamppb.New(cutoff)})
+	if err != nil {
+		t.Fatalf("ListURLs returned unexpected error: %v", err)
+	}
+	if len(resp.Urls) != 1 || resp.Urls[0].ShortId != first.ShortId {
+		t.Errorf("Expected only %s accessed after the cutoff, got %v", first.ShortId, resp.Urls)
+	}
+	if resp.Urls[0].LastAccessed == nil {
+		t.Errorf("Expected last_accessed to be set")
+	}
+}
+
+func TestListURLs_InvalidPageToken(t *testing.T) {
+	s := newTestService(t)
+
+	_, err := s.ListURLs(context.Background(), &proto.ListURLsRequest{PageToken: "not a token"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/analytics"
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/auth"
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"github.com/hohotang/shortlink-core/internal/utils"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// maxTrackedGuesses bounds the throttle state before stale entries are swept
+const maxTrackedGuesses = 10000
+
+// hashLinkPassword validates the requested password and returns its salted
+// hash; an empty password leaves the link unprotected
+func hashLinkPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+	if err := utils.ValidatePassword(password); err != nil {
+		return "", apperrors.InvalidArgument(apperrors.ReasonInvalidPassword, "%s", err.Error())
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return "", apperrors.Internal(apperrors.ReasonInternal, "failed to protect link").WithCause(err)
+	}
+	return hash, nil
+}
+
+// checkLinkPassword verifies the password given for a protected link
+// Wrong guesses count towards locking the guessing client out of the short ID;
+// while it is locked out even the right password is refused, so guessing cannot
+// continue. Other clients are not affected, so nobody can lock a link for everyone
+func (s *URLService) checkLinkPassword(ctx context.Context, link *models.URL, password string) error {
+	if !link.PasswordProtected() {
+		return nil
+	}
+	log := logger.FromContext(ctx)
+
+	// The same short ID may exist in several tenants, each throttled on its own
+	key := tenant.FromContext(ctx) + "/" + link.ShortID + "/" + guessClient(ctx)
+
+	if retryAfter, locked := s.guesses.locked(key); locked {
+		log.Warn("Password guesses throttled",
+			zap.String("shortID", link.ShortID),
+			zap.Duration("retryAfter", retryAfter))
+		return apperrors.ResourceExhausted(apperrors.ReasonTooManyAttempts, "too many wrong passwords for short URL: %s", link.ShortID).
+			WithMetadata("short_id", link.ShortID).
+			WithMetadata("retry_after_seconds", strconv.Itoa(int(retryAfter.Seconds()+0.5)))
+	}
+
+	if password == "" {
+		return apperrors.PermissionDenied(apperrors.ReasonPasswordRequired, "short URL is password protected: %s", link.ShortID).
+			WithMetadata("short_id", link.ShortID)
+	}
+
+	ok, err := s.verifyPassword(ctx, link.PasswordHash, password)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		s.guesses.fail(key)
+		log.Warn("Wrong password for short URL", zap.String("shortID", link.ShortID))
+		return apperrors.PermissionDenied(apperrors.ReasonWrongPassword, "wrong password for short URL: %s", link.ShortID).
+			WithMetadata("short_id", link.ShortID)
+	}
+
+	s.guesses.reset(key)
+	return nil
+}
+
+// verifyPassword checks a password against its hash
+// Key derivation is deliberately slow, so only cap(s.verifySlots) run at once
+// and the rest wait their turn rather than exhausting the CPU
+func (s *URLService) verifyPassword(ctx context.Context, hash, password string) (bool, error) {
+	select {
+	case s.verifySlots <- struct{}{}:
+	case <-ctx.Done():
+		return false, status.FromContextError(ctx.Err()).Err()
+	}
+	defer func() { <-s.verifySlots }()
+
+	return utils.VerifyPassword(hash, password), nil
+}
+
+// newVerifySlots creates the semaphore bounding concurrent password checks
+func newVerifySlots(cfg config.PasswordConfig) chan struct{} {
+	slots := cfg.MaxConcurrentChecks
+	if slots <= 0 {
+		slots = runtime.NumCPU()
+	}
+	return make(chan struct{}, slots)
+}
+
+// guessClient names the client guessing a password. Behind the gateway that
+// is the visitor whose address the gateway forwarded, as recorded for clicks,
+// scoped to the forwarding caller so one caller cannot lock out another's
+// visitors. Without a forwarded address the caller itself is the client: its
+// API key when the request was authenticated, otherwise its peer IP
+func guessClient(ctx context.Context) string {
+	caller := "ip:" + peerIP(ctx)
+	if key, ok := auth.FromContext(ctx); ok {
+		caller = "key:" + key.ID
+	}
+
+	if visitor := analytics.ClientIPFromContext(ctx); visitor != "" {
+		return caller + "/visitor:" + visitor
+	}
+	return caller
+}
+
+// peerIP returns the IP address of the caller, without the port
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// guessThrottle locks clients out of links after too many wrong passwords
+// State is kept in memory, so each instance throttles on its own
+type guessThrottle struct {
+	maxFailures int
+	window      time.Duration
+	lockout     time.Duration
+	now         func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*guessEntry
+}
+
+// guessEntry counts the wrong passwords of one client for one link
+type guessEntry struct {
+	failures    int
+	windowStart time.Time
+	lockedUntil time.Time
+}
+
+// newGuessThrottle creates a throttle from configuration
+// A non-positive MaxFailures disables throttling
+func newGuessThrottle(cfg config.PasswordConfig) *guessThrottle {
+	return &guessThrottle{
+		maxFailures: cfg.MaxFailures,
+		window:      cfg.Window,
+		lockout:     cfg.Lockout,
+		now:         time.Now,
+		entries:     make(map[string]*guessEntry),
+	}
+}
+
+// locked reports whether the key is locked and for how much longer
+func (t *guessThrottle) locked(key string) (time.Duration, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	entry, ok := t.entries[key]
+	if !ok {
+		return 0, false
+	}
+	remaining := entry.lockedUntil.Sub(t.now())
+	return remaining, remaining > 0
+}
+
+// fail records a wrong password, locking the key once the limit is hit
+func (t *guessThrottle) fail(key string) {
+	if t.maxFailures <= 0 {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.now()
+	entry, ok := t.entries[key]
+	if !ok {
+		if len(t.entries) >= maxTrackedGuesses {
+			t.sweep(now)
+		}
+		entry = &guessEntry{windowStart: now}
+		t.entries[key] = entry
+	} else if now.Sub(entry.windowStart) >= t.window {
+		entry.failures = 0
+		entry.windowStart = now
+	}
+
+	entry.failures++
+	if entry.failures >= t.maxFailures {
+		entry.lockedUntil = now.Add(t.lockout)
+		entry.failures = 0
+		entry.windowStart = now
+	}
+}
+
+// reset forgets the wrong passwords of a key after a right one
+func (t *guessThrottle) reset(key string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.entries, key)
+}
+
+// sweep drops entries whose window and lockout have both passed
+// Callers must hold the mutex
+func (t *guessThrottle) sweep(now time.Time) {
+	for key, entry := range t.entries {
+		if now.Sub(entry.windowStart) >= t.window && !now.Before(entry.lockedUntil) {
+			delete(t.entries, key)
+		}
+	}
+}
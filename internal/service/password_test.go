@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/auth"
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestExpandURL_PasswordProtected(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	plain, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/partner"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	protected, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/partner",
+		Password:    "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	// Protected links never share the dedup short ID
+	if protected.ShortId == plain.ShortId {
+		t.Errorf("Expected a new short ID for a protected link")
+	}
+
+	// Only the salted hash is stored
+	link, err := s.storage.GetInfo(ctx, protected.ShortId)
+	if err != nil {
+		t.Fatalf("GetInfo returned unexpected error: %v", err)
+	}
+	if link.PasswordHash == "" || strings.Contains(link.PasswordHash, "s3cret") {
+		t.Errorf("Expected a salted hash, got %q", link.PasswordHash)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		code     codes.Code
+		reason   apperrors.Reason
+	}{
+		{name: "Missing password", password: "", code: codes.PermissionDenied, reason: apperrors.ReasonPasswordRequired},
+		{name: "Wrong password", password: "guess", code: codes.PermissionDenied, reason: apperrors.ReasonWrongPassword},
+		{name: "Right password", password: "s3cret", code: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: protected.ShortId, Password: tt.password})
+			if status.Code(err) != tt.code {
+				t.Fatalf("Expected %v, got %v", tt.code, err)
+			}
+			if err != nil {
+				if reason := apperrors.ReasonOf(err); reason != tt.reason {
+					t.Errorf("Expected reason %s, got %s", tt.reason, reason)
+				}
+				return
+			}
+			if resp.OriginalUrl != "https://example.com/partner" {
+				t.Errorf("Unexpected original URL %s", resp.OriginalUrl)
+			}
+		})
+	}
+
+	// Batch expansion cannot carry a password
+	batch, err := s.BatchExpandURLs(ctx, &proto.BatchExpandURLsRequest{ShortIds: []string{protected.ShortId}})
+	if err != nil {
+		t.Fatalf("BatchExpandURLs returned unexpected error: %v", err)
+	}
+	if batch.Results[0].OriginalUrl != "" || batch.Results[0].Error == "" {
+		t.Errorf("Expected protected link to fail in batch, got %v", batch.Results[0])
+	}
+
+	info, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: protected.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if !info.Info.PasswordProtected {
+		t.Errorf("Expected info to report password protection")
+	}
+}
+
+func TestExpandURL_PasswordThrottled(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	s.guesses = newGuessThrottle(config.PasswordConfig{MaxFailures: 3, Window: time.Minute, Lockout: time.Minute})
+	s.guesses.now = func() time.Time { return now }
+
+	protected, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/partner",
+		Password:    "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	expand := func(password string) error {
+		_, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: protected.ShortId, Password: password})
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := expand("guess"); status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("Guess %d: expected PermissionDenied, got %v", i+1, err)
+		}
+	}
+
+	// Locked: even the right password is refused
+	err = expand("s3cret")
+	if status.Code(err) != codes.ResourceExhausted || apperrors.ReasonOf(err) != apperrors.ReasonTooManyAttempts {
+		t.Fatalf("Expected ResourceExhausted while locked, got %v", err)
+	}
+
+	// Other clients of the same link are not locked out, whether they connect
+	// directly or their address is forwarded by the gateway
+	elsewhere := peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 443}})
+	if _, err := s.ExpandURL(elsewhere, &proto.ExpandURLRequest{ShortId: protected.ShortId, Password: "s3cret"}); err != nil {
+		t.Errorf("Expected another client to resolve the link, got %v", err)
+	}
+	visitor := metadata.NewIncomingContext(ctx, metadata.Pairs("x-client-ip", "198.51.100.1"))
+	if _, err := s.ExpandURL(visitor, &proto.ExpandURLRequest{ShortId: protected.ShortId, Password: "s3cret"}); err != nil {
+		t.Errorf("Expected another visitor behind the gateway to resolve the link, got %v", err)
+	}
+
+	// Other links are not affected
+	other, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/other", Password: "s3cret"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: other.ShortId, Password: "s3cret"}); err != nil {
+		t.Errorf("Expected other link to resolve, got %v", err)
+	}
+
+	// The lock lifts after the lockout
+	now = now.Add(time.Minute)
+	if err := expand("s3cret"); err != nil {
+		t.Errorf("Expected link to resolve after the lockout, got %v", err)
+	}
+}
+
+func TestGuessClient(t *testing.T) {
+	gateway := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 50000}})
+	withKey := auth.WithIdentity(gateway, &models.APIKey{ID: "gateway"})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"peer", gateway, "ip:10.0.0.2"},
+		{"api key", withKey, "key:gateway"},
+		{"client ip", metadata.NewIncomingContext(gateway, metadata.Pairs("x-client-ip", "203.0.113.7")), "ip:10.0.0.2/visitor:203.0.113.7"},
+		{"forwarded for", metadata.NewIncomingContext(withKey, metadata.Pairs("x-forwarded-for", "198.51.100.1, 10.0.0.1")), "key:gateway/visitor:198.51.100.1"},
+		{"client ip first", metadata.NewIncomingContext(gateway, metadata.Pairs("x-client-ip", "203.0.113.7", "x-forwarded-for", "198.51.100.1")), "ip:10.0.0.2/visitor:203.0.113.7"},
+		{"no peer", context.Background(), "ip:unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := guessClient(tt.ctx); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestVerifyPassword_Concurrency(t *testing.T) {
+	s := newTestService(t)
+	s.verifySlots = newVerifySlots(config.PasswordConfig{MaxConcurrentChecks: 1})
+
+	hash, err := hashLinkPassword("s3cret")
+	if err != nil {
+		t.Fatalf("hashLinkPassword returned unexpected error: %v", err)
+	}
+
+	// With every slot taken, a check waits until the request gives up
+	s.verifySlots <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.verifyPassword(ctx, hash, "s3cret"); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("Expected DeadlineExceeded while all slots are taken, got %v", err)
+	}
+
+	// A freed slot lets the check through
+	<-s.verifySlots
+	ok, err := s.verifyPassword(context.Background(), hash, "s3cret")
+	if err != nil || !ok {
+		t.Errorf("Expected the right password to verify, got %v (%v)", ok, err)
+	}
+}
+
+func TestGuessThrottle_Window(t *testing.T) {
+	now := time.Now()
+	throttle := newGuessThrottle(config.PasswordConfig{MaxFailures: 2, Window: time.Minute, Lockout: time.Hour})
+	throttle.now = func() time.Time { return now }
+
+	// Failures spread over more than a window never lock
+	throttle.fail("abc")
+	now = now.Add(time.Minute)
+	throttle.fail("abc")
+	if _, locked := throttle.locked("abc"); locked {
+		t.Errorf("Expected failures in separate windows not to lock")
+	}
+
+	throttle.fail("abc")
+	retryAfter, locked := throttle.locked("abc")
+	if !locked || retryAfter != time.Hour {
+		t.Errorf("Expected a one hour lock, got %v (locked %v)", retryAfter, locked)
+	}
+
+	// Disabled throttling never locks
+	disabled := newGuessThrottle(config.PasswordConfig{})
+	for i := 0; i < 10; i++ {
+		disabled.fail("abc")
+	}
+	if _, locked := disabled.locked("abc"); locked {
+		t.Errorf("Expected disabled throttle not to lock")
+	}
+}
+
+func TestShortenURL_PasswordTooLong(t *testing.T) {
+	s := newTestService(t)
+
+	_, err := s.ShortenURL(context.Background(), &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/partner",
+		Password:    strings.Repeat("a", 129),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument, got %v", err)
+	}
+	if _, err := s.storage.Find(context.Background(), "https://example.com/partner"); err != storage.ErrNotFound {
+		t.Errorf("Expected nothing to be stored, got %v", err)
+	}
+}
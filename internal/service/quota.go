@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// GetUsage implements the GetUsage RPC method
+func (s *URLService) GetUsage(ctx context.Context, req *proto.GetUsageRequest) (*proto.GetUsageResponse, error) {
+	tenantID := tenant.FromContext(ctx)
+	ctx, span := s.tracer.Start(ctx, "URLService.GetUsage",
+		trace.WithAttributes(attribute.String("tenant_id", tenantID)))
+	defer span.End()
+
+	if s.usage == nil {
+		err := apperrors.FromStorage(storage.ErrUnsupported, "")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	usage, err := s.usage.GetUsage(ctx, utcDay(s.now()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.FromContext(ctx).Error("Failed to get usage", zap.Error(err))
+		return nil, apperrors.FromStorage(err, "")
+	}
+
+	quota := s.quotaFor(tenantID)
+	return &proto.GetUsageResponse{
+		TenantId:   tenantID,
+		Day:        usage.Day.Format(time.DateOnly),
+		LinksToday: usage.DailyLinks,
+		LinksTotal: usage.TotalLinks,
+		DailyLimit: quota.Daily,
+		TotalLimit: quota.Total,
+	}, nil
+}
+
+// quotaFor returns the link quota of a tenant
+func (s *URLService) quotaFor(tenantID string) models.Quota {
+	limits, ok := s.quotas.Tenants[tenantID]
+	if !ok {
+		limits = s.quotas.Default
+	}
+	return models.Quota{Daily: limits.Daily, Total: limits.Total}
+}
+
+// reserveLinks counts n new links against the quota of the context's tenant
+// It returns the day they were counted on; links that end up not being
+// created must be given back with releaseLinks for that day
+func (s *URLService) reserveLinks(ctx context.Context, n int64) (time.Time, error) {
+	day := utcDay(s.now())
+	if s.usage == nil || n == 0 {
+		return day, nil
+	}
+
+	tenantID := tenant.FromContext(ctx)
+	quota := s.quotaFor(tenantID)
+	_, err := s.usage.ReserveLinks(ctx, day, n, quota)
+	if err == nil {
+		return day, nil
+	}
+	if !errors.Is(err, storage.ErrQuotaExceeded) {
+		logger.FromContext(ctx).Error("Failed to reserve links", zap.Error(err))
+		return day, apperrors.FromStorage(err, "")
+	}
+
+	// Name the limit that was hit; the daily one is reported if both were
+	name, limit := "total", quota.Total
+	if usage, err := s.usage.GetUsage(ctx, day); err == nil && quota.Daily > 0 && usage.DailyLinks+n > quota.Daily {
+		name, limit = "daily", quota.Daily
+	}
+
+	logger.FromContext(ctx).Warn("Link quota exceeded",
+		zap.String("quota", name),
+		zap.Int64("limit", limit),
+		zap.Int64("links", n))
+	return day, apperrors.ResourceExhausted(apperrors.ReasonQuotaExceeded, "%s link quota of %d reached for tenant %s", name, limit, tenantID).
+		WithMetadata("tenant_id", tenantID).
+		WithMetadata("quota", name).
+		WithMetadata("limit", strconv.FormatInt(limit, 10))
+}
+
+// releaseLinks gives back links reserved on day that were not created
+func (s *URLService) releaseLinks(ctx context.Context, day time.Time, n int64) {
+	if s.usage == nil || n <= 0 {
+		return
+	}
+	if err := s.usage.ReleaseLinks(ctx, day, n); err != nil {
+		logger.FromContext(ctx).Warn("Failed to release reserved links", zap.Error(err), zap.Int64("links", n))
+	}
+}
+
+// utcDay returns the start of the UTC day of t
+func utcDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShortenURL_DailyQuota(t *testing.T) {
+	s := newTestService(t)
+	s.quotas = config.QuotaConfig{
+		Default: config.QuotaLimits{Daily: 2},
+		Tenants: map[string]config.QuotaLimits{"acme": {Daily: 5}},
+	}
+	now := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: fmt.Sprintf("https://example.com/%d", i)}); err != nil {
+			t.Fatalf("ShortenURL returned unexpected error: %v", err)
+		}
+	}
+
+	// Reusing a dedup link creates nothing, so it is not counted
+	if _, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/0"}); err != nil {
+		t.Errorf("Dedup hit should not be limited: %v", err)
+	}
+
+	_, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/new"})
+	if status.Code(err) != codes.ResourceExhausted || apperrors.ReasonOf(err) != apperrors.ReasonQuotaExceeded {
+		t.Fatalf("Expected ResourceExhausted QUOTA_EXCEEDED, got %v", err)
+	}
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/sale", CustomAlias: "spring-sale"})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected custom aliases to count against the quota, got %v", err)
+	}
+
+	// Other tenants have their own counters and quota
+	acme := tenant.WithContext(ctx, "acme")
+	if _, err := s.ShortenURL(acme, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/new"}); err != nil {
+		t.Errorf("Another tenant should not be limited: %v", err)
+	}
+
+	// The daily count restarts at midnight UTC
+	now = now.Add(2 * time.Hour)
+	if _, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/new"}); err != nil {
+		t.Errorf("Quota should reset on a new day: %v", err)
+	}
+
+	usage, err := s.GetUsage(ctx, &proto.GetUsageRequest{})
+	if err != nil {
+		t.Fatalf("GetUsage returned unexpected error: %v", err)
+	}
+	if usage.TenantId != tenant.DefaultID || usage.Day != "2026-03-02" || usage.LinksToday != 1 || usage.LinksTotal != 3 || usage.DailyLimit != 2 {
+		t.Errorf("Unexpected usage %v", usage)
+	}
+}
+
+func TestBatchShortenURLs_TotalQuota(t *testing.T) {
+	s := newTestService(t)
+	s.quotas = config.QuotaConfig{Default: config.QuotaLimits{Total: 3}}
+	ctx := context.Background()
+
+	_, err := s.BatchShortenURLs(ctx, &proto.BatchShortenURLsRequest{
+		OriginalUrls: []string{"https://example.com/1", "https://example.com/2", "https://example.com/3", "https://example.com/4"},
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted for a batch over the quota, got %v", err)
+	}
+
+	// A refused batch counts nothing
+	if _, err := s.BatchShortenURLs(ctx, &proto.BatchShortenURLsRequest{
+		OriginalUrls: []string{"https://example.com/1", "https://example.com/2", "https://example.com/3"},
+	}); err != nil {
+		t.Fatalf("BatchShortenURLs returned unexpected error: %v", err)
+	}
+
+	usage, err := s.GetUsage(ctx, &proto.GetUsageRequest{})
+	if err != nil {
+		t.Fatalf("GetUsage returned unexpected error: %v", err)
+	}
+	if usage.LinksTotal != 3 || usage.TotalLimit != 3 {
+		t.Errorf("Unexpected usage %v", usage)
+	}
+}
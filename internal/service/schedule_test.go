@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSchedule_NotYetActive(t *testing.T) {
+	s := newTestService(t)
+	s.comingSoon = "https://example.com/coming-soon"
+	now := time.Now().UTC().Truncate(time.Second)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	notBefore := now.Add(time.Hour)
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/launch",
+		NotBefore:   timestamppb.New(notBefore),
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if !resp.NotBefore.AsTime().Equal(notBefore) {
+		t.Errorf("Expected not_before %v, got %v", notBefore, resp.NotBefore.AsTime())
+	}
+
+	_, err = s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if status.Code(err) != codes.FailedPrecondition || apperrors.ReasonOf(err) != apperrors.ReasonURLNotYetActive {
+		t.Fatalf("Expected FailedPrecondition URL_NOT_YET_ACTIVE, got %v", err)
+	}
+	var info *errdetails.ErrorInfo
+	for _, detail := range status.Convert(err).Details() {
+		if detail, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = detail
+		}
+	}
+	if info == nil || info.Metadata["not_before"] != notBefore.Format(time.RFC3339) || info.Metadata["fallback_url"] != s.comingSoon {
+		t.Errorf("Unexpected ErrorInfo: %v", info)
+	}
+
+	batch, err := s.BatchExpandURLs(ctx, &proto.BatchExpandURLsRequest{ShortIds: []string{resp.ShortId}})
+	if err != nil {
+		t.Fatalf("BatchExpandURLs returned unexpected error: %v", err)
+	}
+	if batch.Results[0].Error == "" || batch.Results[0].OriginalUrl != "" {
+		t.Errorf("Expected batch expansion of a scheduled link to fail, got %v", batch.Results[0])
+	}
+
+	got, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if got.Info.Status != proto.URLStatus_URL_STATUS_SCHEDULED || !got.Info.NotBefore.AsTime().Equal(notBefore) {
+		t.Errorf("Expected scheduled status with not_before %v, got %v", notBefore, got.Info)
+	}
+
+	// A scheduled link is never shared with a plain request for the same URL
+	plain, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/launch"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if plain.ShortId == resp.ShortId {
+		t.Error("Expected a plain link not to reuse the scheduled one")
+	}
+
+	// The link goes live at not_before
+	now = notBefore
+	expanded, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error once the window opened: %v", err)
+	}
+	if expanded.OriginalUrl != "https://example.com/launch" {
+		t.Errorf("Expected https://example.com/launch, got %s", expanded.OriginalUrl)
+	}
+}
+
+func TestSchedule_PastNotBeforeResolves(t *testing.T) {
+	s := newTestService(t)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	first, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/live"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/live",
+		NotBefore:   timestamppb.New(now.Add(-time.Minute)),
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if resp.ShortId != first.ShortId || resp.NotBefore != nil {
+		t.Errorf("Expected a past not_before to be ignored, got %v", resp)
+	}
+
+	expanded, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	if expanded.OriginalUrl != "https://example.com/live" {
+		t.Errorf("Expected https://example.com/live, got %s", expanded.OriginalUrl)
+	}
+}
+
+func TestSchedule_Invalid(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	tests := []struct {
+		name string
+		req  *proto.ShortenURLRequest
+	}{
+		{"not before expiry", &proto.ShortenURLRequest{
+			OriginalUrl: "https://example.com/a",
+			NotBefore:   timestamppb.New(now.Add(2 * time.Hour)),
+			ExpiresAt:   timestamppb.New(now.Add(time.Hour)),
+		}},
+		{"invalid timestamp", &proto.ShortenURLRequest{
+			OriginalUrl: "https://example.com/b",
+			NotBefore:   &timestamppb.Timestamp{Nanos: -1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ShortenURL(ctx, tt.req)
+			if status.Code(err) != codes.InvalidArgument || apperrors.ReasonOf(err) != apperrors.ReasonInvalidSchedule {
+				t.Errorf("Expected InvalidArgument INVALID_SCHEDULE, got %v", err)
+			}
+		})
+	}
+}
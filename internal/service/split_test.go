@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/analytics"
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestSplit_StickyVariantRecordedInClicks(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/landing",
+		Variants: []*proto.Variant{
+			{VariantId: "control", DestinationUrl: "https://example.com/landing", Weight: 1},
+			{VariantId: "b", DestinationUrl: "https://example.com/landing-b", Weight: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	sub := s.clickHub.Subscribe(analytics.ClickFilter{ShortID: resp.ShortId})
+	defer s.clickHub.Unsubscribe(sub)
+
+	destinations := map[string]string{"control": "https://example.com/landing", "b": "https://example.com/landing-b"}
+	served := map[string]int{}
+	for i := 0; i < 20; i++ {
+		visitorCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(analytics.MetadataVisitorID, fmt.Sprintf("visitor-%d", i)))
+
+		var first string
+		for j := 0; j < 3; j++ {
+			expanded, err := s.ExpandURL(visitorCtx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+			if err != nil {
+				t.Fatalf("ExpandURL returned unexpected error: %v", err)
+			}
+			click := <-sub.Events()
+			if destinations[click.Variant] != expanded.OriginalUrl || click.OriginalURL != expanded.OriginalUrl {
+				t.Fatalf("Click records variant %q to %s, but %s was served", click.Variant, click.OriginalURL, expanded.OriginalUrl)
+			}
+			if first == "" {
+				first = click.Variant
+			} else if click.Variant != first {
+				t.Fatalf("Visitor %d got variant %s, then %s", i, first, click.Variant)
+			}
+		}
+		served[first]++
+	}
+	if served["control"] == 0 || served["b"] == 0 {
+		t.Errorf("Expected visitors to be spread over both variants, got %v", served)
+	}
+}
+
+func TestSetURLVariants(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/landing"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	// Targeting rules take precedence over the split
+	_, err = s.SetURLTargeting(ctx, &proto.SetURLTargetingRequest{
+		ShortId: resp.ShortId,
+		Rules:   []*proto.TargetingRule{{Countries: []string{"JP"}, DestinationUrl: "https://example.jp/landing"}},
+	})
+	if err != nil {
+		t.Fatalf("SetURLTargeting returned unexpected error: %v", err)
+	}
+	_, err = s.SetURLVariants(ctx, &proto.SetURLVariantsRequest{
+		ShortId: resp.ShortId,
+		Variants: []*proto.Variant{
+			{VariantId: "a", DestinationUrl: "https://example.com/a", Weight: 1},
+			{VariantId: "b", DestinationUrl: "https://example.com/b", Weight: 0},
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument || apperrors.ReasonOf(err) != apperrors.ReasonInvalidVariants {
+		t.Fatalf("Expected InvalidArgument INVALID_VARIANTS for a zero weight, got %v", err)
+	}
+	set, err := s.SetURLVariants(ctx, &proto.SetURLVariantsRequest{
+		ShortId: resp.ShortId,
+		Variants: []*proto.Variant{
+			{VariantId: "a", DestinationUrl: "https://example.com/a", Weight: 1},
+			{VariantId: "b", DestinationUrl: "https://example.com/b", Weight: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SetURLVariants returned unexpected error: %v", err)
+	}
+	if len(set.Variants) != 2 {
+		t.Errorf("Expected 2 variants in response, got %v", set.Variants)
+	}
+
+	japan := metadata.NewIncomingContext(ctx, metadata.Pairs(analytics.MetadataCountry, "JP"))
+	expanded, err := s.ExpandURL(japan, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	if expanded.OriginalUrl != "https://example.jp/landing" {
+		t.Errorf("Expected the targeted destination, got %s", expanded.OriginalUrl)
+	}
+	expanded, err = s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	if expanded.OriginalUrl != "https://example.com/a" && expanded.OriginalUrl != "https://example.com/b" {
+		t.Errorf("Expected a variant destination, got %s", expanded.OriginalUrl)
+	}
+
+	// No variants ends the split
+	if _, err := s.SetURLVariants(ctx, &proto.SetURLVariantsRequest{ShortId: resp.ShortId}); err != nil {
+		t.Fatalf("SetURLVariants returned unexpected error: %v", err)
+	}
+	info, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if len(info.Info.Variants) != 0 || len(info.Info.Targeting) != 1 {
+		t.Errorf("Expected the split to end and the targeting to stay, got %v and %v", info.Info.Variants, info.Info.Targeting)
+	}
+
+	_, err = s.SetURLVariants(ctx, &proto.SetURLVariantsRequest{
+		ShortId: "missing",
+		Variants: []*proto.Variant{
+			{VariantId: "a", DestinationUrl: "https://example.com/a", Weight: 1},
+			{VariantId: "b", DestinationUrl: "https://example.com/b", Weight: 1},
+		},
+	})
+	if status.Code(err) != codes.NotFound || apperrors.ReasonOf(err) != apperrors.ReasonURLNotFound {
+		t.Errorf("Expected NotFound URL_NOT_FOUND, got %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/utils"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// AddTags implements the AddTags RPC method
+func (s *URLService) AddTags(ctx context.Context, req *proto.AddTagsRequest) (*proto.AddTagsResponse, error) {
+	tags, err := s.changeTags(ctx, "URLService.AddTags", req.ShortId, req.Tags, true)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.AddTagsResponse{Tags: tags}, nil
+}
+
+// RemoveTags implements the RemoveTags RPC method
+func (s *URLService) RemoveTags(ctx context.Context, req *proto.RemoveTagsRequest) (*proto.RemoveTagsResponse, error) {
+	tags, err := s.changeTags(ctx, "URLService.RemoveTags", req.ShortId, req.Tags, false)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RemoveTagsResponse{Tags: tags}, nil
+}
+
+// changeTags adds or removes tags on a link and returns the tags it ends up with
+func (s *URLService) changeTags(ctx context.Context, spanName string, shortID string, requested []string, add bool) ([]string, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, spanName,
+		trace.WithAttributes(attribute.String("short_id", shortID)))
+	defer span.End()
+
+	if shortID == "" {
+		span.SetStatus(codes.Error, "missing short_id")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "short_id is required")
+	}
+	tags, err := s.normalizeTags(requested)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if len(tags) == 0 {
+		span.SetStatus(codes.Error, "missing tags")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidTag, "at least one tag is required")
+	}
+
+	if add {
+		err = s.tags.AddTags(ctx, shortID, tags)
+	} else {
+		// Removing tags from a missing link is not an error in storage, so check first
+		_, err = s.storage.GetInfo(ctx, shortID)
+		if err == nil {
+			err = s.tags.RemoveTags(ctx, shortID, tags)
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrNotFound {
+			log.Warn("Short URL not found", zap.String("shortID", shortID))
+		} else {
+			log.Error("Failed to change tags", zap.Error(err), zap.String("shortID", shortID))
+		}
+		return nil, apperrors.FromStorage(err, shortID)
+	}
+
+	current, err := s.tags.GetTags(ctx, []string{shortID})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error("Failed to get tags", zap.Error(err), zap.String("shortID", shortID))
+		return nil, apperrors.FromStorage(err, shortID)
+	}
+
+	log.Info("Tags changed",
+		zap.String("shortID", shortID),
+		zap.Strings("tags", tags),
+		zap.Bool("added", add))
+	return current[shortID], nil
+}
+
+// ListURLsByTag implements the ListURLsByTag RPC method
+func (s *URLService) ListURLsByTag(ctx context.Context, req *proto.ListURLsByTagRequest) (*proto.ListURLsByTagResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.ListURLsByTag",
+		trace.WithAttributes(
+			attribute.String("tag", req.Tag),
+			attribute.Int("page_size", int(req.PageSize))))
+	defer span.End()
+
+	tags, err := s.normalizeTags([]string{req.Tag})
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	limit, err := resolvePageSize(req.PageSize)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	afterShortID, err := decodeTagPageToken(req.PageToken)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidPageToken, "invalid page_token").WithCause(err)
+	}
+
+	// Fetch one extra link to learn whether there is a next page
+	links, err := s.tags.ListByTag(ctx, tags[0], afterShortID, limit+1)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Error("Failed to list URLs by tag", zap.Error(err), zap.String("tag", tags[0]))
+		return nil, apperrors.FromStorage(err, "")
+	}
+
+	response := &proto.ListURLsByTagResponse{}
+	if len(links) > limit {
+		links = links[:limit]
+		response.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(links[limit-1].ShortID))
+	}
+	if err := s.loadTags(ctx, links); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	response.Urls = make([]*proto.URLInfo, len(links))
+	for i, link := range links {
+		response.Urls[i] = s.toURLInfo(link)
+	}
+
+	span.SetAttributes(attribute.Int("result_count", len(links)))
+	log.Debug("URLs listed by tag",
+		zap.String("tag", tags[0]),
+		zap.Int("count", len(links)),
+		zap.Bool("hasMore", response.NextPageToken != ""))
+	return response, nil
+}
+
+// decodeTagPageToken returns the short ID a ListURLsByTag page token points after
+func decodeTagPageToken(token string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", errInvalidPageToken
+	}
+	return string(raw), nil
+}
+
+// normalizeTags validates requested tags and returns them in stored form
+// Tags are rejected outright when the storage cannot keep them
+func (s *URLService) normalizeTags(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+	if s.tags == nil {
+		return nil, apperrors.FromStorage(storage.ErrUnsupported, "")
+	}
+
+	tags, err := utils.NormalizeTags(requested)
+	if errors.Is(err, utils.ErrInvalidTag) {
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidTag, "%v", err)
+	}
+	return tags, err
+}
+
+// tagNewLink stores the tags of a link that was just created
+// The link is deleted again if its tags cannot be stored
+func (s *URLService) tagNewLink(ctx context.Context, link *models.URL) error {
+	if len(link.Tags) == 0 {
+		return nil
+	}
+
+	err := s.tags.AddTags(ctx, link.ShortID, link.Tags)
+	if err == nil {
+		return nil
+	}
+
+	log := logger.FromContext(ctx)
+	log.Error("Failed to tag new link", zap.Error(err), zap.String("shortID", link.ShortID))
+	if err := s.storage.Delete(ctx, link.ShortID); err != nil {
+		log.Error("Failed to delete untagged link", zap.Error(err), zap.String("shortID", link.ShortID))
+	}
+	return apperrors.FromStorage(err, link.ShortID)
+}
+
+// loadTags fills in the tags of links read from storage
+func (s *URLService) loadTags(ctx context.Context, links []*models.URL) error {
+	if s.tags == nil || len(links) == 0 {
+		return nil
+	}
+
+	shortIDs := make([]string, len(links))
+	for i, link := range links {
+		shortIDs[i] = link.ShortID
+	}
+	tags, err := s.tags.GetTags(ctx, shortIDs)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get tags", zap.Error(err), zap.Int("count", len(links)))
+		return apperrors.FromStorage(err, "")
+	}
+	for _, link := range links {
+		link.Tags = tags[link.ShortID]
+	}
+	return nil
+}
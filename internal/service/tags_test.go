@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShortenURL_Tags(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	plain, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	tagged, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/a",
+		Tags:        []string{"Spring-Sale", "team/growth", "spring-sale"},
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if tagged.ShortId == plain.ShortId {
+		t.Errorf("Expected a tagged link not to share the dedup link")
+	}
+
+	info, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: tagged.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if want := []string{"spring-sale", "team/growth"}; !reflect.DeepEqual(info.Info.Tags, want) {
+		t.Errorf("Expected tags %v, got %v", want, info.Info.Tags)
+	}
+
+	for _, tags := range [][]string{{""}, {"no spaces"}, {"a:b"}} {
+		_, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/b", Tags: tags})
+		if status.Code(err) != codes.InvalidArgument || apperrors.ReasonOf(err) != apperrors.ReasonInvalidTag {
+			t.Errorf("ShortenURL with tags %q: expected InvalidArgument INVALID_TAG, got %v", tags, err)
+		}
+	}
+}
+
+func TestAddAndRemoveTags(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a", Tags: []string{"sale"}})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	added, err := s.AddTags(ctx, &proto.AddTagsRequest{ShortId: resp.ShortId, Tags: []string{"Email", "sale"}})
+	if err != nil {
+		t.Fatalf("AddTags returned unexpected error: %v", err)
+	}
+	if want := []string{"email", "sale"}; !reflect.DeepEqual(added.Tags, want) {
+		t.Errorf("Expected tags %v after adding, got %v", want, added.Tags)
+	}
+
+	removed, err := s.RemoveTags(ctx, &proto.RemoveTagsRequest{ShortId: resp.ShortId, Tags: []string{"sale", "unknown"}})
+	if err != nil {
+		t.Fatalf("RemoveTags returned unexpected error: %v", err)
+	}
+	if want := []string{"email"}; !reflect.DeepEqual(removed.Tags, want) {
+		t.Errorf("Expected tags %v after removing, got %v", want, removed.Tags)
+	}
+
+	_, err = s.AddTags(ctx, &proto.AddTagsRequest{ShortId: "missing", Tags: []string{"sale"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound tagging a missing link, got %v", err)
+	}
+	_, err = s.RemoveTags(ctx, &proto.RemoveTagsRequest{ShortId: "missing", Tags: []string{"sale"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound untagging a missing link, got %v", err)
+	}
+	_, err = s.AddTags(ctx, &proto.AddTagsRequest{ShortId: resp.ShortId})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without tags, got %v", err)
+	}
+}
+
+func TestListURLsByTag(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	tagged := make(map[string]bool)
+	for _, url := range []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"} {
+		resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: url, Tags: []string{"sale"}})
+		if err != nil {
+			t.Fatalf("ShortenURL returned unexpected error: %v", err)
+		}
+		tagged[resp.ShortId] = true
+	}
+	if _, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/d", Tags: []string{"other"}}); err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	token := ""
+	for pages := 0; ; pages++ {
+		if pages > len(tagged) {
+			t.Fatalf("Listing did not terminate")
+		}
+		resp, err := s.ListURLsByTag(ctx, &proto.ListURLsByTagRequest{Tag: "SALE", PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListURLsByTag returned unexpected error: %v", err)
+		}
+		for _, info := range resp.Urls {
+			if !tagged[info.ShortId] || seen[info.ShortId] {
+				t.Errorf("Unexpected or repeated link %s", info.ShortId)
+			}
+			if !reflect.DeepEqual(info.Tags, []string{"sale"}) {
+				t.Errorf("Expected tags [sale] for %s, got %v", info.ShortId, info.Tags)
+			}
+			seen[info.ShortId] = true
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		token = resp.NextPageToken
+	}
+	if len(seen) != len(tagged) {
+		t.Errorf("Expected %d tagged links, listed %d", len(tagged), len(seen))
+	}
+
+	_, err := s.ListURLsByTag(ctx, &proto.ListURLsByTagRequest{Tag: "sale", PageToken: "!"})
+	if status.Code(err) != codes.InvalidArgument || apperrors.ReasonOf(err) != apperrors.ReasonInvalidPageToken {
+		t.Errorf("Expected InvalidArgument INVALID_PAGE_TOKEN, got %v", err)
+	}
+	_, err = s.ListURLsByTag(ctx, &proto.ListURLsByTagRequest{})
+	if status.Code(err) != codes.InvalidArgument || apperrors.ReasonOf(err) != apperrors.ReasonInvalidTag {
+		t.Errorf("Expected InvalidArgument INVALID_TAG without a tag, got %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/targeting"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// SetURLTargeting implements the SetURLTargeting RPC method
+func (s *URLService) SetURLTargeting(ctx context.Context, req *proto.SetURLTargetingRequest) (*proto.SetURLTargetingResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.SetURLTargeting",
+		trace.WithAttributes(
+			attribute.String("short_id", req.ShortId),
+			attribute.Int("rules", len(req.Rules))))
+	defer span.End()
+
+	if req.ShortId == "" {
+		span.SetStatus(codes.Error, "missing short_id")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "short_id is required")
+	}
+	if s.targeting == nil {
+		err := apperrors.FromStorage(storage.ErrUnsupported, "")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	rules, err := s.normalizeTargeting(ctx, req.Rules)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.targeting.SetTargeting(ctx, req.ShortId, rules); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrNotFound {
+			log.Warn("Short URL not found", zap.String("shortID", req.ShortId))
+		} else {
+			log.Error("Failed to set URL targeting", zap.Error(err), zap.String("shortID", req.ShortId))
+		}
+		return nil, apperrors.FromStorage(err, req.ShortId)
+	}
+
+	log.Info("URL targeting set",
+		zap.String("shortID", req.ShortId),
+		zap.Int("rules", len(rules)))
+	return &proto.SetURLTargetingResponse{Rules: toProtoTargeting(rules)}, nil
+}
+
+// SetURLVariants implements the SetURLVariants RPC method
+func (s *URLService) SetURLVariants(ctx context.Context, req *proto.SetURLVariantsRequest) (*proto.SetURLVariantsResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.SetURLVariants",
+		trace.WithAttributes(
+			attribute.String("short_id", req.ShortId),
+			attribute.Int("variants", len(req.Variants))))
+	defer span.End()
+
+	if req.ShortId == "" {
+		span.SetStatus(codes.Error, "missing short_id")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "short_id is required")
+	}
+	if s.targeting == nil {
+		err := apperrors.FromStorage(storage.ErrUnsupported, "")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	variants, err := s.normalizeVariants(ctx, req.Variants)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.targeting.SetVariants(ctx, req.ShortId, variants); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrNotFound {
+			log.Warn("Short URL not found", zap.String("shortID", req.ShortId))
+		} else {
+			log.Error("Failed to set URL variants", zap.Error(err), zap.String("shortID", req.ShortId))
+		}
+		return nil, apperrors.FromStorage(err, req.ShortId)
+	}
+
+	log.Info("URL variants set",
+		zap.String("shortID", req.ShortId),
+		zap.Int("variants", len(variants)))
+	return &proto.SetURLVariantsResponse{Variants: toProtoVariants(variants)}, nil
+}
+
+// normalizeTargeting validates requested targeting rules and returns them in
+// stored form. Every destination must pass the same checks as an original URL;
+// rules are rejected outright when the storage cannot keep them
+func (s *URLService) normalizeTargeting(ctx context.Context, requested []*proto.TargetingRule) ([]models.TargetingRule, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+	if s.targeting == nil {
+		return nil, apperrors.FromStorage(storage.ErrUnsupported, "")
+	}
+
+	rules := make([]models.TargetingRule, len(requested))
+	for i, rule := range requested {
+		rules[i] = models.TargetingRule{
+			Devices:        rule.GetDevices(),
+			Languages:      rule.GetLanguages(),
+			Countries:      rule.GetCountries(),
+			DestinationURL: rule.GetDestinationUrl(),
+		}
+	}
+	rules, err := targeting.Normalize(rules)
+	if errors.Is(err, targeting.ErrInvalidRule) {
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidTargeting, "%v", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		if err := s.validateURL(ctx, rule.DestinationURL); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// normalizeVariants validates requested split variants and returns them in
+// stored form. Every destination must pass the same checks as an original URL;
+// variants are rejected outright when the storage cannot keep them
+func (s *URLService) normalizeVariants(ctx context.Context, requested []*proto.Variant) ([]models.Variant, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+	if s.targeting == nil {
+		return nil, apperrors.FromStorage(storage.ErrUnsupported, "")
+	}
+
+	variants := make([]models.Variant, len(requested))
+	for i, variant := range requested {
+		variants[i] = models.Variant{
+			ID:             variant.GetVariantId(),
+			DestinationURL: variant.GetDestinationUrl(),
+			Weight:         variant.GetWeight(),
+		}
+	}
+	variants, err := targeting.NormalizeVariants(variants)
+	if errors.Is(err, targeting.ErrInvalidVariants) {
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidVariants, "%v", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, variant := range variants {
+		if err := s.validateURL(ctx, variant.DestinationURL); err != nil {
+			return nil, err
+		}
+	}
+	return variants, nil
+}
+
+// toProtoTargeting converts stored targeting rules into their API representation
+func toProtoTargeting(rules []models.TargetingRule) []*proto.TargetingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	converted := make([]*proto.TargetingRule, len(rules))
+	for i, rule := range rules {
+		converted[i] = &proto.TargetingRule{
+			Devices:        rule.Devices,
+			Languages:      rule.Languages,
+			Countries:      rule.Countries,
+			DestinationUrl: rule.DestinationURL,
+		}
+	}
+	return converted
+}
+
+// toProtoVariants converts stored split variants into their API representation
+func toProtoVariants(variants []models.Variant) []*proto.Variant {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	converted := make([]*proto.Variant, len(variants))
+	for i, variant := range variants {
+		converted[i] = &proto.Variant{
+			VariantId:      variant.ID,
+			DestinationUrl: variant.DestinationURL,
+			Weight:         variant.Weight,
+		}
+	}
+	return converted
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/analytics"
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// visitorContext returns a context carrying visitor metadata as the gateway sends it
+func visitorContext(userAgent string, acceptLanguage string, country string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		analytics.MetadataUserAgent, userAgent,
+		analytics.MetadataAcceptLanguage, acceptLanguage,
+		analytics.MetadataCountry, country,
+	))
+}
+
+func TestTargeting_ExpandPicksFirstMatchingRule(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	plain, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/app"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/app",
+		Targeting: []*proto.TargetingRule{
+			{Devices: []string{"iOS"}, DestinationUrl: "https://apps.apple.com/app/id1"},
+			{Devices: []string{"android"}, DestinationUrl: "https://play.google.com/store/apps/details?id=app"},
+			{Languages: []string{"de"}, Countries: []string{"at"}, DestinationUrl: "https://example.at/app"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if resp.ShortId == plain.ShortId {
+		t.Errorf("Expected a targeted link not to share the dedup link")
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"iPhone", visitorContext("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)", "de-AT", "AT"), "https://apps.apple.com/app/id1"},
+		{"Android", visitorContext("Mozilla/5.0 (Linux; Android 14; Pixel 8)", "", ""), "https://play.google.com/store/apps/details?id=app"},
+		{"Desktop in Austria", visitorContext("Mozilla/5.0 (Windows NT 10.0; Win64; x64)", "en;q=0.5, de-AT", "AT"), "https://example.at/app"},
+		{"Desktop elsewhere", visitorContext("Mozilla/5.0 (Windows NT 10.0; Win64; x64)", "de-DE", "DE"), "https://example.com/app"},
+		{"No metadata", context.Background(), "https://example.com/app"},
+	}
+	for _, tt := range tests {
+		expanded, err := s.ExpandURL(tt.ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+		if err != nil {
+			t.Fatalf("%s: ExpandURL returned unexpected error: %v", tt.name, err)
+		}
+		if expanded.OriginalUrl != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, expanded.OriginalUrl)
+		}
+	}
+
+	info, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if len(info.Info.Targeting) != 3 || info.Info.Targeting[0].Devices[0] != "ios" || info.Info.Targeting[2].Countries[0] != "AT" {
+		t.Errorf("Unexpected stored targeting: %v", info.Info.Targeting)
+	}
+}
+
+func TestSetURLTargeting(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	iPhone := visitorContext("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)", "", "")
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/app"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	set, err := s.SetURLTargeting(ctx, &proto.SetURLTargetingRequest{
+		ShortId: resp.ShortId,
+		Rules:   []*proto.TargetingRule{{Devices: []string{"mobile"}, DestinationUrl: "https://m.example.com/app"}},
+	})
+	if err != nil {
+		t.Fatalf("SetURLTargeting returned unexpected error: %v", err)
+	}
+	if len(set.Rules) != 1 || set.Rules[0].DestinationUrl != "https://m.example.com/app" {
+		t.Errorf("Unexpected rules in response: %v", set.Rules)
+	}
+	expanded, err := s.ExpandURL(iPhone, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	if expanded.OriginalUrl != "https://m.example.com/app" {
+		t.Errorf("Expected the mobile destination, got %s", expanded.OriginalUrl)
+	}
+
+	// Targeted links no longer take part in dedup
+	again, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/app"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if again.ShortId == resp.ShortId {
+		t.Errorf("Expected a new short ID once the dedup link is targeted")
+	}
+
+	// No rules clears the targeting
+	if _, err := s.SetURLTargeting(ctx, &proto.SetURLTargetingRequest{ShortId: resp.ShortId}); err != nil {
+		t.Fatalf("SetURLTargeting returned unexpected error: %v", err)
+	}
+	expanded, err = s.ExpandURL(iPhone, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	if expanded.OriginalUrl != "https://example.com/app" {
+		t.Errorf("Expected the original URL after clearing, got %s", expanded.OriginalUrl)
+	}
+}
+
+func TestSetURLTargeting_Errors(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/app"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		req    *proto.SetURLTargetingRequest
+		code   codes.Code
+		reason apperrors.Reason
+	}{
+		{
+			name:   "Missing short ID",
+			req:    &proto.SetURLTargetingRequest{},
+			code:   codes.InvalidArgument,
+			reason: apperrors.ReasonInvalidArgument,
+		},
+		{
+			name:   "Rule without conditions",
+			req:    &proto.SetURLTargetingRequest{ShortId: resp.ShortId, Rules: []*proto.TargetingRule{{DestinationUrl: "https://example.com/b"}}},
+			code:   codes.InvalidArgument,
+			reason: apperrors.ReasonInvalidTargeting,
+		},
+		{
+			name:   "Unknown device",
+			req:    &proto.SetURLTargetingRequest{ShortId: resp.ShortId, Rules: []*proto.TargetingRule{{Devices: []string{"watch"}, DestinationUrl: "https://example.com/b"}}},
+			code:   codes.InvalidArgument,
+			reason: apperrors.ReasonInvalidTargeting,
+		},
+		{
+			name:   "Invalid destination",
+			req:    &proto.SetURLTargetingRequest{ShortId: resp.ShortId, Rules: []*proto.TargetingRule{{Countries: []string{"US"}, DestinationUrl: "not a url"}}},
+			code:   codes.InvalidArgument,
+			reason: apperrors.ReasonInvalidURL,
+		},
+		{
+			name:   "Missing link",
+			req:    &proto.SetURLTargetingRequest{ShortId: "missing", Rules: []*proto.TargetingRule{{Countries: []string{"US"}, DestinationUrl: "https://example.com/us"}}},
+			code:   codes.NotFound,
+			reason: apperrors.ReasonURLNotFound,
+		},
+	}
+	for _, tt := range tests {
+		_, err := s.SetURLTargeting(ctx, tt.req)
+		if status.Code(err) != tt.code || apperrors.ReasonOf(err) != tt.reason {
+			t.Errorf("%s: expected %v %s, got %v", tt.name, tt.code, tt.reason, err)
+		}
+	}
+
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/app",
+		Targeting:   []*proto.TargetingRule{{Languages: []string{"english"}, DestinationUrl: "https://example.com/en"}},
+	})
+	if status.Code(err) != codes.InvalidArgument || apperrors.ReasonOf(err) != apperrors.ReasonInvalidTargeting {
+		t.Errorf("Expected InvalidArgument INVALID_TARGETING from ShortenURL, got %v", err)
+	}
+}
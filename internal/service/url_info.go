@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetURLInfo implements the GetURLInfo RPC method
+func (s *URLService) GetURLInfo(ctx context.Context, req *proto.GetURLInfoRequest) (*proto.GetURLInfoResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.GetURLInfo",
+		trace.WithAttributes(attribute.String("short_id", req.ShortId)))
+	defer span.End()
+
+	if req.ShortId == "" {
+		span.SetStatus(codes.Error, "missing short_id")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "short_id is required")
+	}
+
+	link, err := s.storage.GetInfo(ctx, req.ShortId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrNotFound {
+			log.Warn("Short URL not found", zap.String("shortID", req.ShortId))
+		} else {
+			log.Error("Failed to retrieve URL info", zap.Error(err), zap.String("shortID", req.ShortId))
+		}
+		return nil, apperrors.FromStorage(err, req.ShortId)
+	}
+	if err := s.loadTags(ctx, []*models.URL{link}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	info := s.toURLInfo(link)
+	span.SetAttributes(
+		attribute.Int64("click_count", info.ClickCount),
+		attribute.String("status", info.Status.String()))
+	return &proto.GetURLInfoResponse{Info: info}, nil
+}
+
+// toURLInfo converts a stored link into its API representation
+func (s *URLService) toURLInfo(link *models.URL) *proto.URLInfo {
+	info := &proto.URLInfo{
+		ShortId:           link.ShortID,
+		ShortUrl:          s.baseURL + link.ShortID,
+		OriginalUrl:       link.OriginalURL,
+		CanonicalUrl:      link.DedupKey(),
+		QuarantineReason:  link.QuarantineReason,
+		CreatedAt:         timestamppb.New(link.CreatedAt),
+		Disabled:          link.Disabled,
+		ClickCount:        link.ClickCount,
+		Status:            urlStatus(link, s.now()),
+		PasswordProtected: link.PasswordProtected(),
+		MaxClicks:         link.MaxClicks,
+		RemainingClicks:   link.RemainingClicks,
+		Tags:              link.Tags,
+		CampaignId:        link.CampaignID,
+		Targeting:         toProtoTargeting(link.Targeting),
+		Variants:          toProtoVariants(link.Variants),
+	}
+	if link.LastAccessed != nil {
+		info.LastAccessed = timestamppb.New(*link.LastAccessed)
+	}
+	if link.ExpiresAt != nil {
+		info.ExpiresAt = timestamppb.New(*link.ExpiresAt)
+	}
+	if link.NotBefore != nil {
+		info.NotBefore = timestamppb.New(*link.NotBefore)
+	}
+	return info
+}
+
+// urlStatus tells whether a link resolves at the given time, in the order
+// ExpandURL checks it
+func urlStatus(link *models.URL, now time.Time) proto.URLStatus {
+	switch {
+	case link.Disabled:
+		return proto.URLStatus_URL_STATUS_DISABLED
+	case link.Quarantined():
+		return proto.URLStatus_URL_STATUS_QUARANTINED
+	case link.Expired(now):
+		return proto.URLStatus_URL_STATUS_EXPIRED
+	case link.Scheduled(now):
+		return proto.URLStatus_URL_STATUS_SCHEDULED
+	case link.ClickLimited() && link.RemainingClicks <= 0:
+		return proto.URLStatus_URL_STATUS_EXHAUSTED
+	default:
+		return proto.URLStatus_URL_STATUS_ACTIVE
+	}
+}
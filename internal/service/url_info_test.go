@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetURLInfo(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	shortened, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	resp, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: shortened.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if resp.Info.ClickCount != 0 || resp.Info.LastAccessed != nil {
+		t.Errorf("Expected a fresh link to have no accesses, got %+v", resp.Info)
+	}
+	if resp.Info.CreatedAt == nil || resp.Info.Status != proto.URLStatus_URL_STATUS_ACTIVE {
+		t.Errorf("Unexpected info for a fresh link: %+v", resp.Info)
+	}
+
+	if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: shortened.ShortId}); err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	if _, err := s.BatchExpandURLs(ctx, &proto.BatchExpandURLsRequest{ShortIds: []string{shortened.ShortId}}); err != nil {
+		t.Fatalf("BatchExpandURLs returned unexpected error: %v", err)
+	}
+	if _, err := s.DisableURL(ctx, &proto.DisableURLRequest{ShortId: shortened.ShortId}); err != nil {
+		t.Fatalf("DisableURL returned unexpected error: %v", err)
+	}
+
+	// Refused resolutions are not accesses either
+	if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: shortened.ShortId}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition for a disabled link, got %v", err)
+	}
+
+	// Accesses are written off the request path
+	s.accesses.Flush()
+
+	// GetURLInfo itself does not count as an access
+	for i := 0; i < 2; i++ {
+		resp, err = s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: shortened.ShortId})
+		if err != nil {
+			t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+		}
+	}
+	info := resp.Info
+	if info.ShortId != shortened.ShortId || info.ShortUrl != shortened.ShortUrl || info.OriginalUrl != "https://example.com/a" {
+		t.Errorf("Unexpected link in info: %+v", info)
+	}
+	if info.ClickCount != 2 {
+		t.Errorf("Expected click count 2, got %d", info.ClickCount)
+	}
+	if info.LastAccessed == nil {
+		t.Errorf("Expected last_accessed to be set")
+	}
+	if !info.Disabled || info.Status != proto.URLStatus_URL_STATUS_DISABLED {
+		t.Errorf("Expected a disabled link, got %+v", info)
+	}
+}
+
+func TestGetURLInfo_NotFound(t *testing.T) {
+	s := newTestService(t)
+
+	_, err := s.GetURLInfo(context.Background(), &proto.GetURLInfoRequest{ShortId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound, got %v", err)
+	}
+}
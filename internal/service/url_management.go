@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// DeleteURL implements the DeleteURL RPC method
+func (s *URLService) DeleteURL(ctx context.Context, req *proto.DeleteURLRequest) (*proto.DeleteURLResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.DeleteURL",
+		trace.WithAttributes(attribute.String("short_id", req.ShortId)))
+	defer span.End()
+
+	if req.ShortId == "" {
+		span.SetStatus(codes.Error, "missing short_id")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "short_id is required")
+	}
+
+	if err := s.storage.Delete(ctx, req.ShortId); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrNotFound {
+			log.Warn("Short URL not found", zap.String("shortID", req.ShortId))
+		} else {
+			log.Error("Failed to delete URL", zap.Error(err), zap.String("shortID", req.ShortId))
+		}
+		return nil, apperrors.FromStorage(err, req.ShortId)
+	}
+
+	log.Info("URL deleted", zap.String("shortID", req.ShortId))
+	return &proto.DeleteURLResponse{}, nil
+}
+
+// DisableURL implements the DisableURL RPC method
+func (s *URLService) DisableURL(ctx context.Context, req *proto.DisableURLRequest) (*proto.DisableURLResponse, error) {
+	if err := s.setDisabled(ctx, "URLService.DisableURL", req.ShortId, true); err != nil {
+		return nil, err
+	}
+	return &proto.DisableURLResponse{}, nil
+}
+
+// EnableURL implements the EnableURL RPC method
+func (s *URLService) EnableURL(ctx context.Context, req *proto.EnableURLRequest) (*proto.EnableURLResponse, error) {
+	if err := s.setDisabled(ctx, "URLService.EnableURL", req.ShortId, false); err != nil {
+		return nil, err
+	}
+	return &proto.EnableURLResponse{}, nil
+}
+
+// UpdateURL implements the UpdateURL RPC method
+func (s *URLService) UpdateURL(ctx context.Context, req *proto.UpdateURLRequest) (*proto.UpdateURLResponse, error) {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, "URLService.UpdateURL",
+		trace.WithAttributes(
+			attribute.String("short_id", req.ShortId),
+			attribute.String("original_url", req.OriginalUrl)))
+	defer span.End()
+
+	if req.ShortId == "" {
+		span.SetStatus(codes.Error, "missing short_id")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "short_id is required")
+	}
+
+	if err := s.validateURL(ctx, req.OriginalUrl); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.storage.Update(ctx, req.ShortId, req.OriginalUrl, s.canonicalize(ctx, req.OriginalUrl)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrNotFound {
+			log.Warn("Short URL not found", zap.String("shortID", req.ShortId))
+		} else {
+			log.Error("Failed to update URL", zap.Error(err), zap.String("shortID", req.ShortId))
+		}
+		return nil, apperrors.FromStorage(err, req.ShortId)
+	}
+
+	log.Info("URL updated",
+		zap.String("shortID", req.ShortId),
+		zap.String("originalURL", req.OriginalUrl))
+	return &proto.UpdateURLResponse{
+		ShortId:     req.ShortId,
+		ShortUrl:    s.baseURL + req.ShortId,
+		OriginalUrl: req.OriginalUrl,
+	}, nil
+}
+
+// setDisabled toggles whether a short URL resolves
+func (s *URLService) setDisabled(ctx context.Context, spanName string, shortID string, disabled bool) error {
+	log := logger.FromContext(ctx)
+
+	ctx, span := s.tracer.Start(ctx, spanName,
+		trace.WithAttributes(attribute.String("short_id", shortID)))
+	defer span.End()
+
+	if shortID == "" {
+		span.SetStatus(codes.Error, "missing short_id")
+		return apperrors.InvalidArgument(apperrors.ReasonInvalidArgument, "short_id is required")
+	}
+
+	if err := s.storage.SetDisabled(ctx, shortID, disabled); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		if err == storage.ErrNotFound {
+			log.Warn("Short URL not found", zap.String("shortID", shortID))
+		} else {
+			log.Error("Failed to update URL status", zap.Error(err), zap.String("shortID", shortID))
+		}
+		return apperrors.FromStorage(err, shortID)
+	}
+
+	log.Info("URL status updated",
+		zap.String("shortID", shortID),
+		zap.Bool("disabled", disabled))
+	return nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDisableAndEnableURL(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	if _, err := s.DisableURL(ctx, &proto.DisableURLRequest{ShortId: resp.ShortId}); err != nil {
+		t.Fatalf("DisableURL returned unexpected error: %v", err)
+	}
+	_, err = s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for disabled link, got %v", err)
+	}
+
+	// A disabled link is no longer handed out for the same URL
+	fresh, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if fresh.ShortId == resp.ShortId {
+		t.Errorf("Expected a new short ID after disabling the dedup link")
+	}
+
+	if _, err := s.EnableURL(ctx, &proto.EnableURLRequest{ShortId: resp.ShortId}); err != nil {
+		t.Fatalf("EnableURL returned unexpected error: %v", err)
+	}
+	expanded, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error after enabling: %v", err)
+	}
+	if expanded.OriginalUrl != "https://example.com/a" {
+		t.Errorf("Unexpected original URL %s", expanded.OriginalUrl)
+	}
+}
+
+func TestDeleteURL(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	if _, err := s.DeleteURL(ctx, &proto.DeleteURLRequest{ShortId: resp.ShortId}); err != nil {
+		t.Fatalf("DeleteURL returned unexpected error: %v", err)
+	}
+
+	_, err = s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err == nil {
+		t.Errorf("Expected error expanding a deleted link")
+	}
+
+	_, err = s.DeleteURL(ctx, &proto.DeleteURLRequest{ShortId: resp.ShortId})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound deleting twice, got %v", err)
+	}
+
+	_, err = s.DisableURL(ctx, &proto.DisableURLRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for missing short ID, got %v", err)
+	}
+}
+
+func TestUpdateURL(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/old"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	updated, err := s.UpdateURL(ctx, &proto.UpdateURLRequest{
+		ShortId:     resp.ShortId,
+		OriginalUrl: "https://example.com/new",
+	})
+	if err != nil {
+		t.Fatalf("UpdateURL returned unexpected error: %v", err)
+	}
+	if updated.ShortUrl != resp.ShortUrl {
+		t.Errorf("Expected short URL to stay %s, got %s", resp.ShortUrl, updated.ShortUrl)
+	}
+
+	expanded, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	if expanded.OriginalUrl != "https://example.com/new" {
+		t.Errorf("Expected new destination, got %s", expanded.OriginalUrl)
+	}
+
+	// The old destination no longer dedups to the retargeted short ID
+	again, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/old"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if again.ShortId == resp.ShortId {
+		t.Errorf("Old destination should not resolve to the retargeted short ID")
+	}
+
+	_, err = s.UpdateURL(ctx, &proto.UpdateURLRequest{ShortId: "missing", OriginalUrl: "https://example.com"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for unknown short ID, got %v", err)
+	}
+}
@@ -2,13 +2,20 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
+	"github.com/hohotang/shortlink-core/internal/analytics"
+	"github.com/hohotang/shortlink-core/internal/apperrors"
 	"github.com/hohotang/shortlink-core/internal/config"
 	"github.com/hohotang/shortlink-core/internal/logger"
 	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/policy"
+	"github.com/hohotang/shortlink-core/internal/reputation"
 	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/targeting"
 	"github.com/hohotang/shortlink-core/internal/utils"
 	"github.com/hohotang/shortlink-core/proto"
 	"go.opentelemetry.io/otel"
@@ -16,19 +23,40 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Tracer 名稱
 const tracerName = "github.com/hohotang/shortlink-core/internal/service"
 
+// maxGenerateAttempts bounds retries when a generated ID collides with an existing one
+const maxGenerateAttempts = 3
+
 // URLService implements the gRPC URLService interface
 type URLService struct {
 	proto.UnimplementedURLServiceServer
-	storage   storage.URLStorage
-	baseURL   string
-	generator utils.IDGenerator
-	tracer    trace.Tracer
-	logger    *zap.Logger
+	storage      storage.URLStorage
+	baseURL      string
+	comingSoon   string
+	maxBatchSize int
+	generator    utils.IDGenerator
+	clicks       *analytics.ClickTracker
+	accesses     *analytics.AccessTracker
+	clickHub     *analytics.ClickHub
+	guesses      *guessThrottle
+	verifySlots  chan struct{}
+	usage        storage.UsageStorage
+	tags         storage.TagStorage
+	campaigns    storage.CampaignStorage
+	targeting    storage.TargetingStorage
+	quotas       config.QuotaConfig
+	canonical    config.CanonicalConfig
+	policy       *policy.Policy
+	checker      reputation.URLChecker
+	rechecker    *reputation.Rechecker
+	now          func() time.Time
+	tracer       trace.Tracer
+	logger       *zap.Logger
 }
 
 // NewURLService creates a new URLService instance
@@ -43,6 +71,12 @@ func NewURLService(cfg *config.Config, log *zap.Logger) (*URLService, error) {
 		return nil, fmt.Errorf("failed to create Snowflake generator: %w", err)
 	}
 
+	// Load the reputation checkers, if any, before connecting to storage
+	checker, err := reputation.New(cfg.Reputation, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reputation checker: %w", err)
+	}
+
 	// Initialize the storage based on configuration
 	switch cfg.Storage.Type {
 	case models.Memory:
@@ -70,6 +104,66 @@ func NewURLService(cfg *config.Config, log *zap.Logger) (*URLService, error) {
 		return nil, fmt.Errorf("unknown storage type: %s", cfg.Storage.Type)
 	}
 
+	// Start click tracking if enabled and the storage can keep clicks
+	var clicks *analytics.ClickTracker
+	if cfg.Analytics.Enabled {
+		if clickStore, ok := store.(storage.ClickStorage); ok {
+			clicks = analytics.NewClickTracker(clickStore, analytics.Config{
+				BufferSize:    cfg.Analytics.BufferSize,
+				BatchSize:     cfg.Analytics.BatchSize,
+				FlushInterval: cfg.Analytics.FlushInterval,
+			}, log)
+		} else {
+			log.Warn("Storage cannot keep clicks, click tracking disabled",
+				zap.String("storage", string(cfg.Storage.Type)))
+		}
+	}
+
+	// Count link resolutions off the request path; GetURLInfo reports them
+	accesses := analytics.NewAccessTracker(store, analytics.Config{
+		BufferSize:    cfg.Analytics.BufferSize,
+		FlushInterval: cfg.Analytics.FlushInterval,
+	}, log)
+
+	// Meter link creation if the storage can keep usage counters
+	usage, ok := store.(storage.UsageStorage)
+	if !ok {
+		log.Warn("Storage cannot keep usage, link quotas disabled",
+			zap.String("storage", string(cfg.Storage.Type)))
+	}
+
+	// Tag links if the storage can index them
+	tags, ok := store.(storage.TagStorage)
+	if !ok {
+		log.Warn("Storage cannot keep tags, tagging disabled",
+			zap.String("storage", string(cfg.Storage.Type)))
+	}
+
+	// Attach links to campaigns if the storage can keep them
+	campaigns, ok := store.(storage.CampaignStorage)
+	if !ok {
+		log.Warn("Storage cannot keep campaigns, campaigns disabled",
+			zap.String("storage", string(cfg.Storage.Type)))
+	}
+
+	// Target links if the storage can keep their rules
+	targeting, ok := store.(storage.TargetingStorage)
+	if !ok {
+		log.Warn("Storage cannot keep targeting rules, targeting disabled",
+			zap.String("storage", string(cfg.Storage.Type)))
+	}
+
+	// Recheck stored links periodically if the storage can scan them
+	var rechecker *reputation.Rechecker
+	if checker != nil && cfg.Reputation.RecheckInterval > 0 {
+		if quarantineStore, ok := store.(storage.QuarantineStorage); ok {
+			rechecker = reputation.NewRechecker(quarantineStore, checker, cfg.Reputation.RecheckInterval, cfg.Reputation.RecheckBatchSize, log)
+		} else {
+			log.Warn("Storage cannot scan links, reputation rechecks disabled",
+				zap.String("storage", string(cfg.Storage.Type)))
+		}
+	}
+
 	// Default base URL from config
 	baseURL := cfg.Server.BaseURL
 
@@ -81,11 +175,28 @@ func NewURLService(cfg *config.Config, log *zap.Logger) (*URLService, error) {
 		zap.String("baseURL", baseURL))
 
 	return &URLService{
-		storage:   store,
-		baseURL:   baseURL,
-		generator: generator,
-		tracer:    tracer,
-		logger:    log,
+		storage:      store,
+		baseURL:      baseURL,
+		comingSoon:   cfg.Server.ComingSoonURL,
+		maxBatchSize: cfg.Server.MaxBatchSize,
+		generator:    generator,
+		clicks:       clicks,
+		accesses:     accesses,
+		clickHub:     analytics.NewClickHub(cfg.Analytics.WatchBufferSize),
+		guesses:      newGuessThrottle(cfg.Password),
+		verifySlots:  newVerifySlots(cfg.Password),
+		usage:        usage,
+		tags:         tags,
+		campaigns:    campaigns,
+		targeting:    targeting,
+		quotas:       cfg.Quota,
+		canonical:    cfg.Canonical,
+		policy:       policy.New(cfg.Policy),
+		checker:      checker,
+		rechecker:    rechecker,
+		now:          time.Now,
+		tracer:       tracer,
+		logger:       log,
 	}, nil
 }
 
@@ -108,115 +219,417 @@ func (s *URLService) ShortenURL(ctx context.Context, req *proto.ShortenURLReques
 		return nil, err
 	}
 
-	// Find existing shortID
-	shortID, err := s.findExistingShortID(ctx, originalURL)
-	if err != nil && err != storage.ErrNotFound {
+	// Resolve the optional expiry
+	expiresAt, err := s.resolveExpiry(ctx, req)
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
-	// If needed, generate new shortID
+	// Resolve the optional start of the activation window
+	notBefore, err := resolveNotBefore(req.NotBefore, expiresAt, s.now())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Hash the optional password; the plaintext is never stored
+	passwordHash, err := hashLinkPassword(req.Password)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.Bool("password_protected", passwordHash != ""))
+
+	if req.MaxClicks < 0 {
+		err := apperrors.InvalidArgument(apperrors.ReasonInvalidMaxClicks, "max_clicks must not be negative")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	tags, err := s.normalizeTags(req.Tags)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if req.CampaignId != "" {
+		if _, err := s.getCampaign(ctx, req.CampaignId); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+	}
+
+	rules, err := s.normalizeTargeting(ctx, req.Targeting)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	variants, err := s.normalizeVariants(ctx, req.Variants)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Only plain links take part in dedup; aliased, expiring, scheduled,
+	// protected, click-limited, tagged, campaign, targeted and split links are always new
+	dedup := req.CustomAlias == "" && expiresAt == nil && notBefore == nil && passwordHash == "" && req.MaxClicks == 0 &&
+		len(tags) == 0 && req.CampaignId == "" && len(rules) == 0 && len(variants) == 0
+	link := &models.URL{
+		ShortID:      req.CustomAlias,
+		OriginalURL:  originalURL,
+		CanonicalURL: s.canonicalize(ctx, originalURL),
+		Dedup:        dedup,
+		ExpiresAt:    expiresAt,
+		NotBefore:    notBefore,
+		PasswordHash: passwordHash,
+		MaxClicks:    req.MaxClicks,
+		Tags:         tags,
+		CampaignID:   req.CampaignId,
+		Targeting:    rules,
+		Variants:     variants,
+	}
+
+	// Custom aliases are stored under the requested ID
+	if req.CustomAlias != "" {
+		day, err := s.reserveLinks(ctx, 1)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if err := s.storeCustomAlias(ctx, link); err != nil {
+			s.releaseLinks(ctx, day, 1)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if err := s.tagNewLink(ctx, link); err != nil {
+			s.releaseLinks(ctx, day, 1)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		span.SetAttributes(attribute.Bool("custom_alias_used", true))
+
+		response := s.buildResponse(link)
+		span.SetAttributes(attribute.String("short_id", response.ShortId))
+		return response, nil
+	}
+
+	// Find existing shortID
+	err = storage.ErrNotFound
+	if link.Dedup {
+		link.ShortID, err = s.findExistingShortID(ctx, link.CanonicalURL)
+		if err != nil && err != storage.ErrNotFound {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, apperrors.FromStorage(err, "")
+		}
+	}
+
+	// If needed, generate new shortID once the tenant's quota allows another link
 	if err == storage.ErrNotFound {
-		shortID, err = s.generateAndStoreShortID(ctx, originalURL)
+		day, err := s.reserveLinks(ctx, 1)
 		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if err := s.generateAndStoreShortID(ctx, link); err != nil {
+			s.releaseLinks(ctx, day, 1)
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 			log.Error("Failed to generate and store short ID", zap.Error(err), zap.String("originalURL", originalURL))
 			return nil, err
 		}
+		if err := s.tagNewLink(ctx, link); err != nil {
+			s.releaseLinks(ctx, day, 1)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
 		span.SetAttributes(attribute.Bool("new_short_id_generated", true))
 	} else {
 		span.SetAttributes(attribute.Bool("existing_short_id_used", true))
 	}
 
 	// Build response
-	response := s.buildResponse(shortID)
+	response := s.buildResponse(link)
 	span.SetAttributes(attribute.String("short_id", response.ShortId))
 	return response, nil
 }
 
+// resolveExpiry turns the requested expires_at or ttl into an absolute expiry
+// It returns nil when the link should never expire
+func (s *URLService) resolveExpiry(ctx context.Context, req *proto.ShortenURLRequest) (*time.Time, error) {
+	log := logger.FromContext(ctx)
+
+	if req.ExpiresAt != nil && req.Ttl != nil {
+		log.Warn("Both expires_at and ttl provided")
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidExpiry, "only one of expires_at and ttl may be set")
+	}
+
+	now := s.now()
+	var expiresAt time.Time
+	switch {
+	case req.ExpiresAt != nil:
+		if err := req.ExpiresAt.CheckValid(); err != nil {
+			return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidExpiry, "invalid expires_at: %v", err)
+		}
+		expiresAt = req.ExpiresAt.AsTime()
+	case req.Ttl != nil:
+		if err := req.Ttl.CheckValid(); err != nil {
+			return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidExpiry, "invalid ttl: %v", err)
+		}
+		if req.Ttl.AsDuration() <= 0 {
+			return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidExpiry, "ttl must be positive")
+		}
+		expiresAt = now.Add(req.Ttl.AsDuration())
+	default:
+		return nil, nil
+	}
+
+	if !expiresAt.After(now) {
+		log.Warn("Expiry is not in the future", zap.Time("expiresAt", expiresAt))
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidExpiry, "expiry must be in the future")
+	}
+
+	expiresAt = expiresAt.UTC()
+	return &expiresAt, nil
+}
+
+// resolveNotBefore validates the requested start of a link's activation window
+// It returns nil when the link should resolve from creation, including when
+// the requested moment has already passed
+func resolveNotBefore(requested *timestamppb.Timestamp, expiresAt *time.Time, now time.Time) (*time.Time, error) {
+	if requested == nil {
+		return nil, nil
+	}
+	if err := requested.CheckValid(); err != nil {
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidSchedule, "invalid not_before: %v", err)
+	}
+
+	notBefore := requested.AsTime().UTC()
+	if expiresAt != nil && !notBefore.Before(*expiresAt) {
+		return nil, apperrors.InvalidArgument(apperrors.ReasonInvalidSchedule, "not_before must be before the expiry")
+	}
+	if !notBefore.After(now) {
+		return nil, nil
+	}
+	return &notBefore, nil
+}
+
 // validateURL checks if the URL is valid
 func (s *URLService) validateURL(ctx context.Context, originalURL string) error {
 	log := logger.FromContext(ctx)
 	_, span := s.tracer.Start(ctx, "URLService.validateURL")
 	defer span.End()
 
-	if _, err := url.ParseRequestURI(originalURL); err != nil {
+	if err := s.checkURL(ctx, originalURL); err != nil {
 		log.Warn("Invalid URL provided", zap.String("url", originalURL), zap.Error(err))
 		span.RecordError(err)
-		return fmt.Errorf("invalid URL: %w", err)
+		return err
 	}
 
 	log.Debug("URL validated successfully", zap.String("url", originalURL))
 	return nil
 }
 
-// findExistingShortID checks if a short link already exists for the URL
-func (s *URLService) findExistingShortID(ctx context.Context, originalURL string) (string, error) {
+// checkURL reports whether the URL can be shortened
+// URLs that break the destination policy are rejected with the rule that
+// fired, and URLs the reputation checker flags with the threat it found
+func (s *URLService) checkURL(ctx context.Context, originalURL string) error {
+	if _, err := url.ParseRequestURI(originalURL); err != nil {
+		return apperrors.InvalidArgument(apperrors.ReasonInvalidURL, "invalid URL: %s", originalURL).
+			WithMetadata("original_url", originalURL).
+			WithCause(err)
+	}
+
+	err := s.policy.Check(originalURL)
+	var violation *policy.Violation
+	if errors.As(err, &violation) {
+		return apperrors.InvalidArgument(apperrors.ReasonURLBlocked, "URL rejected by %s rule: %s", violation.Rule, violation.Detail).
+			WithMetadata("original_url", originalURL).
+			WithMetadata("rule", violation.Rule)
+	}
+	if err != nil {
+		return apperrors.InvalidArgument(apperrors.ReasonInvalidURL, "invalid URL: %s", originalURL).
+			WithMetadata("original_url", originalURL).
+			WithCause(err)
+	}
+	return s.checkReputation(ctx, originalURL)
+}
+
+// checkReputation rejects URLs the reputation checker flags
+// Fail-closed checkers that cannot reach a verdict make the request fail
+func (s *URLService) checkReputation(ctx context.Context, originalURL string) error {
+	if s.checker == nil {
+		return nil
+	}
+
+	verdict, err := s.checker.Check(ctx, originalURL)
+	if err != nil {
+		logger.FromContext(ctx).Error("Reputation check failed", zap.String("url", originalURL), zap.Error(err))
+		return apperrors.Unavailable(apperrors.ReasonCheckUnavailable, "URL reputation could not be checked").
+			WithCause(err)
+	}
+	if verdict.Flagged {
+		logger.FromContext(ctx).Warn("Flagged URL rejected",
+			zap.String("url", originalURL),
+			zap.String("threat", verdict.Reason))
+		return apperrors.InvalidArgument(apperrors.ReasonURLFlagged, "URL flagged as %s", verdict.Reason).
+			WithMetadata("original_url", originalURL).
+			WithMetadata("threat", verdict.Reason)
+	}
+	return nil
+}
+
+// canonicalize returns the form of a URL that dedup compares
+// URLs are compared as submitted when canonicalization is disabled or fails
+func (s *URLService) canonicalize(ctx context.Context, originalURL string) string {
+	if !s.canonical.Enabled {
+		return originalURL
+	}
+	canonicalURL, err := utils.CanonicalizeURL(originalURL, s.canonical.StripParams)
+	if err != nil {
+		logger.FromContext(ctx).Debug("Cannot canonicalize URL", zap.String("url", originalURL), zap.Error(err))
+		return originalURL
+	}
+	return canonicalURL
+}
+
+// findExistingShortID checks if a short link already exists for the canonical URL
+func (s *URLService) findExistingShortID(ctx context.Context, canonicalURL string) (string, error) {
 	log := logger.FromContext(ctx)
 	_, span := s.tracer.Start(ctx, "URLService.findExistingShortID")
 	defer span.End()
 
-	shortID, err := s.storage.Find(ctx, originalURL)
+	shortID, err := s.storage.Find(ctx, canonicalURL)
 	if err == nil {
 		// Found existing short ID, log and return
 		log.Info("Found existing short ID",
 			zap.String("shortID", shortID),
-			zap.String("url", originalURL))
+			zap.String("url", canonicalURL))
 		span.SetAttributes(attribute.String("existing_short_id", shortID))
 		return shortID, nil
 	}
 
 	if err != storage.ErrNotFound {
-		log.Error("Error finding existing short ID", zap.Error(err), zap.String("url", originalURL))
+		log.Error("Error finding existing short ID", zap.Error(err), zap.String("url", canonicalURL))
 		span.RecordError(err)
 	} else {
-		log.Debug("No existing short ID found", zap.String("url", originalURL))
+		log.Debug("No existing short ID found", zap.String("url", canonicalURL))
 	}
 
 	return "", err
 }
 
-// generateAndStoreShortID creates a new short ID and stores it
-func (s *URLService) generateAndStoreShortID(ctx context.Context, originalURL string) (string, error) {
+// generateAndStoreShortID creates a new short ID for the link and stores it
+func (s *URLService) generateAndStoreShortID(ctx context.Context, link *models.URL) error {
 	log := logger.FromContext(ctx)
 	_, span := s.tracer.Start(ctx, "URLService.generateAndStoreShortID")
 	defer span.End()
 
-	// Use the generator's method to generate short ID
-	shortID := s.generator.GenerateShortID()
-	log.Info("Generated new short ID",
-		zap.String("shortID", shortID),
-		zap.String("url", originalURL))
-	span.SetAttributes(attribute.String("generated_short_id", shortID))
+	for attempt := 1; attempt <= maxGenerateAttempts; attempt++ {
+		// Use the generator's method to generate short ID
+		link.ShortID = s.generator.GenerateShortID()
+		log.Info("Generated new short ID",
+			zap.String("shortID", link.ShortID),
+			zap.String("url", link.OriginalURL))
+		span.SetAttributes(attribute.String("generated_short_id", link.ShortID))
+
+		// Store the URL and generated short ID
+		err := s.storage.StoreWithID(ctx, link)
+		if err == nil {
+			log.Debug("Successfully stored URL with short ID",
+				zap.String("shortID", link.ShortID),
+				zap.String("url", link.OriginalURL))
+			return nil
+		}
+
+		if err != storage.ErrAlreadyExists {
+			log.Error("Failed to store URL", zap.Error(err), zap.String("shortID", link.ShortID))
+			span.RecordError(err)
+			return apperrors.FromStorage(err, link.ShortID)
+		}
+
+		// A concurrent request may have stored the same URL first
+		if link.Dedup {
+			if existingID, findErr := s.storage.Find(ctx, link.DedupKey()); findErr == nil {
+				log.Info("URL was stored concurrently, reusing short ID", zap.String("shortID", existingID))
+				link.ShortID = existingID
+				return nil
+			}
+		}
+
+		// Otherwise the generated ID collides with a custom alias, try another one
+		log.Warn("Generated short ID already taken, retrying",
+			zap.String("shortID", link.ShortID),
+			zap.Int("attempt", attempt))
+	}
+
+	err := apperrors.Unavailable(apperrors.ReasonShortIDExhausted, "no free short ID after %d attempts", maxGenerateAttempts)
+	span.RecordError(err)
+	return err
+}
+
+// storeCustomAlias validates the caller-chosen short ID of the link and stores it
+func (s *URLService) storeCustomAlias(ctx context.Context, link *models.URL) error {
+	log := logger.FromContext(ctx)
+	_, span := s.tracer.Start(ctx, "URLService.storeCustomAlias",
+		trace.WithAttributes(attribute.String("custom_alias", link.ShortID)))
+	defer span.End()
+
+	if err := utils.ValidateAlias(link.ShortID); err != nil {
+		log.Warn("Invalid custom alias provided", zap.String("alias", link.ShortID), zap.Error(err))
+		span.RecordError(err)
+		return apperrors.InvalidArgument(apperrors.ReasonInvalidAlias, "%s", err.Error()).
+			WithMetadata("custom_alias", link.ShortID)
+	}
 
-	// Store the URL and generated short ID
-	if err := s.storage.StoreWithID(ctx, shortID, originalURL); err != nil {
-		log.Error("Failed to store URL", zap.Error(err), zap.String("shortID", shortID))
+	// Aliases are not dedup links, so Find never returns them for other requests
+	err := s.storage.StoreWithID(ctx, link)
+	if err == storage.ErrAlreadyExists {
+		log.Warn("Custom alias already taken", zap.String("alias", link.ShortID))
+		span.RecordError(err)
+		return apperrors.AlreadyExists(apperrors.ReasonAliasTaken, "custom alias already taken: %s", link.ShortID).
+			WithMetadata("custom_alias", link.ShortID)
+	}
+	if err != nil {
+		log.Error("Failed to store custom alias", zap.Error(err), zap.String("alias", link.ShortID))
 		span.RecordError(err)
-		return "", fmt.Errorf("failed to store URL: %w", err)
+		return apperrors.FromStorage(err, link.ShortID)
 	}
 
-	log.Debug("Successfully stored URL with short ID",
-		zap.String("shortID", shortID),
-		zap.String("url", originalURL))
-	return shortID, nil
+	log.Info("Stored URL with custom alias",
+		zap.String("alias", link.ShortID),
+		zap.String("url", link.OriginalURL))
+	return nil
 }
 
 // buildResponse creates the response object
-func (s *URLService) buildResponse(shortID string) *proto.ShortenURLResponse {
-	return &proto.ShortenURLResponse{
-		ShortId:  shortID,
-		ShortUrl: s.baseURL + shortID,
+func (s *URLService) buildResponse(link *models.URL) *proto.ShortenURLResponse {
+	response := &proto.ShortenURLResponse{
+		ShortId:  link.ShortID,
+		ShortUrl: s.baseURL + link.ShortID,
+	}
+	if link.ExpiresAt != nil {
+		response.ExpiresAt = timestamppb.New(*link.ExpiresAt)
+	}
+	if link.NotBefore != nil {
+		response.NotBefore = timestamppb.New(*link.NotBefore)
 	}
+	return response
 }
 
 // ExpandURL implements the ExpandURL RPC method
 func (s *URLService) ExpandURL(ctx context.Context, req *proto.ExpandURLRequest) (*proto.ExpandURLResponse, error) {
 	log := logger.FromContext(ctx)
 
-	_, span := s.tracer.Start(ctx, "URLService.ExpandURL",
+	ctx, span := s.tracer.Start(ctx, "URLService.ExpandURL",
 		trace.WithAttributes(attribute.String("short_id", req.ShortId)))
 	defer span.End()
 
@@ -228,25 +641,180 @@ func (s *URLService) ExpandURL(ctx context.Context, req *proto.ExpandURLRequest)
 			zap.Bool("remote", spanCtx.IsRemote()))
 	}
 
-	// Get original URL from storage
-	originalURL, err := s.storage.Get(ctx, req.ShortId)
+	// Get the link from storage
+	link, err := s.storage.Get(ctx, req.ShortId)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
 		if err == storage.ErrNotFound {
 			log.Warn("Short URL not found", zap.String("shortID", req.ShortId))
-			return nil, fmt.Errorf("short URL not found: %s", req.ShortId)
+		} else {
+			log.Error("Failed to retrieve URL", zap.Error(err), zap.String("shortID", req.ShortId))
 		}
-		log.Error("Failed to retrieve URL", zap.Error(err), zap.String("shortID", req.ShortId))
-		return nil, fmt.Errorf("failed to retrieve URL: %w", err)
+		return nil, apperrors.FromStorage(err, req.ShortId)
+	}
+
+	// Disabled links are kept but no longer resolve
+	if link.Disabled {
+		log.Info("Short URL disabled", zap.String("shortID", req.ShortId))
+		span.SetAttributes(attribute.Bool("disabled", true))
+		span.SetStatus(codes.Error, "short URL disabled")
+		return nil, apperrors.FailedPrecondition(apperrors.ReasonURLDisabled, "short URL disabled: %s", req.ShortId).
+			WithMetadata("short_id", req.ShortId)
+	}
+
+	// Quarantined links are kept for review but no longer resolve
+	if link.Quarantined() {
+		log.Info("Short URL quarantined",
+			zap.String("shortID", req.ShortId),
+			zap.String("reason", link.QuarantineReason))
+		span.SetAttributes(attribute.Bool("quarantined", true))
+		span.SetStatus(codes.Error, "short URL quarantined")
+		return nil, apperrors.FailedPrecondition(apperrors.ReasonURLQuarantined, "short URL quarantined: %s", req.ShortId).
+			WithMetadata("short_id", req.ShortId)
+	}
+
+	// Expired links are kept but no longer resolve; the activation window is
+	// checked against the same instant
+	now := s.now()
+	if link.Expired(now) {
+		log.Info("Short URL expired",
+			zap.String("shortID", req.ShortId),
+			zap.Time("expiresAt", *link.ExpiresAt))
+		span.SetAttributes(attribute.Bool("expired", true))
+		span.SetStatus(codes.Error, "short URL expired")
+		return nil, apperrors.FailedPrecondition(apperrors.ReasonURLExpired, "short URL expired: %s", req.ShortId).
+			WithMetadata("short_id", req.ShortId)
+	}
+
+	// Scheduled links are created ahead of time but only resolve once their window opens
+	if link.Scheduled(now) {
+		log.Info("Short URL not active yet",
+			zap.String("shortID", req.ShortId),
+			zap.Time("notBefore", *link.NotBefore))
+		span.SetAttributes(attribute.Bool("scheduled", true))
+		span.SetStatus(codes.Error, "short URL not active yet")
+		err := apperrors.FailedPrecondition(apperrors.ReasonURLNotYetActive, "short URL not active yet: %s", req.ShortId).
+			WithMetadata("short_id", req.ShortId).
+			WithMetadata("not_before", link.NotBefore.Format(time.RFC3339))
+		if s.comingSoon != "" {
+			err = err.WithMetadata("fallback_url", s.comingSoon)
+		}
+		return nil, err
+	}
+
+	// Protected links only resolve with the right password
+	if err := s.checkLinkPassword(ctx, link, req.Password); err != nil {
+		span.SetAttributes(attribute.Bool("password_protected", true))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Click-limited links count down last, so refused requests cost no clicks
+	if link.ClickLimited() {
+		remaining, err := s.storage.ConsumeClick(ctx, req.ShortId)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			if err == storage.ErrClicksExhausted {
+				log.Info("Short URL click limit reached", zap.String("shortID", req.ShortId))
+			} else {
+				log.Error("Failed to consume click", zap.Error(err), zap.String("shortID", req.ShortId))
+			}
+			return nil, apperrors.FromStorage(err, req.ShortId)
+		}
+		span.SetAttributes(attribute.Int64("remaining_clicks", remaining))
+	}
+
+	// Record the click off the request path
+	destination, variantID := s.destination(ctx, link)
+	s.recordAccess(ctx, link.ShortID)
+	s.trackClick(ctx, link, destination, variantID)
+	if variantID != "" {
+		span.SetAttributes(attribute.String("variant_id", variantID))
 	}
 
 	log.Info("URL expanded",
 		zap.String("shortID", req.ShortId),
-		zap.String("originalURL", originalURL))
-	span.SetAttributes(attribute.String("original_url", originalURL))
+		zap.String("originalURL", destination))
+	span.SetAttributes(attribute.String("original_url", destination))
 	return &proto.ExpandURLResponse{
-		OriginalUrl: originalURL,
+		OriginalUrl: destination,
 	}, nil
 }
+
+// destination returns the URL a link sends the visitor of the request to,
+// and the ID of the variant served if the link is split. The destination is
+// that of the first targeting rule the visitor matches, else of the variant
+// picked for the visitor, else the original URL, with the parameters of the
+// link's campaign added
+func (s *URLService) destination(ctx context.Context, link *models.URL) (string, string) {
+	if !link.Targeted() && !link.Split() {
+		return s.addCampaign(ctx, link, link.OriginalURL), ""
+	}
+
+	log := logger.FromContext(ctx)
+	visitor := targeting.VisitorFromContext(ctx)
+	if rule := targeting.Match(link.Targeting, visitor); rule != nil {
+		log.Debug("Targeting rule matched",
+			zap.String("shortID", link.ShortID),
+			zap.String("destination", rule.DestinationURL))
+		return s.addCampaign(ctx, link, rule.DestinationURL), ""
+	}
+	if variant := targeting.Pick(link.Variants, link.ShortID, visitor.ID); variant != nil {
+		log.Debug("Variant picked",
+			zap.String("shortID", link.ShortID),
+			zap.String("variantID", variant.ID),
+			zap.Bool("sticky", visitor.ID != ""))
+		return s.addCampaign(ctx, link, variant.DestinationURL), variant.ID
+	}
+	return s.addCampaign(ctx, link, link.OriginalURL), ""
+}
+
+// recordAccess counts the resolution of links without blocking
+// A failure is only logged, as the redirects were already allowed
+func (s *URLService) recordAccess(ctx context.Context, shortIDs ...string) {
+	if !s.accesses.Track(ctx, shortIDs...) {
+		logger.FromContext(ctx).Debug("Access dropped", zap.Strings("shortIDs", shortIDs))
+	}
+}
+
+// trackClick publishes a click on a resolved link to live feeds and queues it
+// for storage, without blocking
+func (s *URLService) trackClick(ctx context.Context, link *models.URL, destination string, variantID string) {
+	click := analytics.ClickFromContext(ctx, link.ShortID, destination)
+	click.Variant = variantID
+	s.clickHub.Publish(click)
+
+	if s.clicks == nil {
+		return
+	}
+	if !s.clicks.Track(click) {
+		logger.FromContext(ctx).Debug("Click dropped", zap.String("shortID", link.ShortID))
+	}
+}
+
+// Storage returns the link storage, so other components can share its connections
+func (s *URLService) Storage() storage.URLStorage {
+	return s.storage
+}
+
+// CloseStreams ends all open WatchClicks streams, so a graceful server stop
+// does not wait for them
+func (s *URLService) CloseStreams() {
+	s.clickHub.Close()
+}
+
+// Close stops rechecking links, writes any queued clicks and accesses and
+// closes the storage
+func (s *URLService) Close() error {
+	if s.rechecker != nil {
+		s.rechecker.Close()
+	}
+	if s.clicks != nil {
+		s.clicks.Close()
+	}
+	s.accesses.Close()
+	return s.storage.Close()
+}
@@ -0,0 +1,539 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/analytics"
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/config"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/policy"
+	"github.com/hohotang/shortlink-core/internal/reputation"
+	"github.com/hohotang/shortlink-core/internal/storage"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTestService creates a URLService backed by in-memory storage
+func newTestService(t *testing.T) *URLService {
+	t.Helper()
+
+	cfg := &config.Config{
+		Server:    config.ServerConfig{BaseURL: "http://sho.rt/"},
+		Storage:   config.StorageConfig{Type: models.Memory},
+		Snowflake: config.SnowflakeConfig{MachineID: 1},
+	}
+	s, err := NewURLService(cfg, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create URL service: %v", err)
+	}
+	return s
+}
+
+func TestShortenURL_Dedup(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	first, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	second, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	if first.ShortId != second.ShortId {
+		t.Errorf("Expected the same short ID for the same URL, got %s and %s", first.ShortId, second.ShortId)
+	}
+	if first.ShortUrl != "http://sho.rt/"+first.ShortId {
+		t.Errorf("Unexpected short URL %s", first.ShortUrl)
+	}
+}
+
+func TestShortenURL_CanonicalDedup(t *testing.T) {
+	s := newTestService(t)
+	s.canonical = config.CanonicalConfig{Enabled: true, StripParams: []string{"utm_*"}}
+	ctx := context.Background()
+
+	first, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "http://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	for _, spelling := range []string{
+		"HTTP://Example.com/a",
+		"http://example.com:80/a",
+		"http://example.com/a?utm_source=x",
+		"http://example.com/b/../a",
+	} {
+		resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: spelling})
+		if err != nil {
+			t.Fatalf("ShortenURL(%s) returned unexpected error: %v", spelling, err)
+		}
+		if resp.ShortId != first.ShortId {
+			t.Errorf("Expected %s to reuse short ID %s, got %s", spelling, first.ShortId, resp.ShortId)
+		}
+	}
+
+	// The link keeps the URL it was first created with
+	info, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: first.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if info.Info.OriginalUrl != "http://example.com/a" || info.Info.CanonicalUrl != "http://example.com/a" {
+		t.Errorf("Unexpected original %s or canonical URL %s", info.Info.OriginalUrl, info.Info.CanonicalUrl)
+	}
+
+	// Other query parameters still tell links apart
+	other, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "http://example.com/a?id=1"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if other.ShortId == first.ShortId {
+		t.Error("Expected a different short ID for a different query")
+	}
+}
+
+func TestShortenURL_CanonicalizeDisabled(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	first, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "http://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	second, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "HTTP://Example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if first.ShortId == second.ShortId {
+		t.Error("Expected URLs to be compared as submitted when canonicalization is disabled")
+	}
+}
+
+func TestShortenURL_Policy(t *testing.T) {
+	s := newTestService(t)
+	s.policy = policy.New(config.PolicyConfig{
+		AllowedSchemes:   []string{"http", "https"},
+		DeniedDomains:    []string{"*.evil.com"},
+		RejectPrivateIPs: true,
+	})
+	ctx := context.Background()
+
+	for url, rule := range map[string]string{
+		"javascript:alert(1)":        policy.RuleScheme,
+		"https://login.evil.com/a":   policy.RuleDomainDeny,
+		"http://10.0.0.1/admin":      policy.RulePrivateIP,
+		"http://localhost:8080/vars": policy.RulePrivateIP,
+	} {
+		_, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: url})
+		if status.Code(err) != codes.InvalidArgument || apperrors.ReasonOf(err) != apperrors.ReasonURLBlocked {
+			t.Errorf("ShortenURL(%s): expected InvalidArgument URL_BLOCKED, got %v", url, err)
+			continue
+		}
+		for _, detail := range status.Convert(err).Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Metadata["rule"] != rule {
+				t.Errorf("ShortenURL(%s): expected rule %s, got %s", url, rule, info.Metadata["rule"])
+			}
+		}
+	}
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	// Retargeting is held to the same policy
+	_, err = s.UpdateURL(ctx, &proto.UpdateURLRequest{ShortId: resp.ShortId, OriginalUrl: "file:///etc/passwd"})
+	if apperrors.ReasonOf(err) != apperrors.ReasonURLBlocked {
+		t.Errorf("UpdateURL: expected URL_BLOCKED, got %v", err)
+	}
+}
+
+func TestShortenURL_CustomAlias(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/sale",
+		CustomAlias: "spring-sale",
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if resp.ShortId != "spring-sale" {
+		t.Errorf("Expected short ID spring-sale, got %s", resp.ShortId)
+	}
+
+	// The alias is not reused for plain requests
+	plain, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/sale"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if plain.ShortId == "spring-sale" {
+		t.Errorf("Plain request should not reuse a custom alias")
+	}
+
+	// Taken alias
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/other",
+		CustomAlias: "spring-sale",
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("Expected AlreadyExists for taken alias, got %v", err)
+	}
+
+	// Reserved alias
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/other",
+		CustomAlias: "admin",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for reserved alias, got %v", err)
+	}
+}
+
+func TestShortenURL_Expiry(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/flash",
+		Ttl:         durationpb.New(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if resp.ExpiresAt == nil {
+		t.Fatalf("Expected expires_at in response")
+	}
+
+	// Expiring links are never deduped
+	plain, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/flash"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if plain.ShortId == resp.ShortId {
+		t.Errorf("Plain request should not reuse an expiring link")
+	}
+
+	// Expiry in the past is rejected
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/flash",
+		ExpiresAt:   timestamppb.New(time.Now().Add(-time.Minute)),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for past expiry, got %v", err)
+	}
+
+	// Both forms at once are rejected
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/flash",
+		ExpiresAt:   timestamppb.New(time.Now().Add(time.Hour)),
+		Ttl:         durationpb.New(time.Hour),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for expires_at with ttl, got %v", err)
+	}
+}
+
+func TestExpandURL_Expired(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	past := time.Now().Add(-time.Minute)
+	err := s.storage.StoreWithID(ctx, &models.URL{
+		ShortID:     "gone",
+		OriginalURL: "https://example.com/gone",
+		ExpiresAt:   &past,
+	})
+	if err != nil {
+		t.Fatalf("StoreWithID returned unexpected error: %v", err)
+	}
+
+	_, err = s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: "gone"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition for expired link, got %v", err)
+	}
+}
+
+func TestExpiry_ServiceClock(t *testing.T) {
+	s := newTestService(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// A TTL counts from the service clock
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/flash",
+		Ttl:         durationpb.New(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if !resp.ExpiresAt.AsTime().Equal(now.Add(time.Hour)) {
+		t.Errorf("Expected expires_at %v, got %v", now.Add(time.Hour), resp.ExpiresAt.AsTime())
+	}
+
+	// Once the clock passes the expiry, every RPC agrees the link is expired
+	now = now.Add(2 * time.Hour)
+	_, err = s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if status.Code(err) != codes.FailedPrecondition || apperrors.ReasonOf(err) != apperrors.ReasonURLExpired {
+		t.Errorf("Expected FailedPrecondition URL_EXPIRED, got %v", err)
+	}
+	batch, err := s.BatchExpandURLs(ctx, &proto.BatchExpandURLsRequest{ShortIds: []string{resp.ShortId}})
+	if err != nil {
+		t.Fatalf("BatchExpandURLs returned unexpected error: %v", err)
+	}
+	if batch.Results[0].Reason != string(apperrors.ReasonURLExpired) {
+		t.Errorf("Expected batch reason URL_EXPIRED, got %q", batch.Results[0].Reason)
+	}
+	info, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if info.Info.Status != proto.URLStatus_URL_STATUS_EXPIRED {
+		t.Errorf("Expected status EXPIRED, got %v", info.Info.Status)
+	}
+
+	// An expiry that is past on the service clock is rejected
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/flash",
+		ExpiresAt:   timestamppb.New(now.Add(-time.Minute)),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for past expiry, got %v", err)
+	}
+}
+
+func TestShortenURL_Reputation(t *testing.T) {
+	s := newTestService(t)
+	s.checker = flaggingChecker{"https://evil.example/": "phishing"}
+	ctx := context.Background()
+
+	_, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://evil.example/"})
+	if status.Code(err) != codes.InvalidArgument || apperrors.ReasonOf(err) != apperrors.ReasonURLFlagged {
+		t.Errorf("Expected InvalidArgument URL_FLAGGED, got %v", err)
+	}
+	if _, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/"}); err != nil {
+		t.Errorf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	// A fail-closed checker that cannot answer refuses the request
+	s.checker = reputation.NewHTTPChecker("http://127.0.0.1:1", 50*time.Millisecond, false, zap.NewNop())
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.org/"})
+	if status.Code(err) != codes.Unavailable || apperrors.ReasonOf(err) != apperrors.ReasonCheckUnavailable {
+		t.Errorf("Expected Unavailable CHECK_UNAVAILABLE, got %v", err)
+	}
+}
+
+func TestExpandURL_Quarantined(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/turned-bad"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if err := s.storage.(storage.QuarantineStorage).Quarantine(ctx, resp.ShortId, "malware"); err != nil {
+		t.Fatalf("Quarantine returned unexpected error: %v", err)
+	}
+
+	_, err = s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId})
+	if status.Code(err) != codes.FailedPrecondition || apperrors.ReasonOf(err) != apperrors.ReasonURLQuarantined {
+		t.Errorf("Expected FailedPrecondition URL_QUARANTINED, got %v", err)
+	}
+
+	// The link is kept for review
+	info, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: resp.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if info.Info.Status != proto.URLStatus_URL_STATUS_QUARANTINED || info.Info.QuarantineReason != "malware" {
+		t.Errorf("Expected a quarantined link, got status %v and reason %q", info.Info.Status, info.Info.QuarantineReason)
+	}
+
+	// Retargeting releases the link from quarantine
+	if _, err := s.UpdateURL(ctx, &proto.UpdateURLRequest{ShortId: resp.ShortId, OriginalUrl: "https://example.com/clean"}); err != nil {
+		t.Fatalf("UpdateURL returned unexpected error: %v", err)
+	}
+	if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: resp.ShortId}); err != nil {
+		t.Errorf("ExpandURL returned unexpected error after retargeting: %v", err)
+	}
+}
+
+// flaggingChecker flags the URLs in its map
+type flaggingChecker map[string]string
+
+func (c flaggingChecker) Check(_ context.Context, rawURL string) (reputation.Verdict, error) {
+	if reason, ok := c[rawURL]; ok {
+		return reputation.Verdict{Flagged: true, Reason: reason}, nil
+	}
+	return reputation.Verdict{}, nil
+}
+
+func TestExpandURL_TracksClick(t *testing.T) {
+	cfg := &config.Config{
+		Server:    config.ServerConfig{BaseURL: "http://sho.rt/"},
+		Storage:   config.StorageConfig{Type: models.Memory},
+		Snowflake: config.SnowflakeConfig{MachineID: 1},
+		Analytics: config.AnalyticsConfig{Enabled: true},
+	}
+	s, err := NewURLService(cfg, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create URL service: %v", err)
+	}
+	ctx := context.Background()
+
+	shortened, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	expandCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(analytics.MetadataReferrer, "https://news.example.com/"))
+	if _, err := s.ExpandURL(expandCtx, &proto.ExpandURLRequest{ShortId: shortened.ShortId}); err != nil {
+		t.Fatalf("ExpandURL returned unexpected error: %v", err)
+	}
+	// Failed resolutions are not clicks
+	if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: "missing"}); err == nil {
+		t.Fatalf("Expected ExpandURL to fail for unknown short ID")
+	}
+
+	// Close writes the queued clicks
+	store := s.storage.(*storage.MemoryStorage)
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close returned unexpected error: %v", err)
+	}
+
+	clicks := store.Clicks()
+	if len(clicks) != 1 {
+		t.Fatalf("Expected 1 click, got %d", len(clicks))
+	}
+	if clicks[0].ShortID != shortened.ShortId || clicks[0].Referrer != "https://news.example.com/" {
+		t.Errorf("Unexpected click: %+v", clicks[0])
+	}
+}
+
+func TestExpandURL_MaxClicks(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	plain, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/download"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	limited, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/download", MaxClicks: 2})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if limited.ShortId == plain.ShortId {
+		t.Errorf("Expected a new short ID for a click-limited link")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: limited.ShortId}); err != nil {
+			t.Fatalf("Click %d: ExpandURL returned unexpected error: %v", i+1, err)
+		}
+	}
+
+	_, err = s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: limited.ShortId})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition once the limit is reached, got %v", err)
+	}
+
+	info, err := s.GetURLInfo(ctx, &proto.GetURLInfoRequest{ShortId: limited.ShortId})
+	if err != nil {
+		t.Fatalf("GetURLInfo returned unexpected error: %v", err)
+	}
+	if info.Info.Status != proto.URLStatus_URL_STATUS_EXHAUSTED || info.Info.RemainingClicks != 0 {
+		t.Errorf("Expected exhausted link with no clicks left, got %v", info.Info)
+	}
+
+	// The plain link for the same URL is not affected
+	if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: plain.ShortId}); err != nil {
+		t.Errorf("Expected plain link to resolve, got %v", err)
+	}
+
+	_, err = s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/download", MaxClicks: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument for negative max_clicks, got %v", err)
+	}
+}
+
+func TestExpandURL_WrongPasswordCostsNoClick(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	limited, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+		OriginalUrl: "https://example.com/download",
+		Password:    "s3cret",
+		MaxClicks:   1,
+	})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: limited.ShortId, Password: "guess"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied, got %v", err)
+	}
+	if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: limited.ShortId, Password: "s3cret"}); err != nil {
+		t.Errorf("Expected the one click to be left, got %v", err)
+	}
+}
+
+func TestTenantIsolation(t *testing.T) {
+	s := newTestService(t)
+	acme := tenant.WithContext(context.Background(), "acme")
+	globex := tenant.WithContext(context.Background(), "globex")
+
+	// The same alias can be taken once per tenant
+	for _, ctx := range []context.Context{acme, globex} {
+		if _, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{
+			OriginalUrl: "https://" + tenant.FromContext(ctx) + ".example.com",
+			CustomAlias: "home",
+		}); err != nil {
+			t.Fatalf("ShortenURL(%s) returned unexpected error: %v", tenant.FromContext(ctx), err)
+		}
+	}
+
+	resp, err := s.ExpandURL(acme, &proto.ExpandURLRequest{ShortId: "home"})
+	if err != nil || resp.OriginalUrl != "https://acme.example.com" {
+		t.Errorf("ExpandURL(acme) = %v, %v; expected https://acme.example.com", resp, err)
+	}
+
+	_, err = s.ExpandURL(context.Background(), &proto.ExpandURLRequest{ShortId: "home"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound in the default tenant, got %v", err)
+	}
+
+	// Dedup does not cross tenants
+	first, err := s.ShortenURL(acme, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/shared"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	second, err := s.ShortenURL(globex, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/shared"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	if first.ShortId == second.ShortId {
+		t.Errorf("Expected different short IDs across tenants, got %s twice", first.ShortId)
+	}
+
+	list, err := s.ListURLs(globex, &proto.ListURLsRequest{})
+	if err != nil {
+		t.Fatalf("ListURLs returned unexpected error: %v", err)
+	}
+	if len(list.Urls) != 2 {
+		t.Errorf("Expected 2 URLs listed for globex, got %d", len(list.Urls))
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"github.com/hohotang/shortlink-core/internal/analytics"
+	"github.com/hohotang/shortlink-core/internal/apperrors"
+	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"github.com/hohotang/shortlink-core/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WatchClicks implements the WatchClicks RPC method
+func (s *URLService) WatchClicks(req *proto.WatchClicksRequest, stream grpc.ServerStreamingServer[proto.ClickEvent]) error {
+	ctx := stream.Context()
+	log := logger.FromContext(ctx).With(
+		zap.String("shortId", req.ShortId),
+		zap.String("domain", req.Domain))
+
+	// The span only covers setting up the subscription; streams may stay open for hours
+	_, span := s.tracer.Start(ctx, "URLService.WatchClicks",
+		trace.WithAttributes(
+			attribute.String("short_id", req.ShortId),
+			attribute.String("domain", req.Domain)))
+	// Watchers only ever see clicks of their own tenant
+	sub := s.clickHub.Subscribe(analytics.ClickFilter{
+		TenantID: tenant.FromContext(ctx),
+		ShortID:  req.ShortId,
+		Domain:   req.Domain,
+	})
+	span.End()
+	defer s.clickHub.Unsubscribe(sub)
+
+	log.Info("Click watcher connected", zap.Int("subscribers", s.clickHub.Subscribers()))
+
+	sent := 0
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Click watcher disconnected", zap.Int("sent", sent))
+			return status.FromContextError(ctx.Err()).Err()
+
+		case click, ok := <-sub.Events():
+			if !ok {
+				switch sub.Err() {
+				case analytics.ErrSlowSubscriber:
+					log.Warn("Click watcher too slow, disconnecting", zap.Int("sent", sent))
+					return apperrors.ResourceExhausted(apperrors.ReasonSlowConsumer, "%s", sub.Err().Error())
+				default:
+					log.Info("Click feed closed", zap.Int("sent", sent))
+					return apperrors.Unavailable(apperrors.ReasonShuttingDown, "%s", analytics.ErrHubClosed.Error())
+				}
+			}
+			if err := stream.Send(toClickEvent(click)); err != nil {
+				log.Warn("Failed to send click", zap.Error(err), zap.Int("sent", sent))
+				return err
+			}
+			sent++
+		}
+	}
+}
+
+// toClickEvent converts a click into its API representation
+func toClickEvent(click *models.Click) *proto.ClickEvent {
+	return &proto.ClickEvent{
+		ShortId:     click.ShortID,
+		OriginalUrl: click.OriginalURL,
+		ClickedAt:   timestamppb.New(click.ClickedAt),
+		Referrer:    click.Referrer,
+		UserAgent:   click.UserAgent,
+		ClientIp:    click.ClientIP,
+		VariantId:   click.Variant,
+	}
+}
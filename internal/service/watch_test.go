@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeClickStream is a WatchClicks server stream that hands sent events to a channel
+type fakeClickStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *proto.ClickEvent
+}
+
+func (f *fakeClickStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeClickStream) Send(event *proto.ClickEvent) error {
+	f.events <- event
+	return nil
+}
+
+func TestWatchClicks(t *testing.T) {
+	s := newTestService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watched, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/a"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+	other, err := s.ShortenURL(ctx, &proto.ShortenURLRequest{OriginalUrl: "https://example.com/b"})
+	if err != nil {
+		t.Fatalf("ShortenURL returned unexpected error: %v", err)
+	}
+
+	stream := &fakeClickStream{ctx: ctx, events: make(chan *proto.ClickEvent, 10)}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.WatchClicks(&proto.WatchClicksRequest{ShortId: watched.ShortId}, stream)
+	}()
+	for s.clickHub.Subscribers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, shortID := range []string{other.ShortId, watched.ShortId} {
+		if _, err := s.ExpandURL(ctx, &proto.ExpandURLRequest{ShortId: shortID}); err != nil {
+			t.Fatalf("ExpandURL returned unexpected error: %v", err)
+		}
+	}
+
+	select {
+	case event := <-stream.events:
+		if event.ShortId != watched.ShortId || event.OriginalUrl != "https://example.com/a" {
+			t.Errorf("Unexpected click event: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("No click event received")
+	}
+
+	cancel()
+	if err := <-done; status.Code(err) != codes.Canceled {
+		t.Errorf("Expected Canceled after the client left, got %v", err)
+	}
+	if len(stream.events) != 0 {
+		t.Errorf("Expected only the watched short ID, got %d more events", len(stream.events))
+	}
+}
@@ -3,9 +3,11 @@ package storage
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hohotang/shortlink-core/internal/config"
 	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
 	"go.uber.org/zap"
 )
 
@@ -43,13 +45,13 @@ func NewCombinedStorage(redisURL string, cacheTTL int, cfg *config.Config) (*Com
 }
 
 // Find implements URLStorage.Find
-func (s *CombinedStorage) Find(ctx context.Context, originalURL string) (string, error) {
-	if originalURL == "" {
+func (s *CombinedStorage) Find(ctx context.Context, canonicalURL string) (string, error) {
+	if canonicalURL == "" {
 		return "", ErrInvalidURL
 	}
 
 	// Try Redis first
-	shortID, err := s.redis.Find(ctx, originalURL)
+	shortID, err := s.redis.Find(ctx, canonicalURL)
 	if err == nil {
 		return shortID, nil
 	} else if err != ErrNotFound {
@@ -58,32 +60,69 @@ func (s *CombinedStorage) Find(ctx context.Context, originalURL string) (string,
 	}
 
 	// Try PostgreSQL
-	shortID, err = s.postgres.Find(ctx, originalURL)
+	shortID, err = s.postgres.Find(ctx, canonicalURL)
 	if err != nil {
 		return "", err
 	}
 
 	// Found in PostgreSQL, update Redis cache
-	if cacheErr := s.redis.StoreWithID(ctx, shortID, originalURL); cacheErr != nil {
+	if cacheErr := s.redis.cacheReverse(ctx, canonicalURL, shortID); cacheErr != nil {
 		// Log error but don't fail if Redis fails
 		s.logger.Warn("Failed to update Redis cache", zap.Error(cacheErr))
 	}
 	return shortID, nil
 }
 
+// FindMany implements URLStorage.FindMany
+func (s *CombinedStorage) FindMany(ctx context.Context, canonicalURLs []string) (map[string]string, error) {
+	// Try Redis first
+	found, err := s.redis.FindMany(ctx, canonicalURLs)
+	if err != nil {
+		// Non-critical, look everything up in PostgreSQL
+		s.logger.Warn("Error checking Redis for existing URLs", zap.Error(err))
+		found = make(map[string]string, len(canonicalURLs))
+	}
+
+	misses := make([]string, 0, len(canonicalURLs)-len(found))
+	for _, canonicalURL := range canonicalURLs {
+		if _, ok := found[canonicalURL]; !ok {
+			misses = append(misses, canonicalURL)
+		}
+	}
+	if len(misses) == 0 {
+		return found, nil
+	}
+
+	// Look up all misses in one PostgreSQL query
+	fromPostgres, err := s.postgres.FindMany(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	for canonicalURL, shortID := range fromPostgres {
+		found[canonicalURL] = shortID
+	}
+
+	// Update Redis cache
+	if cacheErr := s.redis.cacheReverseMany(ctx, fromPostgres); cacheErr != nil {
+		// Log error but don't fail if Redis fails
+		s.logger.Warn("Failed to update Redis cache", zap.Error(cacheErr))
+	}
+	return found, nil
+}
+
 // StoreWithID implements URLStorage.StoreWithID
-func (s *CombinedStorage) StoreWithID(ctx context.Context, shortID string, originalURL string) error {
-	if originalURL == "" {
+func (s *CombinedStorage) StoreWithID(ctx context.Context, link *models.URL) error {
+	if link.OriginalURL == "" {
 		return ErrInvalidURL
 	}
 
-	// Store in PostgreSQL
-	if err := s.postgres.StoreWithID(ctx, shortID, originalURL); err != nil {
+	// Store in PostgreSQL, which decides whether the short ID is free
+	if err := s.postgres.StoreWithID(ctx, link); err != nil {
 		return err
 	}
 
 	// Try to store in Redis
-	if err := s.redis.StoreWithID(ctx, shortID, originalURL); err != nil {
+	if err := s.redis.cache(ctx, link); err != nil {
 		// Log error but don't fail if Redis fails
 		s.logger.Warn("Failed to store in Redis", zap.Error(err))
 	}
@@ -91,27 +130,319 @@ func (s *CombinedStorage) StoreWithID(ctx context.Context, shortID string, origi
 	return nil
 }
 
+// StoreMany implements URLStorage.StoreMany
+func (s *CombinedStorage) StoreMany(ctx context.Context, links []*models.URL) ([]string, error) {
+	// Store in PostgreSQL, which decides which links are new
+	stored, err := s.postgres.StoreMany(ctx, links)
+	if err != nil {
+		return nil, err
+	}
+
+	isStored := make(map[string]bool, len(stored))
+	for _, shortID := range stored {
+		isStored[shortID] = true
+	}
+	cached := make([]*models.URL, 0, len(stored))
+	for _, link := range links {
+		if isStored[link.ShortID] {
+			cached = append(cached, &models.URL{ShortID: link.ShortID, OriginalURL: link.OriginalURL, CanonicalURL: link.CanonicalURL, Dedup: true})
+		}
+	}
+
+	// Try to store in Redis with a single pipeline
+	if err := s.redis.cacheMany(ctx, cached); err != nil {
+		// Log error but don't fail if Redis fails
+		s.logger.Warn("Failed to store batch in Redis", zap.Error(err))
+	}
+	return stored, nil
+}
+
 // Get implements URLStorage.Get
-func (s *CombinedStorage) Get(ctx context.Context, shortID string) (string, error) {
+func (s *CombinedStorage) Get(ctx context.Context, shortID string) (*models.URL, error) {
 	// Try cache first
-	url, err := s.redis.Get(ctx, shortID)
+	link, err := s.redis.getCached(ctx, shortID)
 	if err == nil {
-		return url, nil
+		return link, nil
 	}
 
 	// Not found in Redis or Redis error, try PostgreSQL
-	url, err = s.postgres.Get(ctx, shortID)
+	link, err = s.postgres.Get(ctx, shortID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Found in PostgreSQL, update Redis cache
-	if cacheErr := s.redis.StoreWithID(ctx, shortID, url); cacheErr != nil {
+	// Found in PostgreSQL, update Redis cache; the TTL is capped at the link's expiry
+	if cacheErr := s.redis.cache(ctx, link); cacheErr != nil {
 		// Log error but don't fail if Redis fails
 		s.logger.Warn("Failed to update Redis cache", zap.Error(cacheErr))
 	}
 
-	return url, nil
+	return link, nil
+}
+
+// GetInfo implements URLStorage.GetInfo
+// Cached copies carry stale access stats, so this always reads PostgreSQL
+func (s *CombinedStorage) GetInfo(ctx context.Context, shortID string) (*models.URL, error) {
+	return s.postgres.GetInfo(ctx, shortID)
+}
+
+// RecordAccess implements URLStorage.RecordAccess
+// Accesses are counted in PostgreSQL whether the link was read from the cache or not
+func (s *CombinedStorage) RecordAccess(ctx context.Context, clicks map[string]int64) error {
+	return s.postgres.RecordAccess(ctx, clicks)
+}
+
+// GetMany implements URLStorage.GetMany
+func (s *CombinedStorage) GetMany(ctx context.Context, shortIDs []string) (map[string]*models.URL, error) {
+	// Try cache first
+	found, err := s.redis.getManyCached(ctx, shortIDs)
+	if err != nil {
+		// Non-critical, fetch everything from PostgreSQL
+		s.logger.Warn("Error getting URLs from Redis", zap.Error(err))
+		found = make(map[string]*models.URL, len(shortIDs))
+	}
+
+	misses := make([]string, 0, len(shortIDs)-len(found))
+	for _, shortID := range shortIDs {
+		if _, ok := found[shortID]; !ok {
+			misses = append(misses, shortID)
+		}
+	}
+	if len(misses) == 0 {
+		return found, nil
+	}
+
+	// Fetch all misses in one PostgreSQL query
+	fromPostgres, err := s.postgres.GetMany(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	backfill := make([]*models.URL, 0, len(fromPostgres))
+	for shortID, link := range fromPostgres {
+		found[shortID] = link
+		backfill = append(backfill, link)
+	}
+	if len(backfill) == 0 {
+		return found, nil
+	}
+
+	// Backfill Redis in one pipeline; TTLs are capped at each link's expiry
+	if cacheErr := s.redis.cacheMany(ctx, backfill); cacheErr != nil {
+		// Log error but don't fail if Redis fails
+		s.logger.Warn("Failed to update Redis cache", zap.Error(cacheErr))
+	}
+	return found, nil
+}
+
+// ConsumeClick implements URLStorage.ConsumeClick
+// The countdown is always taken in PostgreSQL; a cached copy of the link only
+// tells the caller that the link is limited, never how many clicks are left
+func (s *CombinedStorage) ConsumeClick(ctx context.Context, shortID string) (int64, error) {
+	return s.postgres.ConsumeClick(ctx, shortID)
+}
+
+// List implements URLStorage.List
+// Listing always reads PostgreSQL, which holds every link
+func (s *CombinedStorage) List(ctx context.Context, opts ListOptions) ([]*models.URL, error) {
+	return s.postgres.List(ctx, opts)
+}
+
+// StoreClicks implements ClickStorage.StoreClicks
+// Clicks are only kept in PostgreSQL
+func (s *CombinedStorage) StoreClicks(ctx context.Context, clicks []*models.Click) error {
+	return s.postgres.StoreClicks(ctx, clicks)
+}
+
+// GetAPIKey implements APIKeyStorage.GetAPIKey
+// Keys are only kept in PostgreSQL
+func (s *CombinedStorage) GetAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	return s.postgres.GetAPIKey(ctx, keyHash)
+}
+
+// ReserveLinks implements UsageStorage.ReserveLinks
+// Usage is only kept in PostgreSQL
+func (s *CombinedStorage) ReserveLinks(ctx context.Context, day time.Time, n int64, quota models.Quota) (*models.Usage, error) {
+	return s.postgres.ReserveLinks(ctx, day, n, quota)
+}
+
+// ReleaseLinks implements UsageStorage.ReleaseLinks
+func (s *CombinedStorage) ReleaseLinks(ctx context.Context, day time.Time, n int64) error {
+	return s.postgres.ReleaseLinks(ctx, day, n)
+}
+
+// GetUsage implements UsageStorage.GetUsage
+func (s *CombinedStorage) GetUsage(ctx context.Context, day time.Time) (*models.Usage, error) {
+	return s.postgres.GetUsage(ctx, day)
+}
+
+// Delete implements URLStorage.Delete
+func (s *CombinedStorage) Delete(ctx context.Context, shortID string) error {
+	link, err := s.postgres.deleteLink(ctx, shortID)
+	if err != nil {
+		return err
+	}
+
+	// Evict both the forward and the reverse entry so the link stops resolving immediately
+	if err := s.redis.evict(ctx, link); err != nil {
+		s.logger.Error("Failed to evict deleted URL from Redis", zap.Error(err), zap.String("shortID", shortID))
+		return err
+	}
+	return nil
+}
+
+// SetDisabled implements URLStorage.SetDisabled
+func (s *CombinedStorage) SetDisabled(ctx context.Context, shortID string, disabled bool) error {
+	link, err := s.postgres.setDisabled(ctx, shortID, disabled)
+	if err != nil {
+		return err
+	}
+
+	// The next Get caches the updated record again
+	if err := s.redis.evict(ctx, link); err != nil {
+		s.logger.Error("Failed to evict URL from Redis", zap.Error(err), zap.String("shortID", shortID))
+		return err
+	}
+	return nil
+}
+
+// AddTags implements TagStorage.AddTags
+// Tags are only kept in PostgreSQL
+func (s *CombinedStorage) AddTags(ctx context.Context, shortID string, tags []string) error {
+	return s.postgres.AddTags(ctx, shortID, tags)
+}
+
+// RemoveTags implements TagStorage.RemoveTags
+func (s *CombinedStorage) RemoveTags(ctx context.Context, shortID string, tags []string) error {
+	return s.postgres.RemoveTags(ctx, shortID, tags)
+}
+
+// GetTags implements TagStorage.GetTags
+func (s *CombinedStorage) GetTags(ctx context.Context, shortIDs []string) (map[string][]string, error) {
+	return s.postgres.GetTags(ctx, shortIDs)
+}
+
+// ListByTag implements TagStorage.ListByTag
+func (s *CombinedStorage) ListByTag(ctx context.Context, tag string, afterShortID string, limit int) ([]*models.URL, error) {
+	return s.postgres.ListByTag(ctx, tag, afterShortID, limit)
+}
+
+// CreateCampaign implements CampaignStorage.CreateCampaign
+// Campaigns are kept in PostgreSQL and cached in Redis as links resolve
+func (s *CombinedStorage) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	return s.postgres.CreateCampaign(ctx, campaign)
+}
+
+// GetCampaign implements CampaignStorage.GetCampaign
+func (s *CombinedStorage) GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	campaign, err := s.redis.GetCampaign(ctx, campaignID)
+	if err == nil {
+		return campaign, nil
+	}
+
+	campaign, err = s.postgres.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if cacheErr := s.redis.cacheCampaign(ctx, campaign); cacheErr != nil {
+		s.logger.Warn("Failed to update Redis cache", zap.Error(cacheErr))
+	}
+	return campaign, nil
+}
+
+// UpdateCampaign implements CampaignStorage.UpdateCampaign
+func (s *CombinedStorage) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	if err := s.postgres.UpdateCampaign(ctx, campaign); err != nil {
+		return err
+	}
+
+	// Links pick up the new parameters as soon as the stale copy is gone
+	if err := s.redis.evictCampaign(ctx, campaign.ID); err != nil {
+		s.logger.Error("Failed to evict updated campaign from Redis", zap.Error(err), zap.String("campaignID", campaign.ID))
+		return err
+	}
+	return nil
+}
+
+// SetCampaign implements CampaignStorage.SetCampaign
+func (s *CombinedStorage) SetCampaign(ctx context.Context, shortID string, campaignID string) error {
+	link, err := s.postgres.setCampaign(ctx, shortID, campaignID)
+	if err != nil {
+		return err
+	}
+
+	// The next Get caches the updated record again
+	if err := s.redis.evict(ctx, link); err != nil {
+		s.logger.Error("Failed to evict URL from Redis", zap.Error(err), zap.String("shortID", shortID))
+		return err
+	}
+	return nil
+}
+
+// SetTargeting implements TargetingStorage.SetTargeting
+// Rules are cached in Redis with the link they belong to
+func (s *CombinedStorage) SetTargeting(ctx context.Context, shortID string, rules []models.TargetingRule) error {
+	link, err := s.postgres.setTargeting(ctx, shortID, rules)
+	if err != nil {
+		return err
+	}
+
+	// The next Get caches the updated record again
+	if err := s.redis.evict(ctx, link); err != nil {
+		s.logger.Error("Failed to evict URL from Redis", zap.Error(err), zap.String("shortID", shortID))
+		return err
+	}
+	return nil
+}
+
+// SetVariants implements TargetingStorage.SetVariants
+// Variants are cached in Redis with the link they belong to
+func (s *CombinedStorage) SetVariants(ctx context.Context, shortID string, variants []models.Variant) error {
+	link, err := s.postgres.setVariants(ctx, shortID, variants)
+	if err != nil {
+		return err
+	}
+
+	// The next Get caches the updated record again
+	if err := s.redis.evict(ctx, link); err != nil {
+		s.logger.Error("Failed to evict URL from Redis", zap.Error(err), zap.String("shortID", shortID))
+		return err
+	}
+	return nil
+}
+
+// ScanURLs implements QuarantineStorage.ScanURLs
+func (s *CombinedStorage) ScanURLs(ctx context.Context, afterTenantID string, afterShortID string, limit int) ([]*ScannedURL, error) {
+	return s.postgres.ScanURLs(ctx, afterTenantID, afterShortID, limit)
+}
+
+// Quarantine implements QuarantineStorage.Quarantine
+func (s *CombinedStorage) Quarantine(ctx context.Context, shortID string, reason string) error {
+	link, err := s.postgres.quarantine(ctx, shortID, reason)
+	if err != nil {
+		return err
+	}
+
+	// The next Get caches the quarantined record
+	if err := s.redis.evict(ctx, link); err != nil {
+		s.logger.Error("Failed to evict quarantined URL from Redis", zap.Error(err), zap.String("shortID", shortID))
+		return err
+	}
+	return nil
+}
+
+// Update implements URLStorage.Update
+func (s *CombinedStorage) Update(ctx context.Context, shortID string, originalURL string, canonicalURL string) error {
+	old, err := s.postgres.update(ctx, shortID, originalURL, canonicalURL)
+	if err != nil {
+		return err
+	}
+
+	// Evict the stale forward entry and the reverse entry of the old destination
+	if err := s.redis.evict(ctx, old); err != nil {
+		s.logger.Error("Failed to evict updated URL from Redis", zap.Error(err), zap.String("shortID", shortID))
+		return err
+	}
+	return nil
 }
 
 // Close closes both PostgreSQL and Redis connections
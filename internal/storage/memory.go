@@ -2,17 +2,52 @@ package storage
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"github.com/hohotang/shortlink-core/internal/utils"
 	"go.uber.org/zap"
 )
 
+// maxMemoryClicks bounds the click events MemoryStorage keeps
+const maxMemoryClicks = 10000
+
 // MemoryStorage implements URLStorage with an in-memory map
+// Links are kept in one namespace per tenant, chosen by the request context
 type MemoryStorage struct {
-	urls        map[string]string // shortID -> originalURL
-	reverseUrls map[string]string // originalURL -> shortID
-	mutex       sync.RWMutex
+	tenants    map[string]*memoryNamespace // tenant ID -> links
+	clicks     []*models.Click             // ring of the latest click events
+	clicksNext int                         // slot the next event overwrites once the ring is full
+	clickLimit int                         // size of the ring
+	mutex      sync.RWMutex
+}
+
+// memoryNamespace holds the links of one tenant
+type memoryNamespace struct {
+	urls        map[string]*models.URL      // shortID -> link
+	reverseUrls map[string]string           // canonicalURL -> shortID of the dedup link
+	tags        map[string]stringSet        // shortID -> tags of the link
+	tagged      map[string]stringSet        // tag -> shortIDs carrying it
+	campaigns   map[string]*models.Campaign // campaignID -> campaign
+	usage       models.Usage                // links created, for quotas
+}
+
+// stringSet is a set of strings
+type stringSet map[string]struct{}
+
+// sorted returns the members of the set in ascending order
+func (set stringSet) sorted() []string {
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
 }
 
 // NewMemoryStorage creates a new MemoryStorage instance
@@ -21,79 +56,642 @@ func NewMemoryStorage() *MemoryStorage {
 	log.Info("Initializing in-memory storage")
 
 	return &MemoryStorage{
-		urls:        make(map[string]string),
-		reverseUrls: make(map[string]string),
+		tenants:    make(map[string]*memoryNamespace),
+		clickLimit: maxMemoryClicks,
 	}
 }
 
+// namespace returns the links of the context's tenant, creating them if needed
+// Callers must hold the write lock
+func (s *MemoryStorage) namespace(ctx context.Context) *memoryNamespace {
+	tenantID := tenant.FromContext(ctx)
+	ns, exists := s.tenants[tenantID]
+	if !exists {
+		ns = &memoryNamespace{
+			urls:        make(map[string]*models.URL),
+			reverseUrls: make(map[string]string),
+			tags:        make(map[string]stringSet),
+			tagged:      make(map[string]stringSet),
+			campaigns:   make(map[string]*models.Campaign),
+		}
+		s.tenants[tenantID] = ns
+	}
+	return ns
+}
+
+// readNamespace returns the links of the context's tenant for reading
+// A tenant without links gets an empty namespace, whose nil maps read as empty
+// Callers must hold at least the read lock
+func (s *MemoryStorage) readNamespace(ctx context.Context) *memoryNamespace {
+	if ns, exists := s.tenants[tenant.FromContext(ctx)]; exists {
+		return ns
+	}
+	return &memoryNamespace{}
+}
+
 // Find implements URLStorage.Find
-func (s *MemoryStorage) Find(ctx context.Context, originalURL string) (string, error) {
-	if originalURL == "" {
+func (s *MemoryStorage) Find(ctx context.Context, canonicalURL string) (string, error) {
+	if canonicalURL == "" {
 		return "", ErrInvalidURL
 	}
 
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	if shortID, exists := s.reverseUrls[originalURL]; exists {
+	if shortID, exists := s.readNamespace(ctx).reverseUrls[canonicalURL]; exists {
 		return shortID, nil
 	}
 	return "", ErrNotFound
 }
 
+// FindMany implements URLStorage.FindMany
+func (s *MemoryStorage) FindMany(ctx context.Context, canonicalURLs []string) (map[string]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	ns := s.readNamespace(ctx)
+	found := make(map[string]string, len(canonicalURLs))
+	for _, canonicalURL := range canonicalURLs {
+		if shortID, exists := ns.reverseUrls[canonicalURL]; exists {
+			found[canonicalURL] = shortID
+		}
+	}
+	return found, nil
+}
+
 // StoreWithID implements URLStorage.StoreWithID
-func (s *MemoryStorage) StoreWithID(ctx context.Context, shortID string, originalURL string) error {
-	if originalURL == "" {
+func (s *MemoryStorage) StoreWithID(ctx context.Context, link *models.URL) error {
+	if link.OriginalURL == "" {
 		return ErrInvalidURL
 	}
 
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	// Check if this URL already has a different short ID
-	if existingShortID, exists := s.reverseUrls[originalURL]; exists && existingShortID != shortID {
-		// We already have a different shortID for this URL, but we'll override it as requested
-		// Remove the old mapping first
-		log := logger.L()
-		log.Info("URL already exists with different short ID, updating",
-			zap.String("existingID", existingShortID),
-			zap.String("newID", shortID),
-			zap.String("url", originalURL))
-		delete(s.urls, existingShortID)
+	log := logger.L()
+	ns := s.namespace(ctx)
+
+	// Check if this shortID is already used
+	if existing, exists := ns.urls[link.ShortID]; exists {
+		log.Debug("Short ID already in use",
+			zap.String("shortID", link.ShortID),
+			zap.String("existingURL", existing.OriginalURL))
+		return ErrAlreadyExists
 	}
 
-	// Check if this shortID is already used for a different URL
-	if existingURL, exists := s.urls[shortID]; exists && existingURL != originalURL {
-		// Remove the old reverse mapping
-		log := logger.L()
-		log.Info("Short ID already used for different URL, updating mapping",
-			zap.String("shortID", shortID),
-			zap.String("oldURL", existingURL),
-			zap.String("newURL", originalURL))
-		delete(s.reverseUrls, existingURL)
+	// Only one dedup link may exist per URL
+	if link.Dedup {
+		if existingShortID, exists := ns.reverseUrls[link.DedupKey()]; exists {
+			log.Debug("URL already has a dedup short ID",
+				zap.String("existingID", existingShortID),
+				zap.String("url", link.OriginalURL))
+			return ErrAlreadyExists
+		}
+		ns.reverseUrls[link.DedupKey()] = link.ShortID
 	}
 
-	// Insert or update both mappings
-	s.urls[shortID] = originalURL
-	s.reverseUrls[originalURL] = shortID
+	stored := *link
+	stored.CreatedAt = time.Now()
+	stored.RemainingClicks = link.MaxClicks
+	ns.urls[link.ShortID] = &stored
 
-	log := logger.L()
 	log.Debug("Stored URL in memory",
-		zap.String("shortID", shortID),
-		zap.String("url", originalURL))
+		zap.String("shortID", link.ShortID),
+		zap.String("url", link.OriginalURL))
 
 	return nil
 }
 
+// StoreMany implements URLStorage.StoreMany
+func (s *MemoryStorage) StoreMany(ctx context.Context, links []*models.URL) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.namespace(ctx)
+	stored := make([]string, 0, len(links))
+	for _, link := range links {
+		if link.OriginalURL == "" {
+			continue
+		}
+		if _, exists := ns.urls[link.ShortID]; exists {
+			continue
+		}
+		if _, exists := ns.reverseUrls[link.DedupKey()]; exists {
+			continue
+		}
+
+		ns.urls[link.ShortID] = &models.URL{
+			ShortID:      link.ShortID,
+			OriginalURL:  link.OriginalURL,
+			CanonicalURL: link.CanonicalURL,
+			Dedup:        true,
+			CreatedAt:    time.Now(),
+		}
+		ns.reverseUrls[link.DedupKey()] = link.ShortID
+		stored = append(stored, link.ShortID)
+	}
+
+	logger.L().Debug("Stored URL batch in memory",
+		zap.Int("requested", len(links)),
+		zap.Int("stored", len(stored)))
+	return stored, nil
+}
+
 // Get implements URLStorage.Get
-func (s *MemoryStorage) Get(ctx context.Context, shortID string) (string, error) {
+func (s *MemoryStorage) Get(ctx context.Context, shortID string) (*models.URL, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	if url, exists := s.urls[shortID]; exists {
-		return url, nil
+	if link, exists := s.readNamespace(ctx).urls[shortID]; exists {
+		found := *link
+		return &found, nil
+	}
+	return nil, ErrNotFound
+}
+
+// GetInfo implements URLStorage.GetInfo
+func (s *MemoryStorage) GetInfo(ctx context.Context, shortID string) (*models.URL, error) {
+	return s.Get(ctx, shortID)
+}
+
+// GetMany implements URLStorage.GetMany
+func (s *MemoryStorage) GetMany(ctx context.Context, shortIDs []string) (map[string]*models.URL, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	ns := s.readNamespace(ctx)
+	found := make(map[string]*models.URL, len(shortIDs))
+	for _, shortID := range shortIDs {
+		if link, exists := ns.urls[shortID]; exists {
+			copied := *link
+			found[shortID] = &copied
+		}
+	}
+	return found, nil
+}
+
+// RecordAccess implements URLStorage.RecordAccess
+func (s *MemoryStorage) RecordAccess(ctx context.Context, clicks map[string]int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	now := time.Now()
+	for shortID, n := range clicks {
+		if link, exists := ns.urls[shortID]; exists {
+			link.LastAccessed = &now
+			link.ClickCount += n
+		}
+	}
+	return nil
+}
+
+// List implements URLStorage.List
+func (s *MemoryStorage) List(ctx context.Context, opts ListOptions) ([]*models.URL, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	domain := strings.ToLower(opts.Domain)
+	links := make([]*models.URL, 0)
+	for _, link := range s.readNamespace(ctx).urls {
+		if !listedAfter(link, opts.AfterCreatedAt, opts.AfterShortID) {
+			continue
+		}
+		if !inRange(&link.CreatedAt, opts.CreatedFrom, opts.CreatedTo) ||
+			!inRange(link.LastAccessed, opts.AccessedFrom, opts.AccessedTo) {
+			continue
+		}
+		if domain != "" && utils.Hostname(link.OriginalURL) != domain {
+			continue
+		}
+		copied := *link
+		links = append(links, &copied)
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		return listedAfter(links[j], links[i].CreatedAt, links[i].ShortID)
+	})
+	if len(links) > opts.Limit {
+		links = links[:opts.Limit]
+	}
+	return links, nil
+}
+
+// listedAfter reports whether a link comes after the given position in List order
+func listedAfter(link *models.URL, createdAt time.Time, shortID string) bool {
+	if !link.CreatedAt.Equal(createdAt) {
+		return link.CreatedAt.After(createdAt)
+	}
+	return link.ShortID > shortID
+}
+
+// inRange reports whether t lies within [from, to); nil bounds are ignored,
+// and a nil t never matches a bound
+func inRange(t *time.Time, from *time.Time, to *time.Time) bool {
+	if from == nil && to == nil {
+		return true
+	}
+	if t == nil {
+		return false
+	}
+	return (from == nil || !t.Before(*from)) && (to == nil || t.Before(*to))
+}
+
+// ScanURLs implements QuarantineStorage.ScanURLs
+func (s *MemoryStorage) ScanURLs(ctx context.Context, afterTenantID string, afterShortID string, limit int) ([]*ScannedURL, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	scanned := make([]*ScannedURL, 0)
+	for tenantID, ns := range s.tenants {
+		if tenantID < afterTenantID {
+			continue
+		}
+		for shortID, link := range ns.urls {
+			if tenantID == afterTenantID && shortID <= afterShortID {
+				continue
+			}
+			copied := *link
+			scanned = append(scanned, &ScannedURL{TenantID: tenantID, Link: &copied})
+		}
+	}
+
+	sort.Slice(scanned, func(i, j int) bool {
+		if scanned[i].TenantID != scanned[j].TenantID {
+			return scanned[i].TenantID < scanned[j].TenantID
+		}
+		return scanned[i].Link.ShortID < scanned[j].Link.ShortID
+	})
+	if len(scanned) > limit {
+		scanned = scanned[:limit]
+	}
+	return scanned, nil
+}
+
+// Quarantine implements QuarantineStorage.Quarantine
+func (s *MemoryStorage) Quarantine(ctx context.Context, shortID string, reason string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	link, exists := ns.urls[shortID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	ns.unindex(link)
+	link.Dedup = false
+	link.QuarantineReason = reason
+	return nil
+}
+
+// AddTags implements TagStorage.AddTags
+func (s *MemoryStorage) AddTags(ctx context.Context, shortID string, tags []string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	if _, exists := ns.urls[shortID]; !exists {
+		return ErrNotFound
+	}
+
+	for _, tag := range tags {
+		if ns.tags[shortID] == nil {
+			ns.tags[shortID] = make(stringSet)
+		}
+		ns.tags[shortID][tag] = struct{}{}
+		if ns.tagged[tag] == nil {
+			ns.tagged[tag] = make(stringSet)
+		}
+		ns.tagged[tag][shortID] = struct{}{}
+	}
+	return nil
+}
+
+// RemoveTags implements TagStorage.RemoveTags
+func (s *MemoryStorage) RemoveTags(ctx context.Context, shortID string, tags []string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.readNamespace(ctx).untag(shortID, tags)
+	return nil
+}
+
+// untag removes tags from a link and drops sets that become empty
+// Callers must hold the write lock
+func (ns *memoryNamespace) untag(shortID string, tags []string) {
+	for _, tag := range tags {
+		delete(ns.tags[shortID], tag)
+		delete(ns.tagged[tag], shortID)
+		if len(ns.tagged[tag]) == 0 {
+			delete(ns.tagged, tag)
+		}
+	}
+	if len(ns.tags[shortID]) == 0 {
+		delete(ns.tags, shortID)
+	}
+}
+
+// GetTags implements TagStorage.GetTags
+func (s *MemoryStorage) GetTags(ctx context.Context, shortIDs []string) (map[string][]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	ns := s.readNamespace(ctx)
+	found := make(map[string][]string, len(shortIDs))
+	for _, shortID := range shortIDs {
+		if tags, ok := ns.tags[shortID]; ok {
+			found[shortID] = tags.sorted()
+		}
+	}
+	return found, nil
+}
+
+// ListByTag implements TagStorage.ListByTag
+func (s *MemoryStorage) ListByTag(ctx context.Context, tag string, afterShortID string, limit int) ([]*models.URL, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	ns := s.readNamespace(ctx)
+	links := make([]*models.URL, 0)
+	for _, shortID := range ns.tagged[tag].sorted() {
+		if len(links) == limit {
+			break
+		}
+		if shortID <= afterShortID {
+			continue
+		}
+		copied := *ns.urls[shortID]
+		links = append(links, &copied)
+	}
+	return links, nil
+}
+
+// CreateCampaign implements CampaignStorage.CreateCampaign
+func (s *MemoryStorage) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.namespace(ctx)
+	if _, exists := ns.campaigns[campaign.ID]; exists {
+		return ErrAlreadyExists
+	}
+
+	now := time.Now()
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+	ns.campaigns[campaign.ID] = copyCampaign(campaign)
+	return nil
+}
+
+// GetCampaign implements CampaignStorage.GetCampaign
+func (s *MemoryStorage) GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	campaign, exists := s.readNamespace(ctx).campaigns[campaignID]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return copyCampaign(campaign), nil
+}
+
+// UpdateCampaign implements CampaignStorage.UpdateCampaign
+func (s *MemoryStorage) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	existing, exists := ns.campaigns[campaign.ID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	campaign.CreatedAt = existing.CreatedAt
+	campaign.UpdatedAt = time.Now()
+	ns.campaigns[campaign.ID] = copyCampaign(campaign)
+	return nil
+}
+
+// SetCampaign implements CampaignStorage.SetCampaign
+func (s *MemoryStorage) SetCampaign(ctx context.Context, shortID string, campaignID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	link, exists := ns.urls[shortID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// Campaign links add parameters to the destination, so they are not shared
+	if campaignID != "" {
+		ns.unindex(link)
+		link.Dedup = false
+	}
+	link.CampaignID = campaignID
+	return nil
+}
+
+// SetTargeting implements TargetingStorage.SetTargeting
+func (s *MemoryStorage) SetTargeting(ctx context.Context, shortID string, rules []models.TargetingRule) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	link, exists := ns.urls[shortID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// Targeted links send some visitors elsewhere, so they are not shared
+	if len(rules) > 0 {
+		ns.unindex(link)
+		link.Dedup = false
+	}
+	link.Targeting = rules
+	return nil
+}
+
+// SetVariants implements TargetingStorage.SetVariants
+func (s *MemoryStorage) SetVariants(ctx context.Context, shortID string, variants []models.Variant) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	link, exists := ns.urls[shortID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	// Split links send visitors elsewhere, so they are not shared
+	if len(variants) > 0 {
+		ns.unindex(link)
+		link.Dedup = false
+	}
+	link.Variants = variants
+	return nil
+}
+
+// copyCampaign returns a copy of a campaign that shares no maps with it
+func copyCampaign(campaign *models.Campaign) *models.Campaign {
+	copied := *campaign
+	copied.Params = make(map[string]string, len(campaign.Params))
+	for name, value := range campaign.Params {
+		copied.Params[name] = value
+	}
+	return &copied
+}
+
+// StoreClicks implements ClickStorage.StoreClicks
+// Only the latest clickLimit events are kept; older ones are overwritten
+func (s *MemoryStorage) StoreClicks(ctx context.Context, clicks []*models.Click) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, click := range clicks {
+		stored := *click
+		if len(s.clicks) < s.clickLimit {
+			s.clicks = append(s.clicks, &stored)
+			continue
+		}
+		s.clicks[s.clicksNext] = &stored
+		s.clicksNext = (s.clicksNext + 1) % s.clickLimit
+	}
+	return nil
+}
+
+// Clicks returns a copy of the click events kept, oldest first
+func (s *MemoryStorage) Clicks() []*models.Click {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	clicks := make([]*models.Click, 0, len(s.clicks))
+	for i := range s.clicks {
+		copied := *s.clicks[(s.clicksNext+i)%len(s.clicks)]
+		clicks = append(clicks, &copied)
+	}
+	return clicks
+}
+
+// ConsumeClick implements URLStorage.ConsumeClick
+func (s *MemoryStorage) ConsumeClick(ctx context.Context, shortID string) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	link, exists := s.readNamespace(ctx).urls[shortID]
+	if !exists || link.RemainingClicks <= 0 {
+		return 0, ErrClicksExhausted
+	}
+
+	link.RemainingClicks--
+	return link.RemainingClicks, nil
+}
+
+// ReserveLinks implements UsageStorage.ReserveLinks
+func (s *MemoryStorage) ReserveLinks(ctx context.Context, day time.Time, n int64, quota models.Quota) (*models.Usage, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.namespace(ctx)
+	usage := usageOn(ns.usage, day)
+	if quotaExceeded(usage, n, quota) {
+		return nil, ErrQuotaExceeded
+	}
+
+	usage.DailyLinks += n
+	usage.TotalLinks += n
+	ns.usage = usage
+	return &usage, nil
+}
+
+// ReleaseLinks implements UsageStorage.ReleaseLinks
+func (s *MemoryStorage) ReleaseLinks(ctx context.Context, day time.Time, n int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.namespace(ctx)
+	if ns.usage.Day.Equal(day) {
+		ns.usage.DailyLinks = max(ns.usage.DailyLinks-n, 0)
+	}
+	ns.usage.TotalLinks = max(ns.usage.TotalLinks-n, 0)
+	return nil
+}
+
+// GetUsage implements UsageStorage.GetUsage
+func (s *MemoryStorage) GetUsage(ctx context.Context, day time.Time) (*models.Usage, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	usage := usageOn(s.readNamespace(ctx).usage, day)
+	return &usage, nil
+}
+
+// Delete implements URLStorage.Delete
+func (s *MemoryStorage) Delete(ctx context.Context, shortID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	link, exists := ns.urls[shortID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	ns.unindex(link)
+	ns.untag(shortID, ns.tags[shortID].sorted())
+	delete(ns.urls, shortID)
+	return nil
+}
+
+// SetDisabled implements URLStorage.SetDisabled
+func (s *MemoryStorage) SetDisabled(ctx context.Context, shortID string, disabled bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	link, exists := ns.urls[shortID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	if disabled {
+		ns.unindex(link)
+		link.Dedup = false
+	}
+	link.Disabled = disabled
+	return nil
+}
+
+// Update implements URLStorage.Update
+func (s *MemoryStorage) Update(ctx context.Context, shortID string, originalURL string, canonicalURL string) error {
+	if originalURL == "" {
+		return ErrInvalidURL
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ns := s.readNamespace(ctx)
+	link, exists := ns.urls[shortID]
+	if !exists {
+		return ErrNotFound
+	}
+
+	ns.unindex(link)
+	link.OriginalURL = originalURL
+	link.CanonicalURL = canonicalURL
+	link.Dedup = false
+	link.QuarantineReason = ""
+	return nil
+}
+
+// unindex removes the reverse mapping of a link if it points to the link
+// Callers must hold the write lock
+func (ns *memoryNamespace) unindex(link *models.URL) {
+	if ns.reverseUrls[link.DedupKey()] == link.ShortID {
+		delete(ns.reverseUrls, link.DedupKey())
 	}
-	return "", ErrNotFound
 }
 
 // Close is a no-op for memory storage
@@ -0,0 +1,275 @@
+package storage
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+)
+
+func TestMemoryStorage_StoreWithID(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStorage()
+
+	if err := s.StoreWithID(ctx, &models.URL{ShortID: "abc", OriginalURL: "https://example.com", Dedup: true}); err != nil {
+		t.Fatalf("StoreWithID returned unexpected error: %v", err)
+	}
+
+	// Taken short ID is rejected even for a different URL
+	err := s.StoreWithID(ctx, &models.URL{ShortID: "abc", OriginalURL: "https://other.example.com"})
+	if err != ErrAlreadyExists {
+		t.Errorf("Expected ErrAlreadyExists for taken short ID, got %v", err)
+	}
+
+	// A second dedup link for the same URL is rejected
+	err = s.StoreWithID(ctx, &models.URL{ShortID: "def", OriginalURL: "https://example.com", Dedup: true})
+	if err != ErrAlreadyExists {
+		t.Errorf("Expected ErrAlreadyExists for second dedup link, got %v", err)
+	}
+
+	// An alias for the same URL is fine and does not replace the dedup link
+	if err := s.StoreWithID(ctx, &models.URL{ShortID: "spring-sale", OriginalURL: "https://example.com"}); err != nil {
+		t.Fatalf("StoreWithID for alias returned unexpected error: %v", err)
+	}
+
+	shortID, err := s.Find(ctx, "https://example.com")
+	if err != nil || shortID != "abc" {
+		t.Errorf("Find() = %q, %v; expected abc", shortID, err)
+	}
+
+	link, err := s.Get(ctx, "spring-sale")
+	if err != nil || link.OriginalURL != "https://example.com" {
+		t.Errorf("Get(spring-sale) = %v, %v; expected https://example.com", link, err)
+	}
+}
+
+func TestMemoryStorage_ConsumeClick(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStorage()
+
+	if err := s.StoreWithID(ctx, &models.URL{ShortID: "abc", OriginalURL: "https://example.com", MaxClicks: 5}); err != nil {
+		t.Fatalf("StoreWithID returned unexpected error: %v", err)
+	}
+
+	// Concurrent callers never take more clicks than the limit
+	var wg sync.WaitGroup
+	var consumed atomic.Int64
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.ConsumeClick(ctx, "abc"); err == nil {
+				consumed.Add(1)
+			} else if err != ErrClicksExhausted {
+				t.Errorf("Expected ErrClicksExhausted, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if consumed.Load() != 5 {
+		t.Errorf("Expected 5 clicks consumed, got %d", consumed.Load())
+	}
+
+	link, err := s.GetInfo(ctx, "abc")
+	if err != nil {
+		t.Fatalf("GetInfo returned unexpected error: %v", err)
+	}
+	if link.MaxClicks != 5 || link.RemainingClicks != 0 {
+		t.Errorf("Expected 0 of 5 clicks left, got %d of %d", link.RemainingClicks, link.MaxClicks)
+	}
+}
+
+func TestMemoryStorage_RecordAccess(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStorage()
+
+	if err := s.StoreWithID(ctx, &models.URL{ShortID: "abc", OriginalURL: "https://example.com"}); err != nil {
+		t.Fatalf("StoreWithID returned unexpected error: %v", err)
+	}
+
+	// Reading a link is not an access
+	if _, err := s.Get(ctx, "abc"); err != nil {
+		t.Fatalf("Get returned unexpected error: %v", err)
+	}
+	if _, err := s.GetMany(ctx, []string{"abc"}); err != nil {
+		t.Fatalf("GetMany returned unexpected error: %v", err)
+	}
+
+	// Counts add up; unknown short IDs are ignored
+	if err := s.RecordAccess(ctx, map[string]int64{"abc": 1, "missing": 3}); err != nil {
+		t.Fatalf("RecordAccess returned unexpected error: %v", err)
+	}
+	if err := s.RecordAccess(ctx, map[string]int64{"abc": 1}); err != nil {
+		t.Fatalf("RecordAccess returned unexpected error: %v", err)
+	}
+
+	link, err := s.GetInfo(ctx, "abc")
+	if err != nil {
+		t.Fatalf("GetInfo returned unexpected error: %v", err)
+	}
+	if link.ClickCount != 2 || link.LastAccessed == nil {
+		t.Errorf("Expected 2 clicks and a last access time, got %d and %v", link.ClickCount, link.LastAccessed)
+	}
+}
+
+func TestMemoryStorage_ClicksBounded(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStorage()
+	s.clickLimit = 3
+
+	for i := 0; i < 5; i++ {
+		if err := s.StoreClicks(ctx, []*models.Click{{ShortID: strconv.Itoa(i)}}); err != nil {
+			t.Fatalf("StoreClicks returned unexpected error: %v", err)
+		}
+	}
+
+	// Only the latest events are kept, oldest first
+	clicks := s.Clicks()
+	got := make([]string, len(clicks))
+	for i, click := range clicks {
+		got[i] = click.ShortID
+	}
+	if strings.Join(got, ",") != "2,3,4" {
+		t.Errorf("Expected clicks 2,3,4, got %v", got)
+	}
+}
+
+func TestMemoryStorage_TenantIsolation(t *testing.T) {
+	acme := tenant.WithContext(context.Background(), "acme")
+	globex := tenant.WithContext(context.Background(), "globex")
+	s := NewMemoryStorage()
+
+	// The same short ID and dedup URL may exist once per tenant
+	if err := s.StoreWithID(acme, &models.URL{ShortID: "abc", OriginalURL: "https://example.com", Dedup: true}); err != nil {
+		t.Fatalf("StoreWithID returned unexpected error: %v", err)
+	}
+	if err := s.StoreWithID(globex, &models.URL{ShortID: "abc", OriginalURL: "https://other.example.com", Dedup: true}); err != nil {
+		t.Fatalf("StoreWithID in another tenant returned unexpected error: %v", err)
+	}
+
+	link, err := s.Get(acme, "abc")
+	if err != nil || link.OriginalURL != "https://example.com" {
+		t.Errorf("Get(acme, abc) = %v, %v; expected https://example.com", link, err)
+	}
+
+	if _, err := s.Find(globex, "https://example.com"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound for another tenant's dedup link, got %v", err)
+	}
+	if _, err := s.Get(context.Background(), "abc"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound in the default tenant, got %v", err)
+	}
+
+	if err := s.Delete(globex, "abc"); err != nil {
+		t.Fatalf("Delete returned unexpected error: %v", err)
+	}
+	if _, err := s.Get(acme, "abc"); err != nil {
+		t.Errorf("Deleting in one tenant removed another tenant's link: %v", err)
+	}
+}
+
+func TestMemoryStorage_Tags(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStorage()
+
+	for _, shortID := range []string{"c", "a", "b"} {
+		if err := s.StoreWithID(ctx, &models.URL{ShortID: shortID, OriginalURL: "https://example.com/" + shortID}); err != nil {
+			t.Fatalf("StoreWithID returned unexpected error: %v", err)
+		}
+		if err := s.AddTags(ctx, shortID, []string{"sale"}); err != nil {
+			t.Fatalf("AddTags returned unexpected error: %v", err)
+		}
+	}
+	if err := s.AddTags(ctx, "a", []string{"team/growth"}); err != nil {
+		t.Fatalf("AddTags returned unexpected error: %v", err)
+	}
+	if err := s.AddTags(ctx, "missing", []string{"sale"}); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound tagging a missing link, got %v", err)
+	}
+
+	tags, err := s.GetTags(ctx, []string{"a", "b", "missing"})
+	if err != nil {
+		t.Fatalf("GetTags returned unexpected error: %v", err)
+	}
+	if got := tags["a"]; len(got) != 2 || got[0] != "sale" || got[1] != "team/growth" {
+		t.Errorf("Unexpected tags for a: %v", got)
+	}
+	if _, ok := tags["missing"]; ok {
+		t.Errorf("Expected no tags for a missing link")
+	}
+
+	// Pages are ordered by short ID and start after the cursor
+	page, err := s.ListByTag(ctx, "sale", "", 2)
+	if err != nil {
+		t.Fatalf("ListByTag returned unexpected error: %v", err)
+	}
+	if len(page) != 2 || page[0].ShortID != "a" || page[1].ShortID != "b" {
+		t.Fatalf("Unexpected first page: %v", page)
+	}
+	page, err = s.ListByTag(ctx, "sale", "b", 2)
+	if err != nil {
+		t.Fatalf("ListByTag returned unexpected error: %v", err)
+	}
+	if len(page) != 1 || page[0].ShortID != "c" {
+		t.Errorf("Unexpected second page: %v", page)
+	}
+
+	if err := s.RemoveTags(ctx, "b", []string{"sale"}); err != nil {
+		t.Fatalf("RemoveTags returned unexpected error: %v", err)
+	}
+	if err := s.Delete(ctx, "c"); err != nil {
+		t.Fatalf("Delete returned unexpected error: %v", err)
+	}
+	page, err = s.ListByTag(ctx, "sale", "", 10)
+	if err != nil {
+		t.Fatalf("ListByTag returned unexpected error: %v", err)
+	}
+	if len(page) != 1 || page[0].ShortID != "a" {
+		t.Errorf("Expected only a after removing and deleting, got %v", page)
+	}
+}
+
+func TestMemoryStorage_Campaigns(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStorage()
+
+	campaign := &models.Campaign{ID: "q3", Params: map[string]string{"utm_source": "ads"}}
+	if err := s.CreateCampaign(ctx, campaign); err != nil {
+		t.Fatalf("CreateCampaign returned unexpected error: %v", err)
+	}
+	if err := s.CreateCampaign(ctx, campaign); err != ErrAlreadyExists {
+		t.Errorf("Expected ErrAlreadyExists creating a campaign twice, got %v", err)
+	}
+	if err := s.UpdateCampaign(ctx, &models.Campaign{ID: "missing"}); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound updating a missing campaign, got %v", err)
+	}
+
+	// Stored campaigns do not share maps with the caller
+	campaign.Params["utm_source"] = "changed"
+	stored, err := s.GetCampaign(ctx, "q3")
+	if err != nil {
+		t.Fatalf("GetCampaign returned unexpected error: %v", err)
+	}
+	if stored.Params["utm_source"] != "ads" {
+		t.Errorf("Expected utm_source ads, got %q", stored.Params["utm_source"])
+	}
+
+	link := &models.URL{ShortID: "a", OriginalURL: "https://example.com/", CanonicalURL: "https://example.com/", Dedup: true}
+	if err := s.StoreWithID(ctx, link); err != nil {
+		t.Fatalf("StoreWithID returned unexpected error: %v", err)
+	}
+	if err := s.SetCampaign(ctx, "a", "q3"); err != nil {
+		t.Fatalf("SetCampaign returned unexpected error: %v", err)
+	}
+	if _, err := s.Find(ctx, "https://example.com/"); err != ErrNotFound {
+		t.Errorf("Expected a campaign link to leave dedup, got %v", err)
+	}
+	if err := s.SetCampaign(ctx, "missing", "q3"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound for a missing link, got %v", err)
+	}
+}
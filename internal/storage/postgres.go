@@ -3,16 +3,29 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hohotang/shortlink-core/internal/config"
 	"github.com/hohotang/shortlink-core/internal/logger"
+	"github.com/hohotang/shortlink-core/internal/models"
 	"github.com/hohotang/shortlink-core/internal/storage/postgres/db"
-	_ "github.com/lib/pq"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
+// PostgreSQL error codes the storage reacts to
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
 // PostgresStorage implements URLStorage with PostgreSQL
+// Every query is scoped to the tenant of the request context
 type PostgresStorage struct {
 	db      *sql.DB
 	queries *db.Queries
@@ -76,18 +89,21 @@ func NewPostgresStorage(cfg *config.Config) (*PostgresStorage, error) {
 	}, nil
 }
 
-// FindShortIDByURL checks if a URL already has a short ID
-func (s *PostgresStorage) FindShortIDByURL(ctx context.Context, originalURL string) (string, error) {
+// FindShortIDByURL checks if a canonical URL already has a short ID
+func (s *PostgresStorage) FindShortIDByURL(ctx context.Context, canonicalURL string) (string, error) {
 	log := logger.L()
 
-	if originalURL == "" {
+	if canonicalURL == "" {
 		return "", ErrInvalidURL
 	}
 
-	shortID, err := s.queries.FindShortIDByURL(ctx, originalURL)
+	shortID, err := s.queries.FindShortIDByURL(ctx, db.FindShortIDByURLParams{
+		TenantID:     tenant.FromContext(ctx),
+		CanonicalUrl: canonicalURL,
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Debug("No existing short ID found for URL", zap.String("url", originalURL))
+			log.Debug("No existing short ID found for URL", zap.String("url", canonicalURL))
 			return "", ErrNotFound
 		}
 		log.Error("Failed to query for existing URL", zap.Error(err))
@@ -96,61 +112,736 @@ func (s *PostgresStorage) FindShortIDByURL(ctx context.Context, originalURL stri
 
 	log.Debug("Found existing short ID for URL",
 		zap.String("shortID", shortID),
-		zap.String("url", originalURL))
+		zap.String("url", canonicalURL))
 	return shortID, nil
 }
 
-func (s *PostgresStorage) Find(ctx context.Context, originalURL string) (string, error) {
+func (s *PostgresStorage) Find(ctx context.Context, canonicalURL string) (string, error) {
 	// This method simply calls FindShortIDByURL to check if the URL already exists
-	return s.FindShortIDByURL(ctx, originalURL)
+	return s.FindShortIDByURL(ctx, canonicalURL)
+}
+
+// FindMany implements URLStorage.FindMany
+func (s *PostgresStorage) FindMany(ctx context.Context, canonicalURLs []string) (map[string]string, error) {
+	log := logger.L()
+
+	rows, err := s.queries.FindShortIDsByURLs(ctx, db.FindShortIDsByURLsParams{
+		TenantID:      tenant.FromContext(ctx),
+		CanonicalUrls: canonicalURLs,
+	})
+	if err != nil {
+		log.Error("Failed to query for existing URLs", zap.Error(err), zap.Int("count", len(canonicalURLs)))
+		return nil, fmt.Errorf("failed to query for existing URLs: %w", err)
+	}
+
+	found := make(map[string]string, len(rows))
+	for _, row := range rows {
+		found[row.CanonicalUrl] = row.ShortID
+	}
+
+	log.Debug("Found existing short IDs for URLs",
+		zap.Int("requested", len(canonicalURLs)),
+		zap.Int("found", len(found)))
+	return found, nil
 }
 
 // StoreWithID implements URLStorage.StoreWithID
-func (s *PostgresStorage) StoreWithID(ctx context.Context, shortID string, originalURL string) error {
+func (s *PostgresStorage) StoreWithID(ctx context.Context, link *models.URL) error {
 	log := logger.L()
 
-	if originalURL == "" {
+	if link.OriginalURL == "" {
 		return ErrInvalidURL
 	}
+	targeting, err := encodeList(link.Targeting)
+	if err != nil {
+		return err
+	}
+	variants, err := encodeList(link.Variants)
+	if err != nil {
+		return err
+	}
 
-	err := s.queries.StoreWithID(ctx, db.StoreWithIDParams{
-		ShortID:     shortID,
-		OriginalUrl: originalURL,
+	err = s.queries.StoreWithID(ctx, db.StoreWithIDParams{
+		TenantID:     tenant.FromContext(ctx),
+		ShortID:      link.ShortID,
+		OriginalUrl:  link.OriginalURL,
+		CanonicalUrl: link.DedupKey(),
+		Dedup:        link.Dedup,
+		ExpiresAt:    toNullTime(link.ExpiresAt),
+		PasswordHash: link.PasswordHash,
+		MaxClicks:    link.MaxClicks,
+		CampaignID:   link.CampaignID,
+		Targeting:    targeting,
+		Variants:     variants,
+		NotBefore:    toNullTime(link.NotBefore),
 	})
 
 	if err != nil {
+		// The primary key and the dedup index reject duplicates atomically
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation {
+			log.Debug("Short ID or dedup URL already exists",
+				zap.String("shortID", link.ShortID),
+				zap.String("constraint", pqErr.Constraint))
+			return ErrAlreadyExists
+		}
 		log.Error("Failed to insert URL",
 			zap.Error(err),
-			zap.String("shortID", shortID),
-			zap.String("url", originalURL))
+			zap.String("shortID", link.ShortID),
+			zap.String("url", link.OriginalURL))
 		return fmt.Errorf("failed to insert URL: %w", err)
 	}
 
 	log.Debug("URL stored successfully",
-		zap.String("shortID", shortID),
-		zap.String("url", originalURL))
+		zap.String("shortID", link.ShortID),
+		zap.String("url", link.OriginalURL))
 	return nil
 }
 
+// StoreMany implements URLStorage.StoreMany
+func (s *PostgresStorage) StoreMany(ctx context.Context, links []*models.URL) ([]string, error) {
+	log := logger.L()
+
+	params := db.StoreManyParams{
+		TenantID:      tenant.FromContext(ctx),
+		ShortIds:      make([]string, len(links)),
+		OriginalUrls:  make([]string, len(links)),
+		CanonicalUrls: make([]string, len(links)),
+	}
+	for i, link := range links {
+		params.ShortIds[i] = link.ShortID
+		params.OriginalUrls[i] = link.OriginalURL
+		params.CanonicalUrls[i] = link.DedupKey()
+	}
+
+	// A single multi-row insert; conflicting rows are skipped, not failed
+	stored, err := s.queries.StoreMany(ctx, params)
+	if err != nil {
+		log.Error("Failed to insert URL batch", zap.Error(err), zap.Int("count", len(links)))
+		return nil, fmt.Errorf("failed to insert URLs: %w", err)
+	}
+
+	log.Debug("URL batch stored",
+		zap.Int("requested", len(links)),
+		zap.Int("stored", len(stored)))
+	return stored, nil
+}
+
 // Get implements URLStorage.Get
-func (s *PostgresStorage) Get(ctx context.Context, shortID string) (string, error) {
+func (s *PostgresStorage) Get(ctx context.Context, shortID string) (*models.URL, error) {
 	log := logger.L()
 
-	originalURL, err := s.queries.GetURL(ctx, shortID)
+	row, err := s.queries.GetURL(ctx, db.GetURLParams{
+		TenantID: tenant.FromContext(ctx),
+		ShortID:  shortID,
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Debug("Short ID not found", zap.String("shortID", shortID))
-			return "", ErrNotFound
+			return nil, ErrNotFound
 		}
 		log.Error("Failed to get URL", zap.Error(err), zap.String("shortID", shortID))
-		return "", fmt.Errorf("failed to get URL: %w", err)
+		return nil, fmt.Errorf("failed to get URL: %w", err)
 	}
 
 	log.Debug("Retrieved URL for short ID",
 		zap.String("shortID", shortID),
-		zap.String("url", originalURL))
+		zap.String("url", row.OriginalUrl))
+
+	return toURLModel(row), nil
+}
+
+// GetInfo implements URLStorage.GetInfo
+// Get never records an access, so it already reads the current stats
+func (s *PostgresStorage) GetInfo(ctx context.Context, shortID string) (*models.URL, error) {
+	return s.Get(ctx, shortID)
+}
+
+// GetMany implements URLStorage.GetMany
+func (s *PostgresStorage) GetMany(ctx context.Context, shortIDs []string) (map[string]*models.URL, error) {
+	log := logger.L()
+
+	rows, err := s.queries.GetURLs(ctx, db.GetURLsParams{
+		TenantID: tenant.FromContext(ctx),
+		ShortIds: shortIDs,
+	})
+	if err != nil {
+		log.Error("Failed to get URLs", zap.Error(err), zap.Int("count", len(shortIDs)))
+		return nil, fmt.Errorf("failed to get URLs: %w", err)
+	}
 
-	return originalURL, nil
+	found := make(map[string]*models.URL, len(rows))
+	for _, row := range rows {
+		found[row.ShortID] = toURLModel(row)
+	}
+
+	log.Debug("Retrieved URLs for short IDs",
+		zap.Int("requested", len(shortIDs)),
+		zap.Int("found", len(found)))
+	return found, nil
+}
+
+// RecordAccess implements URLStorage.RecordAccess
+// All links are updated in one statement
+func (s *PostgresStorage) RecordAccess(ctx context.Context, clicks map[string]int64) error {
+	if len(clicks) == 0 {
+		return nil
+	}
+
+	params := db.RecordAccessParams{
+		ShortIds: make([]string, 0, len(clicks)),
+		Clicks:   make([]int64, 0, len(clicks)),
+		TenantID: tenant.FromContext(ctx),
+	}
+	for shortID, n := range clicks {
+		params.ShortIds = append(params.ShortIds, shortID)
+		params.Clicks = append(params.Clicks, n)
+	}
+	if err := s.queries.RecordAccess(ctx, params); err != nil {
+		logger.L().Error("Failed to record access", zap.Error(err), zap.Int("count", len(clicks)))
+		return fmt.Errorf("failed to record access: %w", err)
+	}
+	return nil
+}
+
+// ConsumeClick implements URLStorage.ConsumeClick
+// The conditional UPDATE decrements and checks the countdown in one statement,
+// so concurrent resolutions can never take more clicks than are left
+func (s *PostgresStorage) ConsumeClick(ctx context.Context, shortID string) (int64, error) {
+	log := logger.L()
+
+	remaining, err := s.queries.ConsumeClick(ctx, db.ConsumeClickParams{
+		TenantID: tenant.FromContext(ctx),
+		ShortID:  shortID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debug("No clicks left", zap.String("shortID", shortID))
+			return 0, ErrClicksExhausted
+		}
+		log.Error("Failed to consume click", zap.Error(err), zap.String("shortID", shortID))
+		return 0, fmt.Errorf("failed to consume click: %w", err)
+	}
+
+	log.Debug("Click consumed",
+		zap.String("shortID", shortID),
+		zap.Int64("remaining", remaining))
+	return remaining, nil
+}
+
+// List implements URLStorage.List
+func (s *PostgresStorage) List(ctx context.Context, opts ListOptions) ([]*models.URL, error) {
+	log := logger.L()
+
+	rows, err := s.queries.ListURLs(ctx, db.ListURLsParams{
+		TenantID:       tenant.FromContext(ctx),
+		AfterCreatedAt: opts.AfterCreatedAt,
+		AfterShortID:   opts.AfterShortID,
+		CreatedFrom:    toNullTime(opts.CreatedFrom),
+		CreatedTo:      toNullTime(opts.CreatedTo),
+		AccessedFrom:   toNullTime(opts.AccessedFrom),
+		AccessedTo:     toNullTime(opts.AccessedTo),
+		Domain:         strings.ToLower(opts.Domain),
+		PageSize:       int32(opts.Limit),
+	})
+	if err != nil {
+		log.Error("Failed to list URLs", zap.Error(err))
+		return nil, fmt.Errorf("failed to list URLs: %w", err)
+	}
+
+	links := make([]*models.URL, len(rows))
+	for i, row := range rows {
+		links[i] = toURLModel(row)
+	}
+
+	log.Debug("Listed URLs", zap.Int("count", len(links)))
+	return links, nil
+}
+
+// ScanURLs implements QuarantineStorage.ScanURLs
+func (s *PostgresStorage) ScanURLs(ctx context.Context, afterTenantID string, afterShortID string, limit int) ([]*ScannedURL, error) {
+	rows, err := s.queries.ScanURLs(ctx, db.ScanURLsParams{
+		AfterTenantID: afterTenantID,
+		AfterShortID:  afterShortID,
+		PageSize:      int32(limit),
+	})
+	if err != nil {
+		logger.L().Error("Failed to scan URLs", zap.Error(err))
+		return nil, fmt.Errorf("failed to scan URLs: %w", err)
+	}
+
+	scanned := make([]*ScannedURL, len(rows))
+	for i, row := range rows {
+		scanned[i] = &ScannedURL{TenantID: row.TenantID, Link: toURLModel(row)}
+	}
+	return scanned, nil
+}
+
+// Quarantine implements QuarantineStorage.Quarantine
+func (s *PostgresStorage) Quarantine(ctx context.Context, shortID string, reason string) error {
+	_, err := s.quarantine(ctx, shortID, reason)
+	return err
+}
+
+// quarantine flags a link and returns the updated record
+func (s *PostgresStorage) quarantine(ctx context.Context, shortID string, reason string) (*models.URL, error) {
+	log := logger.L()
+
+	row, err := s.queries.QuarantineURL(ctx, db.QuarantineURLParams{
+		TenantID:         tenant.FromContext(ctx),
+		ShortID:          shortID,
+		QuarantineReason: reason,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debug("Short ID not found", zap.String("shortID", shortID))
+			return nil, ErrNotFound
+		}
+		log.Error("Failed to quarantine URL", zap.Error(err), zap.String("shortID", shortID))
+		return nil, fmt.Errorf("failed to quarantine URL: %w", err)
+	}
+
+	log.Info("URL quarantined",
+		zap.String("shortID", shortID),
+		zap.String("reason", reason))
+	return toURLModel(row), nil
+}
+
+// AddTags implements TagStorage.AddTags
+func (s *PostgresStorage) AddTags(ctx context.Context, shortID string, tags []string) error {
+	err := s.queries.AddTags(ctx, db.AddTagsParams{
+		TenantID: tenant.FromContext(ctx),
+		ShortID:  shortID,
+		Tags:     tags,
+	})
+	if err != nil {
+		// The foreign key on url_tags rejects tags on missing links
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+			return ErrNotFound
+		}
+		logger.L().Error("Failed to add tags", zap.Error(err), zap.String("shortID", shortID))
+		return fmt.Errorf("failed to add tags: %w", err)
+	}
+	return nil
+}
+
+// RemoveTags implements TagStorage.RemoveTags
+func (s *PostgresStorage) RemoveTags(ctx context.Context, shortID string, tags []string) error {
+	err := s.queries.RemoveTags(ctx, db.RemoveTagsParams{
+		TenantID: tenant.FromContext(ctx),
+		ShortID:  shortID,
+		Tags:     tags,
+	})
+	if err != nil {
+		logger.L().Error("Failed to remove tags", zap.Error(err), zap.String("shortID", shortID))
+		return fmt.Errorf("failed to remove tags: %w", err)
+	}
+	return nil
+}
+
+// GetTags implements TagStorage.GetTags
+func (s *PostgresStorage) GetTags(ctx context.Context, shortIDs []string) (map[string][]string, error) {
+	rows, err := s.queries.GetTags(ctx, db.GetTagsParams{
+		TenantID: tenant.FromContext(ctx),
+		ShortIds: shortIDs,
+	})
+	if err != nil {
+		logger.L().Error("Failed to get tags", zap.Error(err), zap.Int("count", len(shortIDs)))
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	found := make(map[string][]string)
+	for _, row := range rows {
+		found[row.ShortID] = append(found[row.ShortID], row.Tag)
+	}
+	return found, nil
+}
+
+// ListByTag implements TagStorage.ListByTag
+func (s *PostgresStorage) ListByTag(ctx context.Context, tag string, afterShortID string, limit int) ([]*models.URL, error) {
+	rows, err := s.queries.ListURLsByTag(ctx, db.ListURLsByTagParams{
+		TenantID:     tenant.FromContext(ctx),
+		Tag:          tag,
+		AfterShortID: afterShortID,
+		PageSize:     int32(limit),
+	})
+	if err != nil {
+		logger.L().Error("Failed to list URLs by tag", zap.Error(err), zap.String("tag", tag))
+		return nil, fmt.Errorf("failed to list URLs by tag: %w", err)
+	}
+
+	links := make([]*models.URL, len(rows))
+	for i, row := range rows {
+		links[i] = toURLModel(row)
+	}
+	return links, nil
+}
+
+// CreateCampaign implements CampaignStorage.CreateCampaign
+func (s *PostgresStorage) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	params, err := json.Marshal(campaign.Params)
+	if err != nil {
+		return fmt.Errorf("failed to encode campaign params: %w", err)
+	}
+
+	row, err := s.queries.CreateCampaign(ctx, db.CreateCampaignParams{
+		TenantID: tenant.FromContext(ctx),
+		ID:       campaign.ID,
+		Name:     campaign.Name,
+		Params:   params,
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation {
+			return ErrAlreadyExists
+		}
+		logger.L().Error("Failed to insert campaign", zap.Error(err), zap.String("campaignID", campaign.ID))
+		return fmt.Errorf("failed to insert campaign: %w", err)
+	}
+
+	campaign.CreatedAt = row.CreatedAt
+	campaign.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+// GetCampaign implements CampaignStorage.GetCampaign
+func (s *PostgresStorage) GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	row, err := s.queries.GetCampaign(ctx, db.GetCampaignParams{
+		TenantID: tenant.FromContext(ctx),
+		ID:       campaignID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		logger.L().Error("Failed to query campaign", zap.Error(err), zap.String("campaignID", campaignID))
+		return nil, fmt.Errorf("failed to query campaign: %w", err)
+	}
+
+	campaign := &models.Campaign{
+		ID:        row.ID,
+		Name:      row.Name,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	if err := json.Unmarshal(row.Params, &campaign.Params); err != nil {
+		return nil, fmt.Errorf("failed to decode campaign params: %w", err)
+	}
+	return campaign, nil
+}
+
+// UpdateCampaign implements CampaignStorage.UpdateCampaign
+func (s *PostgresStorage) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	params, err := json.Marshal(campaign.Params)
+	if err != nil {
+		return fmt.Errorf("failed to encode campaign params: %w", err)
+	}
+
+	row, err := s.queries.UpdateCampaign(ctx, db.UpdateCampaignParams{
+		TenantID: tenant.FromContext(ctx),
+		ID:       campaign.ID,
+		Name:     campaign.Name,
+		Params:   params,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		logger.L().Error("Failed to update campaign", zap.Error(err), zap.String("campaignID", campaign.ID))
+		return fmt.Errorf("failed to update campaign: %w", err)
+	}
+
+	campaign.CreatedAt = row.CreatedAt
+	campaign.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+// SetCampaign implements CampaignStorage.SetCampaign
+func (s *PostgresStorage) SetCampaign(ctx context.Context, shortID string, campaignID string) error {
+	_, err := s.setCampaign(ctx, shortID, campaignID)
+	return err
+}
+
+// setCampaign attaches a link to a campaign and returns the updated link
+func (s *PostgresStorage) setCampaign(ctx context.Context, shortID string, campaignID string) (*models.URL, error) {
+	row, err := s.queries.SetURLCampaign(ctx, db.SetURLCampaignParams{
+		TenantID:   tenant.FromContext(ctx),
+		ShortID:    shortID,
+		CampaignID: campaignID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		logger.L().Error("Failed to set URL campaign", zap.Error(err), zap.String("shortID", shortID))
+		return nil, fmt.Errorf("failed to set URL campaign: %w", err)
+	}
+	return toURLModel(row), nil
+}
+
+// SetTargeting implements TargetingStorage.SetTargeting
+func (s *PostgresStorage) SetTargeting(ctx context.Context, shortID string, rules []models.TargetingRule) error {
+	_, err := s.setTargeting(ctx, shortID, rules)
+	return err
+}
+
+// setTargeting replaces the targeting rules of a link and returns the updated link
+func (s *PostgresStorage) setTargeting(ctx context.Context, shortID string, rules []models.TargetingRule) (*models.URL, error) {
+	targeting, err := encodeList(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.SetURLTargeting(ctx, db.SetURLTargetingParams{
+		TenantID:  tenant.FromContext(ctx),
+		ShortID:   shortID,
+		Targeting: targeting,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		logger.L().Error("Failed to set URL targeting", zap.Error(err), zap.String("shortID", shortID))
+		return nil, fmt.Errorf("failed to set URL targeting: %w", err)
+	}
+	return toURLModel(row), nil
+}
+
+// SetVariants implements TargetingStorage.SetVariants
+func (s *PostgresStorage) SetVariants(ctx context.Context, shortID string, variants []models.Variant) error {
+	_, err := s.setVariants(ctx, shortID, variants)
+	return err
+}
+
+// setVariants replaces the split variants of a link and returns the updated link
+func (s *PostgresStorage) setVariants(ctx context.Context, shortID string, variants []models.Variant) (*models.URL, error) {
+	encoded, err := encodeList(variants)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.SetURLVariants(ctx, db.SetURLVariantsParams{
+		TenantID: tenant.FromContext(ctx),
+		ShortID:  shortID,
+		Variants: encoded,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		logger.L().Error("Failed to set URL variants", zap.Error(err), zap.String("shortID", shortID))
+		return nil, fmt.Errorf("failed to set URL variants: %w", err)
+	}
+	return toURLModel(row), nil
+}
+
+// StoreClicks implements ClickStorage.StoreClicks
+func (s *PostgresStorage) StoreClicks(ctx context.Context, clicks []*models.Click) error {
+	params := db.StoreClicksParams{
+		TenantIds:  make([]string, len(clicks)),
+		ShortIds:   make([]string, len(clicks)),
+		ClickedAts: make([]time.Time, len(clicks)),
+		Referrers:  make([]string, len(clicks)),
+		UserAgents: make([]string, len(clicks)),
+		ClientIps:  make([]string, len(clicks)),
+		Variants:   make([]string, len(clicks)),
+	}
+	for i, click := range clicks {
+		params.TenantIds[i] = click.TenantID
+		params.ShortIds[i] = click.ShortID
+		params.ClickedAts[i] = click.ClickedAt
+		params.Referrers[i] = click.Referrer
+		params.UserAgents[i] = click.UserAgent
+		params.ClientIps[i] = click.ClientIP
+		params.Variants[i] = click.Variant
+	}
+
+	if err := s.queries.StoreClicks(ctx, params); err != nil {
+		logger.L().Error("Failed to insert clicks", zap.Error(err), zap.Int("count", len(clicks)))
+		return fmt.Errorf("failed to insert clicks: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKey implements APIKeyStorage.GetAPIKey
+func (s *PostgresStorage) GetAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	row, err := s.queries.GetAPIKey(ctx, keyHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		logger.L().Error("Failed to query API key", zap.Error(err))
+		return nil, fmt.Errorf("failed to query API key: %w", err)
+	}
+
+	return &models.APIKey{
+		ID:       row.ID,
+		KeyHash:  row.KeyHash,
+		TenantID: row.TenantID,
+		Scopes:   row.Scopes,
+	}, nil
+}
+
+// ReserveLinks implements UsageStorage.ReserveLinks
+// The limits are checked and the counters bumped in one statement, so
+// concurrent instances cannot overshoot a quota together
+func (s *PostgresStorage) ReserveLinks(ctx context.Context, day time.Time, n int64, quota models.Quota) (*models.Usage, error) {
+	// The first reservation of a tenant inserts its row without checking the limits
+	if quotaExceeded(models.Usage{}, n, quota) {
+		return nil, ErrQuotaExceeded
+	}
+
+	row, err := s.queries.ReserveLinks(ctx, db.ReserveLinksParams{
+		TenantID:   tenant.FromContext(ctx),
+		Day:        day,
+		Links:      n,
+		DailyLimit: quota.Daily,
+		TotalLimit: quota.Total,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrQuotaExceeded
+		}
+		logger.L().Error("Failed to reserve links", zap.Error(err), zap.Int64("links", n))
+		return nil, fmt.Errorf("failed to reserve links: %w", err)
+	}
+
+	return &models.Usage{
+		Day:        day,
+		DailyLinks: row.DailyLinks,
+		TotalLinks: row.TotalLinks,
+	}, nil
+}
+
+// ReleaseLinks implements UsageStorage.ReleaseLinks
+func (s *PostgresStorage) ReleaseLinks(ctx context.Context, day time.Time, n int64) error {
+	err := s.queries.ReleaseLinks(ctx, db.ReleaseLinksParams{
+		Day:      day,
+		Links:    n,
+		TenantID: tenant.FromContext(ctx),
+	})
+	if err != nil {
+		logger.L().Error("Failed to release links", zap.Error(err), zap.Int64("links", n))
+		return fmt.Errorf("failed to release links: %w", err)
+	}
+	return nil
+}
+
+// GetUsage implements UsageStorage.GetUsage
+func (s *PostgresStorage) GetUsage(ctx context.Context, day time.Time) (*models.Usage, error) {
+	row, err := s.queries.GetUsage(ctx, tenant.FromContext(ctx))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &models.Usage{Day: day}, nil
+		}
+		logger.L().Error("Failed to query usage", zap.Error(err))
+		return nil, fmt.Errorf("failed to query usage: %w", err)
+	}
+
+	usage := usageOn(models.Usage{
+		Day:        row.Day,
+		DailyLinks: row.DailyLinks,
+		TotalLinks: row.TotalLinks,
+	}, day)
+	return &usage, nil
+}
+
+// Delete implements URLStorage.Delete
+func (s *PostgresStorage) Delete(ctx context.Context, shortID string) error {
+	_, err := s.deleteLink(ctx, shortID)
+	return err
+}
+
+// deleteLink removes a link and returns the removed record
+func (s *PostgresStorage) deleteLink(ctx context.Context, shortID string) (*models.URL, error) {
+	log := logger.L()
+
+	row, err := s.queries.DeleteURL(ctx, db.DeleteURLParams{
+		TenantID: tenant.FromContext(ctx),
+		ShortID:  shortID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debug("Short ID not found", zap.String("shortID", shortID))
+			return nil, ErrNotFound
+		}
+		log.Error("Failed to delete URL", zap.Error(err), zap.String("shortID", shortID))
+		return nil, fmt.Errorf("failed to delete URL: %w", err)
+	}
+
+	log.Debug("URL deleted", zap.String("shortID", shortID))
+	return toURLModel(row), nil
+}
+
+// SetDisabled implements URLStorage.SetDisabled
+func (s *PostgresStorage) SetDisabled(ctx context.Context, shortID string, disabled bool) error {
+	_, err := s.setDisabled(ctx, shortID, disabled)
+	return err
+}
+
+// setDisabled toggles the disabled flag of a link and returns the updated record
+func (s *PostgresStorage) setDisabled(ctx context.Context, shortID string, disabled bool) (*models.URL, error) {
+	log := logger.L()
+
+	row, err := s.queries.SetDisabled(ctx, db.SetDisabledParams{
+		TenantID: tenant.FromContext(ctx),
+		ShortID:  shortID,
+		Disabled: disabled,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debug("Short ID not found", zap.String("shortID", shortID))
+			return nil, ErrNotFound
+		}
+		log.Error("Failed to update URL status", zap.Error(err), zap.String("shortID", shortID))
+		return nil, fmt.Errorf("failed to update URL status: %w", err)
+	}
+
+	log.Debug("URL status updated",
+		zap.String("shortID", shortID),
+		zap.Bool("disabled", disabled))
+	return toURLModel(row), nil
+}
+
+// Update implements URLStorage.Update
+func (s *PostgresStorage) Update(ctx context.Context, shortID string, originalURL string, canonicalURL string) error {
+	_, err := s.update(ctx, shortID, originalURL, canonicalURL)
+	return err
+}
+
+// update retargets a link and returns its previous original and canonical URL
+func (s *PostgresStorage) update(ctx context.Context, shortID string, originalURL string, canonicalURL string) (*models.URL, error) {
+	log := logger.L()
+
+	if originalURL == "" {
+		return nil, ErrInvalidURL
+	}
+
+	old, err := s.queries.UpdateURL(ctx, db.UpdateURLParams{
+		TenantID:     tenant.FromContext(ctx),
+		ShortID:      shortID,
+		OriginalUrl:  originalURL,
+		CanonicalUrl: canonicalURL,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Debug("Short ID not found", zap.String("shortID", shortID))
+			return nil, ErrNotFound
+		}
+		log.Error("Failed to update URL", zap.Error(err), zap.String("shortID", shortID))
+		return nil, fmt.Errorf("failed to update URL: %w", err)
+	}
+
+	log.Debug("URL updated",
+		zap.String("shortID", shortID),
+		zap.String("oldURL", old.OriginalUrl),
+		zap.String("newURL", originalURL))
+	return &models.URL{
+		ShortID:      shortID,
+		OriginalURL:  old.OriginalUrl,
+		CanonicalURL: old.CanonicalUrl,
+	}, nil
 }
 
 // Close closes the database connection
@@ -159,3 +850,73 @@ func (s *PostgresStorage) Close() error {
 	log.Info("Closing PostgreSQL connection")
 	return s.db.Close()
 }
+
+// toURLModel converts a database row into a link record
+func toURLModel(row db.Url) *models.URL {
+	return &models.URL{
+		ShortID:          row.ShortID,
+		OriginalURL:      row.OriginalUrl,
+		CanonicalURL:     row.CanonicalUrl,
+		Dedup:            row.Dedup,
+		ExpiresAt:        fromNullTime(row.ExpiresAt),
+		NotBefore:        fromNullTime(row.NotBefore),
+		Disabled:         row.Disabled,
+		CreatedAt:        row.CreatedAt.Time,
+		LastAccessed:     fromNullTime(row.LastAccessed),
+		ClickCount:       row.ClickCount,
+		PasswordHash:     row.PasswordHash,
+		MaxClicks:        row.MaxClicks,
+		RemainingClicks:  row.RemainingClicks,
+		QuarantineReason: row.QuarantineReason,
+		CampaignID:       row.CampaignID,
+		Targeting:        decodeList[models.TargetingRule](row.ShortID, "targeting", row.Targeting),
+		Variants:         decodeList[models.Variant](row.ShortID, "variants", row.Variants),
+	}
+}
+
+// encodeList converts the targeting rules or variants of a link into their
+// JSON column value. Empty lists store an empty array, which is what dedup checks for
+func encodeList[T any](values []T) (json.RawMessage, error) {
+	if len(values) == 0 {
+		return json.RawMessage("[]"), nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %T: %w", values, err)
+	}
+	return data, nil
+}
+
+// decodeList reads the targeting rules or variants of a link from their JSON
+// column. Values that cannot be decoded are dropped, so the link still
+// resolves to its original URL
+func decodeList[T any](shortID string, column string, data json.RawMessage) []T {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		logger.L().Error("Failed to decode link column",
+			zap.Error(err),
+			zap.String("shortID", shortID),
+			zap.String("column", column))
+		return nil
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// toNullTime converts an optional time into its SQL representation
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// fromNullTime converts a nullable SQL time into an optional time
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
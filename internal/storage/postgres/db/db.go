@@ -24,35 +24,243 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addTagsStmt, err = db.PrepareContext(ctx, addTags); err != nil {
+		return nil, fmt.Errorf("error preparing query AddTags: %w", err)
+	}
+	if q.consumeClickStmt, err = db.PrepareContext(ctx, consumeClick); err != nil {
+		return nil, fmt.Errorf("error preparing query ConsumeClick: %w", err)
+	}
+	if q.createCampaignStmt, err = db.PrepareContext(ctx, createCampaign); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateCampaign: %w", err)
+	}
+	if q.deleteURLStmt, err = db.PrepareContext(ctx, deleteURL); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteURL: %w", err)
+	}
 	if q.findShortIDByURLStmt, err = db.PrepareContext(ctx, findShortIDByURL); err != nil {
 		return nil, fmt.Errorf("error preparing query FindShortIDByURL: %w", err)
 	}
+	if q.findShortIDsByURLsStmt, err = db.PrepareContext(ctx, findShortIDsByURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query FindShortIDsByURLs: %w", err)
+	}
+	if q.getAPIKeyStmt, err = db.PrepareContext(ctx, getAPIKey); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAPIKey: %w", err)
+	}
+	if q.getCampaignStmt, err = db.PrepareContext(ctx, getCampaign); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCampaign: %w", err)
+	}
+	if q.getTagsStmt, err = db.PrepareContext(ctx, getTags); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTags: %w", err)
+	}
 	if q.getURLStmt, err = db.PrepareContext(ctx, getURL); err != nil {
 		return nil, fmt.Errorf("error preparing query GetURL: %w", err)
 	}
+	if q.getURLsStmt, err = db.PrepareContext(ctx, getURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query GetURLs: %w", err)
+	}
+	if q.getUsageStmt, err = db.PrepareContext(ctx, getUsage); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUsage: %w", err)
+	}
+	if q.listURLsStmt, err = db.PrepareContext(ctx, listURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListURLs: %w", err)
+	}
+	if q.listURLsByTagStmt, err = db.PrepareContext(ctx, listURLsByTag); err != nil {
+		return nil, fmt.Errorf("error preparing query ListURLsByTag: %w", err)
+	}
+	if q.quarantineURLStmt, err = db.PrepareContext(ctx, quarantineURL); err != nil {
+		return nil, fmt.Errorf("error preparing query QuarantineURL: %w", err)
+	}
+	if q.recordAccessStmt, err = db.PrepareContext(ctx, recordAccess); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordAccess: %w", err)
+	}
+	if q.releaseLinksStmt, err = db.PrepareContext(ctx, releaseLinks); err != nil {
+		return nil, fmt.Errorf("error preparing query ReleaseLinks: %w", err)
+	}
+	if q.removeTagsStmt, err = db.PrepareContext(ctx, removeTags); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveTags: %w", err)
+	}
+	if q.reserveLinksStmt, err = db.PrepareContext(ctx, reserveLinks); err != nil {
+		return nil, fmt.Errorf("error preparing query ReserveLinks: %w", err)
+	}
+	if q.scanURLsStmt, err = db.PrepareContext(ctx, scanURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query ScanURLs: %w", err)
+	}
+	if q.setDisabledStmt, err = db.PrepareContext(ctx, setDisabled); err != nil {
+		return nil, fmt.Errorf("error preparing query SetDisabled: %w", err)
+	}
+	if q.setURLCampaignStmt, err = db.PrepareContext(ctx, setURLCampaign); err != nil {
+		return nil, fmt.Errorf("error preparing query SetURLCampaign: %w", err)
+	}
+	if q.setURLTargetingStmt, err = db.PrepareContext(ctx, setURLTargeting); err != nil {
+		return nil, fmt.Errorf("error preparing query SetURLTargeting: %w", err)
+	}
+	if q.setURLVariantsStmt, err = db.PrepareContext(ctx, setURLVariants); err != nil {
+		return nil, fmt.Errorf("error preparing query SetURLVariants: %w", err)
+	}
+	if q.storeClicksStmt, err = db.PrepareContext(ctx, storeClicks); err != nil {
+		return nil, fmt.Errorf("error preparing query StoreClicks: %w", err)
+	}
+	if q.storeManyStmt, err = db.PrepareContext(ctx, storeMany); err != nil {
+		return nil, fmt.Errorf("error preparing query StoreMany: %w", err)
+	}
 	if q.storeWithIDStmt, err = db.PrepareContext(ctx, storeWithID); err != nil {
 		return nil, fmt.Errorf("error preparing query StoreWithID: %w", err)
 	}
+	if q.updateCampaignStmt, err = db.PrepareContext(ctx, updateCampaign); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCampaign: %w", err)
+	}
+	if q.updateURLStmt, err = db.PrepareContext(ctx, updateURL); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateURL: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addTagsStmt != nil {
+		if cerr := q.addTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addTagsStmt: %w", cerr)
+		}
+	}
+	if q.consumeClickStmt != nil {
+		if cerr := q.consumeClickStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing consumeClickStmt: %w", cerr)
+		}
+	}
+	if q.createCampaignStmt != nil {
+		if cerr := q.createCampaignStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createCampaignStmt: %w", cerr)
+		}
+	}
+	if q.deleteURLStmt != nil {
+		if cerr := q.deleteURLStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteURLStmt: %w", cerr)
+		}
+	}
 	if q.findShortIDByURLStmt != nil {
 		if cerr := q.findShortIDByURLStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findShortIDByURLStmt: %w", cerr)
 		}
 	}
+	if q.findShortIDsByURLsStmt != nil {
+		if cerr := q.findShortIDsByURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findShortIDsByURLsStmt: %w", cerr)
+		}
+	}
+	if q.getAPIKeyStmt != nil {
+		if cerr := q.getAPIKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAPIKeyStmt: %w", cerr)
+		}
+	}
+	if q.getCampaignStmt != nil {
+		if cerr := q.getCampaignStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCampaignStmt: %w", cerr)
+		}
+	}
+	if q.getTagsStmt != nil {
+		if cerr := q.getTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTagsStmt: %w", cerr)
+		}
+	}
 	if q.getURLStmt != nil {
 		if cerr := q.getURLStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getURLStmt: %w", cerr)
 		}
 	}
+	if q.getURLsStmt != nil {
+		if cerr := q.getURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getURLsStmt: %w", cerr)
+		}
+	}
+	if q.getUsageStmt != nil {
+		if cerr := q.getUsageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUsageStmt: %w", cerr)
+		}
+	}
+	if q.listURLsStmt != nil {
+		if cerr := q.listURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listURLsStmt: %w", cerr)
+		}
+	}
+	if q.listURLsByTagStmt != nil {
+		if cerr := q.listURLsByTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listURLsByTagStmt: %w", cerr)
+		}
+	}
+	if q.quarantineURLStmt != nil {
+		if cerr := q.quarantineURLStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing quarantineURLStmt: %w", cerr)
+		}
+	}
+	if q.recordAccessStmt != nil {
+		if cerr := q.recordAccessStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordAccessStmt: %w", cerr)
+		}
+	}
+	if q.releaseLinksStmt != nil {
+		if cerr := q.releaseLinksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing releaseLinksStmt: %w", cerr)
+		}
+	}
+	if q.removeTagsStmt != nil {
+		if cerr := q.removeTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeTagsStmt: %w", cerr)
+		}
+	}
+	if q.reserveLinksStmt != nil {
+		if cerr := q.reserveLinksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reserveLinksStmt: %w", cerr)
+		}
+	}
+	if q.scanURLsStmt != nil {
+		if cerr := q.scanURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing scanURLsStmt: %w", cerr)
+		}
+	}
+	if q.setDisabledStmt != nil {
+		if cerr := q.setDisabledStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setDisabledStmt: %w", cerr)
+		}
+	}
+	if q.setURLCampaignStmt != nil {
+		if cerr := q.setURLCampaignStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setURLCampaignStmt: %w", cerr)
+		}
+	}
+	if q.setURLTargetingStmt != nil {
+		if cerr := q.setURLTargetingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setURLTargetingStmt: %w", cerr)
+		}
+	}
+	if q.setURLVariantsStmt != nil {
+		if cerr := q.setURLVariantsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setURLVariantsStmt: %w", cerr)
+		}
+	}
+	if q.storeClicksStmt != nil {
+		if cerr := q.storeClicksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing storeClicksStmt: %w", cerr)
+		}
+	}
+	if q.storeManyStmt != nil {
+		if cerr := q.storeManyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing storeManyStmt: %w", cerr)
+		}
+	}
 	if q.storeWithIDStmt != nil {
 		if cerr := q.storeWithIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing storeWithIDStmt: %w", cerr)
 		}
 	}
+	if q.updateCampaignStmt != nil {
+		if cerr := q.updateCampaignStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCampaignStmt: %w", cerr)
+		}
+	}
+	if q.updateURLStmt != nil {
+		if cerr := q.updateURLStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateURLStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -90,19 +298,71 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                   DBTX
-	tx                   *sql.Tx
-	findShortIDByURLStmt *sql.Stmt
-	getURLStmt           *sql.Stmt
-	storeWithIDStmt      *sql.Stmt
+	db                     DBTX
+	tx                     *sql.Tx
+	addTagsStmt            *sql.Stmt
+	consumeClickStmt       *sql.Stmt
+	createCampaignStmt     *sql.Stmt
+	deleteURLStmt          *sql.Stmt
+	findShortIDByURLStmt   *sql.Stmt
+	findShortIDsByURLsStmt *sql.Stmt
+	getAPIKeyStmt          *sql.Stmt
+	getCampaignStmt        *sql.Stmt
+	getTagsStmt            *sql.Stmt
+	getURLStmt             *sql.Stmt
+	getURLsStmt            *sql.Stmt
+	getUsageStmt           *sql.Stmt
+	listURLsStmt           *sql.Stmt
+	listURLsByTagStmt      *sql.Stmt
+	quarantineURLStmt      *sql.Stmt
+	recordAccessStmt       *sql.Stmt
+	releaseLinksStmt       *sql.Stmt
+	removeTagsStmt         *sql.Stmt
+	reserveLinksStmt       *sql.Stmt
+	scanURLsStmt           *sql.Stmt
+	setDisabledStmt        *sql.Stmt
+	setURLCampaignStmt     *sql.Stmt
+	setURLTargetingStmt    *sql.Stmt
+	setURLVariantsStmt     *sql.Stmt
+	storeClicksStmt        *sql.Stmt
+	storeManyStmt          *sql.Stmt
+	storeWithIDStmt        *sql.Stmt
+	updateCampaignStmt     *sql.Stmt
+	updateURLStmt          *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                   tx,
-		tx:                   tx,
-		findShortIDByURLStmt: q.findShortIDByURLStmt,
-		getURLStmt:           q.getURLStmt,
-		storeWithIDStmt:      q.storeWithIDStmt,
+		db:                     tx,
+		tx:                     tx,
+		addTagsStmt:            q.addTagsStmt,
+		consumeClickStmt:       q.consumeClickStmt,
+		createCampaignStmt:     q.createCampaignStmt,
+		deleteURLStmt:          q.deleteURLStmt,
+		findShortIDByURLStmt:   q.findShortIDByURLStmt,
+		findShortIDsByURLsStmt: q.findShortIDsByURLsStmt,
+		getAPIKeyStmt:          q.getAPIKeyStmt,
+		getCampaignStmt:        q.getCampaignStmt,
+		getTagsStmt:            q.getTagsStmt,
+		getURLStmt:             q.getURLStmt,
+		getURLsStmt:            q.getURLsStmt,
+		getUsageStmt:           q.getUsageStmt,
+		listURLsStmt:           q.listURLsStmt,
+		listURLsByTagStmt:      q.listURLsByTagStmt,
+		quarantineURLStmt:      q.quarantineURLStmt,
+		recordAccessStmt:       q.recordAccessStmt,
+		releaseLinksStmt:       q.releaseLinksStmt,
+		removeTagsStmt:         q.removeTagsStmt,
+		reserveLinksStmt:       q.reserveLinksStmt,
+		scanURLsStmt:           q.scanURLsStmt,
+		setDisabledStmt:        q.setDisabledStmt,
+		setURLCampaignStmt:     q.setURLCampaignStmt,
+		setURLTargetingStmt:    q.setURLTargetingStmt,
+		setURLVariantsStmt:     q.setURLVariantsStmt,
+		storeClicksStmt:        q.storeClicksStmt,
+		storeManyStmt:          q.storeManyStmt,
+		storeWithIDStmt:        q.storeWithIDStmt,
+		updateCampaignStmt:     q.updateCampaignStmt,
+		updateURLStmt:          q.updateURLStmt,
 	}
 }
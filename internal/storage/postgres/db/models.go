@@ -6,11 +6,69 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 )
 
+type ApiKey struct {
+	ID        string    `json:"id"`
+	KeyHash   string    `json:"key_hash"`
+	TenantID  string    `json:"tenant_id"`
+	Scopes    []string  `json:"scopes"`
+	Disabled  bool      `json:"disabled"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Campaign struct {
+	TenantID  string          `json:"tenant_id"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Params    json.RawMessage `json:"params"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type Click struct {
+	ID        int64     `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	ShortID   string    `json:"short_id"`
+	ClickedAt time.Time `json:"clicked_at"`
+	Referrer  string    `json:"referrer"`
+	UserAgent string    `json:"user_agent"`
+	ClientIp  string    `json:"client_ip"`
+	Variant   string    `json:"variant"`
+}
+
 type Url struct {
-	ShortID      string       `json:"short_id"`
-	OriginalUrl  string       `json:"original_url"`
-	CreatedAt    sql.NullTime `json:"created_at"`
-	LastAccessed sql.NullTime `json:"last_accessed"`
+	TenantID         string          `json:"tenant_id"`
+	ShortID          string          `json:"short_id"`
+	OriginalUrl      string          `json:"original_url"`
+	CanonicalUrl     string          `json:"canonical_url"`
+	Dedup            bool            `json:"dedup"`
+	CreatedAt        sql.NullTime    `json:"created_at"`
+	LastAccessed     sql.NullTime    `json:"last_accessed"`
+	ExpiresAt        sql.NullTime    `json:"expires_at"`
+	Disabled         bool            `json:"disabled"`
+	ClickCount       int64           `json:"click_count"`
+	PasswordHash     string          `json:"password_hash"`
+	MaxClicks        int64           `json:"max_clicks"`
+	RemainingClicks  int64           `json:"remaining_clicks"`
+	QuarantineReason string          `json:"quarantine_reason"`
+	CampaignID       string          `json:"campaign_id"`
+	Targeting        json.RawMessage `json:"targeting"`
+	Variants         json.RawMessage `json:"variants"`
+	NotBefore        sql.NullTime    `json:"not_before"`
+}
+
+type UrlTag struct {
+	TenantID string `json:"tenant_id"`
+	ShortID  string `json:"short_id"`
+	Tag      string `json:"tag"`
+}
+
+type TenantUsage struct {
+	TenantID   string    `json:"tenant_id"`
+	Day        time.Time `json:"day"`
+	DailyLinks int64     `json:"daily_links"`
+	TotalLinks int64     `json:"total_links"`
 }
@@ -9,9 +9,35 @@ import (
 )
 
 type Querier interface {
-	FindShortIDByURL(ctx context.Context, originalUrl string) (string, error)
-	GetURL(ctx context.Context, shortID string) (string, error)
+	AddTags(ctx context.Context, arg AddTagsParams) error
+	ConsumeClick(ctx context.Context, arg ConsumeClickParams) (int64, error)
+	CreateCampaign(ctx context.Context, arg CreateCampaignParams) (CreateCampaignRow, error)
+	DeleteURL(ctx context.Context, arg DeleteURLParams) (Url, error)
+	FindShortIDByURL(ctx context.Context, arg FindShortIDByURLParams) (string, error)
+	FindShortIDsByURLs(ctx context.Context, arg FindShortIDsByURLsParams) ([]FindShortIDsByURLsRow, error)
+	GetAPIKey(ctx context.Context, keyHash string) (ApiKey, error)
+	GetCampaign(ctx context.Context, arg GetCampaignParams) (Campaign, error)
+	GetTags(ctx context.Context, arg GetTagsParams) ([]GetTagsRow, error)
+	GetURL(ctx context.Context, arg GetURLParams) (Url, error)
+	GetURLs(ctx context.Context, arg GetURLsParams) ([]Url, error)
+	GetUsage(ctx context.Context, tenantID string) (TenantUsage, error)
+	ListURLs(ctx context.Context, arg ListURLsParams) ([]Url, error)
+	ListURLsByTag(ctx context.Context, arg ListURLsByTagParams) ([]Url, error)
+	QuarantineURL(ctx context.Context, arg QuarantineURLParams) (Url, error)
+	RecordAccess(ctx context.Context, arg RecordAccessParams) error
+	ReleaseLinks(ctx context.Context, arg ReleaseLinksParams) error
+	RemoveTags(ctx context.Context, arg RemoveTagsParams) error
+	ReserveLinks(ctx context.Context, arg ReserveLinksParams) (ReserveLinksRow, error)
+	ScanURLs(ctx context.Context, arg ScanURLsParams) ([]Url, error)
+	SetDisabled(ctx context.Context, arg SetDisabledParams) (Url, error)
+	SetURLCampaign(ctx context.Context, arg SetURLCampaignParams) (Url, error)
+	SetURLTargeting(ctx context.Context, arg SetURLTargetingParams) (Url, error)
+	SetURLVariants(ctx context.Context, arg SetURLVariantsParams) (Url, error)
+	StoreClicks(ctx context.Context, arg StoreClicksParams) error
+	StoreMany(ctx context.Context, arg StoreManyParams) ([]string, error)
 	StoreWithID(ctx context.Context, arg StoreWithIDParams) error
+	UpdateCampaign(ctx context.Context, arg UpdateCampaignParams) (UpdateCampaignRow, error)
+	UpdateURL(ctx context.Context, arg UpdateURLParams) (UpdateURLRow, error)
 }
 
 var _ Querier = (*Queries)(nil)
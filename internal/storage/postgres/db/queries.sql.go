@@ -7,45 +7,1002 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
 )
 
+const addTags = `-- name: AddTags :exec
+INSERT INTO url_tags (tenant_id, short_id, tag) 
+SELECT $1::text, $2::text, unnest($3::text[]) 
+ON CONFLICT DO NOTHING
+`
+
+type AddTagsParams struct {
+	TenantID string   `json:"tenant_id"`
+	ShortID  string   `json:"short_id"`
+	Tags     []string `json:"tags"`
+}
+
+func (q *Queries) AddTags(ctx context.Context, arg AddTagsParams) error {
+	_, err := q.exec(ctx, q.addTagsStmt, addTags, arg.TenantID, arg.ShortID, pq.Array(arg.Tags))
+	return err
+}
+
+const consumeClick = `-- name: ConsumeClick :one
+UPDATE urls 
+SET remaining_clicks = remaining_clicks - 1 
+WHERE tenant_id = $1 AND short_id = $2 AND remaining_clicks > 0 
+RETURNING remaining_clicks
+`
+
+type ConsumeClickParams struct {
+	TenantID string `json:"tenant_id"`
+	ShortID  string `json:"short_id"`
+}
+
+func (q *Queries) ConsumeClick(ctx context.Context, arg ConsumeClickParams) (int64, error) {
+	row := q.queryRow(ctx, q.consumeClickStmt, consumeClick, arg.TenantID, arg.ShortID)
+	var remaining_clicks int64
+	err := row.Scan(&remaining_clicks)
+	return remaining_clicks, err
+}
+
+const createCampaign = `-- name: CreateCampaign :one
+INSERT INTO campaigns (tenant_id, id, name, params) 
+VALUES ($1, $2, $3, $4) 
+RETURNING created_at, updated_at
+`
+
+type CreateCampaignParams struct {
+	TenantID string          `json:"tenant_id"`
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Params   json.RawMessage `json:"params"`
+}
+
+type CreateCampaignRow struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) CreateCampaign(ctx context.Context, arg CreateCampaignParams) (CreateCampaignRow, error) {
+	row := q.queryRow(ctx, q.createCampaignStmt, createCampaign,
+		arg.TenantID,
+		arg.ID,
+		arg.Name,
+		arg.Params,
+	)
+	var i CreateCampaignRow
+	err := row.Scan(&i.CreatedAt, &i.UpdatedAt)
+	return i, err
+}
+
+const deleteURL = `-- name: DeleteURL :one
+DELETE FROM urls 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before
+`
+
+type DeleteURLParams struct {
+	TenantID string `json:"tenant_id"`
+	ShortID  string `json:"short_id"`
+}
+
+func (q *Queries) DeleteURL(ctx context.Context, arg DeleteURLParams) (Url, error) {
+	row := q.queryRow(ctx, q.deleteURLStmt, deleteURL, arg.TenantID, arg.ShortID)
+	var i Url
+	err := row.Scan(
+		&i.TenantID,
+		&i.ShortID,
+		&i.OriginalUrl,
+		&i.CanonicalUrl,
+		&i.Dedup,
+		&i.CreatedAt,
+		&i.LastAccessed,
+		&i.ExpiresAt,
+		&i.Disabled,
+		&i.ClickCount,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.RemainingClicks,
+		&i.QuarantineReason,
+		&i.CampaignID,
+		&i.Targeting,
+		&i.Variants,
+		&i.NotBefore,
+	)
+	return i, err
+}
+
 const findShortIDByURL = `-- name: FindShortIDByURL :one
-SELECT short_id FROM urls WHERE original_url = $1 LIMIT 1
+SELECT short_id FROM urls WHERE tenant_id = $1 AND canonical_url = $2 AND dedup LIMIT 1
 `
 
-func (q *Queries) FindShortIDByURL(ctx context.Context, originalUrl string) (string, error) {
-	row := q.queryRow(ctx, q.findShortIDByURLStmt, findShortIDByURL, originalUrl)
+type FindShortIDByURLParams struct {
+	TenantID     string `json:"tenant_id"`
+	CanonicalUrl string `json:"canonical_url"`
+}
+
+func (q *Queries) FindShortIDByURL(ctx context.Context, arg FindShortIDByURLParams) (string, error) {
+	row := q.queryRow(ctx, q.findShortIDByURLStmt, findShortIDByURL, arg.TenantID, arg.CanonicalUrl)
 	var short_id string
 	err := row.Scan(&short_id)
 	return short_id, err
 }
 
+const findShortIDsByURLs = `-- name: FindShortIDsByURLs :many
+SELECT short_id, canonical_url FROM urls WHERE tenant_id = $1 AND canonical_url = ANY($2::text[]) AND dedup
+`
+
+type FindShortIDsByURLsParams struct {
+	TenantID      string   `json:"tenant_id"`
+	CanonicalUrls []string `json:"canonical_urls"`
+}
+
+type FindShortIDsByURLsRow struct {
+	ShortID      string `json:"short_id"`
+	CanonicalUrl string `json:"canonical_url"`
+}
+
+func (q *Queries) FindShortIDsByURLs(ctx context.Context, arg FindShortIDsByURLsParams) ([]FindShortIDsByURLsRow, error) {
+	rows, err := q.query(ctx, q.findShortIDsByURLsStmt, findShortIDsByURLs, arg.TenantID, pq.Array(arg.CanonicalUrls))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FindShortIDsByURLsRow{}
+	for rows.Next() {
+		var i FindShortIDsByURLsRow
+		if err := rows.Scan(&i.ShortID, &i.CanonicalUrl); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAPIKey = `-- name: GetAPIKey :one
+SELECT id, key_hash, tenant_id, scopes, disabled, created_at FROM api_keys 
+WHERE key_hash = $1 AND NOT disabled
+`
+
+func (q *Queries) GetAPIKey(ctx context.Context, keyHash string) (ApiKey, error) {
+	row := q.queryRow(ctx, q.getAPIKeyStmt, getAPIKey, keyHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.KeyHash,
+		&i.TenantID,
+		pq.Array(&i.Scopes),
+		&i.Disabled,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCampaign = `-- name: GetCampaign :one
+SELECT tenant_id, id, name, params, created_at, updated_at FROM campaigns 
+WHERE tenant_id = $1 AND id = $2
+`
+
+type GetCampaignParams struct {
+	TenantID string `json:"tenant_id"`
+	ID       string `json:"id"`
+}
+
+func (q *Queries) GetCampaign(ctx context.Context, arg GetCampaignParams) (Campaign, error) {
+	row := q.queryRow(ctx, q.getCampaignStmt, getCampaign, arg.TenantID, arg.ID)
+	var i Campaign
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Name,
+		&i.Params,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTags = `-- name: GetTags :many
+SELECT short_id, tag FROM url_tags 
+WHERE tenant_id = $1 AND short_id = ANY($2::text[]) 
+ORDER BY short_id, tag
+`
+
+type GetTagsParams struct {
+	TenantID string   `json:"tenant_id"`
+	ShortIds []string `json:"short_ids"`
+}
+
+type GetTagsRow struct {
+	ShortID string `json:"short_id"`
+	Tag     string `json:"tag"`
+}
+
+func (q *Queries) GetTags(ctx context.Context, arg GetTagsParams) ([]GetTagsRow, error) {
+	rows, err := q.query(ctx, q.getTagsStmt, getTags, arg.TenantID, pq.Array(arg.ShortIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTagsRow{}
+	for rows.Next() {
+		var i GetTagsRow
+		if err := rows.Scan(&i.ShortID, &i.Tag); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getURL = `-- name: GetURL :one
+SELECT tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before FROM urls 
+WHERE tenant_id = $1 AND short_id = $2
+`
+
+type GetURLParams struct {
+	TenantID string `json:"tenant_id"`
+	ShortID  string `json:"short_id"`
+}
+
+func (q *Queries) GetURL(ctx context.Context, arg GetURLParams) (Url, error) {
+	row := q.queryRow(ctx, q.getURLStmt, getURL, arg.TenantID, arg.ShortID)
+	var i Url
+	err := row.Scan(
+		&i.TenantID,
+		&i.ShortID,
+		&i.OriginalUrl,
+		&i.CanonicalUrl,
+		&i.Dedup,
+		&i.CreatedAt,
+		&i.LastAccessed,
+		&i.ExpiresAt,
+		&i.Disabled,
+		&i.ClickCount,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.RemainingClicks,
+		&i.QuarantineReason,
+		&i.CampaignID,
+		&i.Targeting,
+		&i.Variants,
+		&i.NotBefore,
+	)
+	return i, err
+}
+
+const getURLs = `-- name: GetURLs :many
+SELECT tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before FROM urls 
+WHERE tenant_id = $1 AND short_id = ANY($2::text[])
+`
+
+type GetURLsParams struct {
+	TenantID string   `json:"tenant_id"`
+	ShortIds []string `json:"short_ids"`
+}
+
+func (q *Queries) GetURLs(ctx context.Context, arg GetURLsParams) ([]Url, error) {
+	rows, err := q.query(ctx, q.getURLsStmt, getURLs, arg.TenantID, pq.Array(arg.ShortIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ShortID,
+			&i.OriginalUrl,
+			&i.CanonicalUrl,
+			&i.Dedup,
+			&i.CreatedAt,
+			&i.LastAccessed,
+			&i.ExpiresAt,
+			&i.Disabled,
+			&i.ClickCount,
+			&i.PasswordHash,
+			&i.MaxClicks,
+			&i.RemainingClicks,
+			&i.QuarantineReason,
+			&i.CampaignID,
+			&i.Targeting,
+			&i.Variants,
+			&i.NotBefore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsage = `-- name: GetUsage :one
+SELECT tenant_id, day, daily_links, total_links FROM tenant_usage WHERE tenant_id = $1
+`
+
+func (q *Queries) GetUsage(ctx context.Context, tenantID string) (TenantUsage, error) {
+	row := q.queryRow(ctx, q.getUsageStmt, getUsage, tenantID)
+	var i TenantUsage
+	err := row.Scan(
+		&i.TenantID,
+		&i.Day,
+		&i.DailyLinks,
+		&i.TotalLinks,
+	)
+	return i, err
+}
+
+const listURLs = `-- name: ListURLs :many
+SELECT tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before FROM urls 
+WHERE tenant_id = $1 
+  AND (created_at, short_id) > ($2::timestamptz, $3::text) 
+  AND ($4::timestamptz IS NULL OR created_at >= $4) 
+  AND ($5::timestamptz IS NULL OR created_at < $5) 
+  AND ($6::timestamptz IS NULL OR last_accessed >= $6) 
+  AND ($7::timestamptz IS NULL OR last_accessed < $7) 
+  AND ($8::text = '' OR lower(substring(original_url FROM '^[a-zA-Z][a-zA-Z0-9+.-]*://(?:[^/?#@]*@)?([^/?#:]*)')) = $8) 
+ORDER BY created_at, short_id 
+LIMIT $9
+`
+
+type ListURLsParams struct {
+	TenantID       string       `json:"tenant_id"`
+	AfterCreatedAt time.Time    `json:"after_created_at"`
+	AfterShortID   string       `json:"after_short_id"`
+	CreatedFrom    sql.NullTime `json:"created_from"`
+	CreatedTo      sql.NullTime `json:"created_to"`
+	AccessedFrom   sql.NullTime `json:"accessed_from"`
+	AccessedTo     sql.NullTime `json:"accessed_to"`
+	Domain         string       `json:"domain"`
+	PageSize       int32        `json:"page_size"`
+}
+
+func (q *Queries) ListURLs(ctx context.Context, arg ListURLsParams) ([]Url, error) {
+	rows, err := q.query(ctx, q.listURLsStmt, listURLs,
+		arg.TenantID,
+		arg.AfterCreatedAt,
+		arg.AfterShortID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.AccessedFrom,
+		arg.AccessedTo,
+		arg.Domain,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ShortID,
+			&i.OriginalUrl,
+			&i.CanonicalUrl,
+			&i.Dedup,
+			&i.CreatedAt,
+			&i.LastAccessed,
+			&i.ExpiresAt,
+			&i.Disabled,
+			&i.ClickCount,
+			&i.PasswordHash,
+			&i.MaxClicks,
+			&i.RemainingClicks,
+			&i.QuarantineReason,
+			&i.CampaignID,
+			&i.Targeting,
+			&i.Variants,
+			&i.NotBefore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listURLsByTag = `-- name: ListURLsByTag :many
+SELECT u.tenant_id, u.short_id, u.original_url, u.canonical_url, u.dedup, u.created_at, u.last_accessed, u.expires_at, u.disabled, u.click_count, u.password_hash, u.max_clicks, u.remaining_clicks, u.quarantine_reason, u.campaign_id, u.targeting, u.variants, u.not_before FROM urls AS u 
+JOIN url_tags AS t ON t.tenant_id = u.tenant_id AND t.short_id = u.short_id 
+WHERE t.tenant_id = $1 AND t.tag = $2 AND t.short_id > $3::text 
+ORDER BY t.short_id 
+LIMIT $4
+`
+
+type ListURLsByTagParams struct {
+	TenantID     string `json:"tenant_id"`
+	Tag          string `json:"tag"`
+	AfterShortID string `json:"after_short_id"`
+	PageSize     int32  `json:"page_size"`
+}
+
+func (q *Queries) ListURLsByTag(ctx context.Context, arg ListURLsByTagParams) ([]Url, error) {
+	rows, err := q.query(ctx, q.listURLsByTagStmt, listURLsByTag,
+		arg.TenantID,
+		arg.Tag,
+		arg.AfterShortID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ShortID,
+			&i.OriginalUrl,
+			&i.CanonicalUrl,
+			&i.Dedup,
+			&i.CreatedAt,
+			&i.LastAccessed,
+			&i.ExpiresAt,
+			&i.Disabled,
+			&i.ClickCount,
+			&i.PasswordHash,
+			&i.MaxClicks,
+			&i.RemainingClicks,
+			&i.QuarantineReason,
+			&i.CampaignID,
+			&i.Targeting,
+			&i.Variants,
+			&i.NotBefore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const quarantineURL = `-- name: QuarantineURL :one
 UPDATE urls 
-SET last_accessed = NOW() 
-WHERE short_id = $1 
-RETURNING original_url
+SET quarantine_reason = $3, dedup = FALSE 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before
 `
 
-func (q *Queries) GetURL(ctx context.Context, shortID string) (string, error) {
-	row := q.queryRow(ctx, q.getURLStmt, getURL, shortID)
-	var original_url string
-	err := row.Scan(&original_url)
-	return original_url, err
+type QuarantineURLParams struct {
+	TenantID         string `json:"tenant_id"`
+	ShortID          string `json:"short_id"`
+	QuarantineReason string `json:"quarantine_reason"`
+}
+
+func (q *Queries) QuarantineURL(ctx context.Context, arg QuarantineURLParams) (Url, error) {
+	row := q.queryRow(ctx, q.quarantineURLStmt, quarantineURL, arg.TenantID, arg.ShortID, arg.QuarantineReason)
+	var i Url
+	err := row.Scan(
+		&i.TenantID,
+		&i.ShortID,
+		&i.OriginalUrl,
+		&i.CanonicalUrl,
+		&i.Dedup,
+		&i.CreatedAt,
+		&i.LastAccessed,
+		&i.ExpiresAt,
+		&i.Disabled,
+		&i.ClickCount,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.RemainingClicks,
+		&i.QuarantineReason,
+		&i.CampaignID,
+		&i.Targeting,
+		&i.Variants,
+		&i.NotBefore,
+	)
+	return i, err
+}
+
+const recordAccess = `-- name: RecordAccess :exec
+UPDATE urls AS u 
+SET last_accessed = NOW(), click_count = u.click_count + a.clicks 
+FROM unnest($1::text[], $2::bigint[]) AS a(short_id, clicks) 
+WHERE u.tenant_id = $3 AND u.short_id = a.short_id
+`
+
+type RecordAccessParams struct {
+	ShortIds []string `json:"short_ids"`
+	Clicks   []int64  `json:"clicks"`
+	TenantID string   `json:"tenant_id"`
+}
+
+func (q *Queries) RecordAccess(ctx context.Context, arg RecordAccessParams) error {
+	_, err := q.exec(ctx, q.recordAccessStmt, recordAccess, pq.Array(arg.ShortIds), pq.Array(arg.Clicks), arg.TenantID)
+	return err
+}
+
+const releaseLinks = `-- name: ReleaseLinks :exec
+UPDATE tenant_usage 
+SET daily_links = CASE WHEN day = $1 THEN GREATEST(daily_links - $2, 0) ELSE daily_links END, 
+    total_links = GREATEST(total_links - $2, 0) 
+WHERE tenant_id = $3
+`
+
+type ReleaseLinksParams struct {
+	Day      time.Time `json:"day"`
+	Links    int64     `json:"links"`
+	TenantID string    `json:"tenant_id"`
+}
+
+func (q *Queries) ReleaseLinks(ctx context.Context, arg ReleaseLinksParams) error {
+	_, err := q.exec(ctx, q.releaseLinksStmt, releaseLinks, arg.Day, arg.Links, arg.TenantID)
+	return err
+}
+
+const removeTags = `-- name: RemoveTags :exec
+DELETE FROM url_tags 
+WHERE tenant_id = $1 AND short_id = $2 AND tag = ANY($3::text[])
+`
+
+type RemoveTagsParams struct {
+	TenantID string   `json:"tenant_id"`
+	ShortID  string   `json:"short_id"`
+	Tags     []string `json:"tags"`
+}
+
+func (q *Queries) RemoveTags(ctx context.Context, arg RemoveTagsParams) error {
+	_, err := q.exec(ctx, q.removeTagsStmt, removeTags, arg.TenantID, arg.ShortID, pq.Array(arg.Tags))
+	return err
+}
+
+const reserveLinks = `-- name: ReserveLinks :one
+INSERT INTO tenant_usage AS u (tenant_id, day, daily_links, total_links) 
+VALUES ($1, $2, $3, $3) 
+ON CONFLICT (tenant_id) DO UPDATE SET 
+    daily_links = CASE WHEN u.day = EXCLUDED.day THEN u.daily_links ELSE 0 END + EXCLUDED.daily_links, 
+    total_links = u.total_links + EXCLUDED.total_links, 
+    day = EXCLUDED.day 
+WHERE ($4::bigint <= 0 OR CASE WHEN u.day = EXCLUDED.day THEN u.daily_links ELSE 0 END + EXCLUDED.daily_links <= $4::bigint) 
+AND ($5::bigint <= 0 OR u.total_links + EXCLUDED.total_links <= $5::bigint) 
+RETURNING day, daily_links, total_links
+`
+
+type ReserveLinksParams struct {
+	TenantID   string    `json:"tenant_id"`
+	Day        time.Time `json:"day"`
+	Links      int64     `json:"links"`
+	DailyLimit int64     `json:"daily_limit"`
+	TotalLimit int64     `json:"total_limit"`
+}
+
+type ReserveLinksRow struct {
+	Day        time.Time `json:"day"`
+	DailyLinks int64     `json:"daily_links"`
+	TotalLinks int64     `json:"total_links"`
+}
+
+func (q *Queries) ReserveLinks(ctx context.Context, arg ReserveLinksParams) (ReserveLinksRow, error) {
+	row := q.queryRow(ctx, q.reserveLinksStmt, reserveLinks,
+		arg.TenantID,
+		arg.Day,
+		arg.Links,
+		arg.DailyLimit,
+		arg.TotalLimit,
+	)
+	var i ReserveLinksRow
+	err := row.Scan(&i.Day, &i.DailyLinks, &i.TotalLinks)
+	return i, err
+}
+
+const scanURLs = `-- name: ScanURLs :many
+SELECT tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before FROM urls 
+WHERE (tenant_id, short_id) > ($1::text, $2::text) 
+ORDER BY tenant_id, short_id 
+LIMIT $3
+`
+
+type ScanURLsParams struct {
+	AfterTenantID string `json:"after_tenant_id"`
+	AfterShortID  string `json:"after_short_id"`
+	PageSize      int32  `json:"page_size"`
+}
+
+func (q *Queries) ScanURLs(ctx context.Context, arg ScanURLsParams) ([]Url, error) {
+	rows, err := q.query(ctx, q.scanURLsStmt, scanURLs, arg.AfterTenantID, arg.AfterShortID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ShortID,
+			&i.OriginalUrl,
+			&i.CanonicalUrl,
+			&i.Dedup,
+			&i.CreatedAt,
+			&i.LastAccessed,
+			&i.ExpiresAt,
+			&i.Disabled,
+			&i.ClickCount,
+			&i.PasswordHash,
+			&i.MaxClicks,
+			&i.RemainingClicks,
+			&i.QuarantineReason,
+			&i.CampaignID,
+			&i.Targeting,
+			&i.Variants,
+			&i.NotBefore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setDisabled = `-- name: SetDisabled :one
+UPDATE urls 
+SET disabled = $3, dedup = dedup AND NOT $3 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before
+`
+
+type SetDisabledParams struct {
+	TenantID string `json:"tenant_id"`
+	ShortID  string `json:"short_id"`
+	Disabled bool   `json:"disabled"`
+}
+
+func (q *Queries) SetDisabled(ctx context.Context, arg SetDisabledParams) (Url, error) {
+	row := q.queryRow(ctx, q.setDisabledStmt, setDisabled, arg.TenantID, arg.ShortID, arg.Disabled)
+	var i Url
+	err := row.Scan(
+		&i.TenantID,
+		&i.ShortID,
+		&i.OriginalUrl,
+		&i.CanonicalUrl,
+		&i.Dedup,
+		&i.CreatedAt,
+		&i.LastAccessed,
+		&i.ExpiresAt,
+		&i.Disabled,
+		&i.ClickCount,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.RemainingClicks,
+		&i.QuarantineReason,
+		&i.CampaignID,
+		&i.Targeting,
+		&i.Variants,
+		&i.NotBefore,
+	)
+	return i, err
+}
+
+const setURLCampaign = `-- name: SetURLCampaign :one
+UPDATE urls 
+SET campaign_id = $3, dedup = dedup AND $3 = '' 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before
+`
+
+type SetURLCampaignParams struct {
+	TenantID   string `json:"tenant_id"`
+	ShortID    string `json:"short_id"`
+	CampaignID string `json:"campaign_id"`
+}
+
+func (q *Queries) SetURLCampaign(ctx context.Context, arg SetURLCampaignParams) (Url, error) {
+	row := q.queryRow(ctx, q.setURLCampaignStmt, setURLCampaign, arg.TenantID, arg.ShortID, arg.CampaignID)
+	var i Url
+	err := row.Scan(
+		&i.TenantID,
+		&i.ShortID,
+		&i.OriginalUrl,
+		&i.CanonicalUrl,
+		&i.Dedup,
+		&i.CreatedAt,
+		&i.LastAccessed,
+		&i.ExpiresAt,
+		&i.Disabled,
+		&i.ClickCount,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.RemainingClicks,
+		&i.QuarantineReason,
+		&i.CampaignID,
+		&i.Targeting,
+		&i.Variants,
+		&i.NotBefore,
+	)
+	return i, err
+}
+
+const setURLTargeting = `-- name: SetURLTargeting :one
+UPDATE urls 
+SET targeting = $3, dedup = dedup AND $3 = '[]'::jsonb 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before
+`
+
+type SetURLTargetingParams struct {
+	TenantID  string          `json:"tenant_id"`
+	ShortID   string          `json:"short_id"`
+	Targeting json.RawMessage `json:"targeting"`
+}
+
+func (q *Queries) SetURLTargeting(ctx context.Context, arg SetURLTargetingParams) (Url, error) {
+	row := q.queryRow(ctx, q.setURLTargetingStmt, setURLTargeting, arg.TenantID, arg.ShortID, arg.Targeting)
+	var i Url
+	err := row.Scan(
+		&i.TenantID,
+		&i.ShortID,
+		&i.OriginalUrl,
+		&i.CanonicalUrl,
+		&i.Dedup,
+		&i.CreatedAt,
+		&i.LastAccessed,
+		&i.ExpiresAt,
+		&i.Disabled,
+		&i.ClickCount,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.RemainingClicks,
+		&i.QuarantineReason,
+		&i.CampaignID,
+		&i.Targeting,
+		&i.Variants,
+		&i.NotBefore,
+	)
+	return i, err
+}
+
+const setURLVariants = `-- name: SetURLVariants :one
+UPDATE urls 
+SET variants = $3, dedup = dedup AND $3 = '[]'::jsonb 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING tenant_id, short_id, original_url, canonical_url, dedup, created_at, last_accessed, expires_at, disabled, click_count, password_hash, max_clicks, remaining_clicks, quarantine_reason, campaign_id, targeting, variants, not_before
+`
+
+type SetURLVariantsParams struct {
+	TenantID string          `json:"tenant_id"`
+	ShortID  string          `json:"short_id"`
+	Variants json.RawMessage `json:"variants"`
+}
+
+func (q *Queries) SetURLVariants(ctx context.Context, arg SetURLVariantsParams) (Url, error) {
+	row := q.queryRow(ctx, q.setURLVariantsStmt, setURLVariants, arg.TenantID, arg.ShortID, arg.Variants)
+	var i Url
+	err := row.Scan(
+		&i.TenantID,
+		&i.ShortID,
+		&i.OriginalUrl,
+		&i.CanonicalUrl,
+		&i.Dedup,
+		&i.CreatedAt,
+		&i.LastAccessed,
+		&i.ExpiresAt,
+		&i.Disabled,
+		&i.ClickCount,
+		&i.PasswordHash,
+		&i.MaxClicks,
+		&i.RemainingClicks,
+		&i.QuarantineReason,
+		&i.CampaignID,
+		&i.Targeting,
+		&i.Variants,
+		&i.NotBefore,
+	)
+	return i, err
+}
+
+const storeClicks = `-- name: StoreClicks :exec
+INSERT INTO clicks (tenant_id, short_id, clicked_at, referrer, user_agent, client_ip, variant) 
+SELECT unnest($1::text[]), unnest($2::text[]), unnest($3::timestamptz[]), unnest($4::text[]), unnest($5::text[]), unnest($6::text[]), unnest($7::text[])
+`
+
+type StoreClicksParams struct {
+	TenantIds  []string    `json:"tenant_ids"`
+	ShortIds   []string    `json:"short_ids"`
+	ClickedAts []time.Time `json:"clicked_ats"`
+	Referrers  []string    `json:"referrers"`
+	UserAgents []string    `json:"user_agents"`
+	ClientIps  []string    `json:"client_ips"`
+	Variants   []string    `json:"variants"`
+}
+
+func (q *Queries) StoreClicks(ctx context.Context, arg StoreClicksParams) error {
+	_, err := q.exec(ctx, q.storeClicksStmt, storeClicks,
+		pq.Array(arg.TenantIds),
+		pq.Array(arg.ShortIds),
+		pq.Array(arg.ClickedAts),
+		pq.Array(arg.Referrers),
+		pq.Array(arg.UserAgents),
+		pq.Array(arg.ClientIps),
+		pq.Array(arg.Variants),
+	)
+	return err
+}
+
+const storeMany = `-- name: StoreMany :many
+INSERT INTO urls (tenant_id, short_id, original_url, canonical_url, dedup) 
+SELECT $1::text, unnest($2::text[]), unnest($3::text[]), unnest($4::text[]), TRUE 
+ON CONFLICT DO NOTHING 
+RETURNING short_id
+`
+
+type StoreManyParams struct {
+	TenantID      string   `json:"tenant_id"`
+	ShortIds      []string `json:"short_ids"`
+	OriginalUrls  []string `json:"original_urls"`
+	CanonicalUrls []string `json:"canonical_urls"`
+}
+
+func (q *Queries) StoreMany(ctx context.Context, arg StoreManyParams) ([]string, error) {
+	rows, err := q.query(ctx, q.storeManyStmt, storeMany,
+		arg.TenantID,
+		pq.Array(arg.ShortIds),
+		pq.Array(arg.OriginalUrls),
+		pq.Array(arg.CanonicalUrls),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var short_id string
+		if err := rows.Scan(&short_id); err != nil {
+			return nil, err
+		}
+		items = append(items, short_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const storeWithID = `-- name: StoreWithID :exec
-INSERT INTO urls (short_id, original_url) 
-VALUES ($1, $2) 
-ON CONFLICT (original_url) DO NOTHING
+INSERT INTO urls (tenant_id, short_id, original_url, canonical_url, dedup, expires_at, password_hash, max_clicks, remaining_clicks, campaign_id, targeting, variants, not_before) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8, $9, $10, $11, $12)
 `
 
 type StoreWithIDParams struct {
-	ShortID     string `json:"short_id"`
-	OriginalUrl string `json:"original_url"`
+	TenantID     string          `json:"tenant_id"`
+	ShortID      string          `json:"short_id"`
+	OriginalUrl  string          `json:"original_url"`
+	CanonicalUrl string          `json:"canonical_url"`
+	Dedup        bool            `json:"dedup"`
+	ExpiresAt    sql.NullTime    `json:"expires_at"`
+	PasswordHash string          `json:"password_hash"`
+	MaxClicks    int64           `json:"max_clicks"`
+	CampaignID   string          `json:"campaign_id"`
+	Targeting    json.RawMessage `json:"targeting"`
+	Variants     json.RawMessage `json:"variants"`
+	NotBefore    sql.NullTime    `json:"not_before"`
 }
 
 func (q *Queries) StoreWithID(ctx context.Context, arg StoreWithIDParams) error {
-	_, err := q.exec(ctx, q.storeWithIDStmt, storeWithID, arg.ShortID, arg.OriginalUrl)
+	_, err := q.exec(ctx, q.storeWithIDStmt, storeWithID,
+		arg.TenantID,
+		arg.ShortID,
+		arg.OriginalUrl,
+		arg.CanonicalUrl,
+		arg.Dedup,
+		arg.ExpiresAt,
+		arg.PasswordHash,
+		arg.MaxClicks,
+		arg.CampaignID,
+		arg.Targeting,
+		arg.Variants,
+		arg.NotBefore,
+	)
 	return err
 }
+
+const updateCampaign = `-- name: UpdateCampaign :one
+UPDATE campaigns 
+SET name = $3, params = $4, updated_at = NOW() 
+WHERE tenant_id = $1 AND id = $2 
+RETURNING created_at, updated_at
+`
+
+type UpdateCampaignParams struct {
+	TenantID string          `json:"tenant_id"`
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Params   json.RawMessage `json:"params"`
+}
+
+type UpdateCampaignRow struct {
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) UpdateCampaign(ctx context.Context, arg UpdateCampaignParams) (UpdateCampaignRow, error) {
+	row := q.queryRow(ctx, q.updateCampaignStmt, updateCampaign,
+		arg.TenantID,
+		arg.ID,
+		arg.Name,
+		arg.Params,
+	)
+	var i UpdateCampaignRow
+	err := row.Scan(&i.CreatedAt, &i.UpdatedAt)
+	return i, err
+}
+
+const updateURL = `-- name: UpdateURL :one
+UPDATE urls AS u 
+SET original_url = $3, canonical_url = $4, dedup = FALSE, quarantine_reason = '' 
+FROM urls AS old 
+WHERE u.tenant_id = $1 AND u.short_id = $2 AND old.tenant_id = u.tenant_id AND old.short_id = u.short_id 
+RETURNING old.original_url, old.canonical_url
+`
+
+type UpdateURLParams struct {
+	TenantID     string `json:"tenant_id"`
+	ShortID      string `json:"short_id"`
+	OriginalUrl  string `json:"original_url"`
+	CanonicalUrl string `json:"canonical_url"`
+}
+
+type UpdateURLRow struct {
+	OriginalUrl  string `json:"original_url"`
+	CanonicalUrl string `json:"canonical_url"`
+}
+
+func (q *Queries) UpdateURL(ctx context.Context, arg UpdateURLParams) (UpdateURLRow, error) {
+	row := q.queryRow(ctx, q.updateURLStmt, updateURL,
+		arg.TenantID,
+		arg.ShortID,
+		arg.OriginalUrl,
+		arg.CanonicalUrl,
+	)
+	var i UpdateURLRow
+	err := row.Scan(&i.OriginalUrl, &i.CanonicalUrl)
+	return i, err
+}
@@ -1,13 +1,159 @@
 -- name: FindShortIDByURL :one
-SELECT short_id FROM urls WHERE original_url = $1 LIMIT 1;
+SELECT short_id FROM urls WHERE tenant_id = $1 AND canonical_url = $2 AND dedup LIMIT 1;
+
+-- name: FindShortIDsByURLs :many
+SELECT short_id, canonical_url FROM urls WHERE tenant_id = @tenant_id AND canonical_url = ANY(@canonical_urls::text[]) AND dedup;
 
 -- name: StoreWithID :exec
-INSERT INTO urls (short_id, original_url) 
-VALUES ($1, $2) 
-ON CONFLICT (original_url) DO NOTHING;
+INSERT INTO urls (tenant_id, short_id, original_url, canonical_url, dedup, expires_at, password_hash, max_clicks, remaining_clicks, campaign_id, targeting, variants, not_before) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8, $9, $10, $11, $12);
+
+-- name: StoreMany :many
+INSERT INTO urls (tenant_id, short_id, original_url, canonical_url, dedup) 
+SELECT @tenant_id::text, unnest(@short_ids::text[]), unnest(@original_urls::text[]), unnest(@canonical_urls::text[]), TRUE 
+ON CONFLICT DO NOTHING 
+RETURNING short_id;
 
 -- name: GetURL :one
+SELECT * FROM urls 
+WHERE tenant_id = $1 AND short_id = $2;
+
+-- name: RecordAccess :exec
+UPDATE urls AS u 
+SET last_accessed = NOW(), click_count = u.click_count + a.clicks 
+FROM unnest(@short_ids::text[], @clicks::bigint[]) AS a(short_id, clicks) 
+WHERE u.tenant_id = @tenant_id AND u.short_id = a.short_id;
+
+-- name: ConsumeClick :one
+UPDATE urls 
+SET remaining_clicks = remaining_clicks - 1 
+WHERE tenant_id = $1 AND short_id = $2 AND remaining_clicks > 0 
+RETURNING remaining_clicks;
+
+-- name: GetURLs :many
+SELECT * FROM urls 
+WHERE tenant_id = @tenant_id AND short_id = ANY(@short_ids::text[]);
+
+-- name: ListURLs :many
+SELECT * FROM urls 
+WHERE tenant_id = @tenant_id 
+  AND (created_at, short_id) > (@after_created_at::timestamptz, @after_short_id::text) 
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from)) 
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to)) 
+  AND (sqlc.narg(accessed_from)::timestamptz IS NULL OR last_accessed >= sqlc.narg(accessed_from)) 
+  AND (sqlc.narg(accessed_to)::timestamptz IS NULL OR last_accessed < sqlc.narg(accessed_to)) 
+  AND (@domain::text = '' OR lower(substring(original_url FROM '^[a-zA-Z][a-zA-Z0-9+.-]*://(?:[^/?#@]*@)?([^/?#:]*)')) = @domain) 
+ORDER BY created_at, short_id 
+LIMIT @page_size;
+
+-- name: ScanURLs :many
+SELECT * FROM urls 
+WHERE (tenant_id, short_id) > (@after_tenant_id::text, @after_short_id::text) 
+ORDER BY tenant_id, short_id 
+LIMIT @page_size;
+
+-- name: DeleteURL :one
+DELETE FROM urls 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING *;
+
+-- name: UpdateURL :one
+UPDATE urls AS u 
+SET original_url = $3, canonical_url = $4, dedup = FALSE, quarantine_reason = '' 
+FROM urls AS old 
+WHERE u.tenant_id = $1 AND u.short_id = $2 AND old.tenant_id = u.tenant_id AND old.short_id = u.short_id 
+RETURNING old.original_url, old.canonical_url;
+
+-- name: SetDisabled :one
+UPDATE urls 
+SET disabled = $3, dedup = dedup AND NOT $3 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING *;
+
+-- name: QuarantineURL :one
 UPDATE urls 
-SET last_accessed = NOW() 
-WHERE short_id = $1 
-RETURNING original_url; 
\ No newline at end of file
+SET quarantine_reason = $3, dedup = FALSE 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING *;
+
+-- name: AddTags :exec
+INSERT INTO url_tags (tenant_id, short_id, tag) 
+SELECT @tenant_id::text, @short_id::text, unnest(@tags::text[]) 
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveTags :exec
+DELETE FROM url_tags 
+WHERE tenant_id = @tenant_id AND short_id = @short_id AND tag = ANY(@tags::text[]);
+
+-- name: GetTags :many
+SELECT short_id, tag FROM url_tags 
+WHERE tenant_id = @tenant_id AND short_id = ANY(@short_ids::text[]) 
+ORDER BY short_id, tag;
+
+-- name: ListURLsByTag :many
+SELECT u.* FROM urls AS u 
+JOIN url_tags AS t ON t.tenant_id = u.tenant_id AND t.short_id = u.short_id 
+WHERE t.tenant_id = @tenant_id AND t.tag = @tag AND t.short_id > @after_short_id::text 
+ORDER BY t.short_id 
+LIMIT @page_size;
+
+-- name: CreateCampaign :one
+INSERT INTO campaigns (tenant_id, id, name, params) 
+VALUES ($1, $2, $3, $4) 
+RETURNING created_at, updated_at;
+
+-- name: GetCampaign :one
+SELECT * FROM campaigns 
+WHERE tenant_id = $1 AND id = $2;
+
+-- name: UpdateCampaign :one
+UPDATE campaigns 
+SET name = $3, params = $4, updated_at = NOW() 
+WHERE tenant_id = $1 AND id = $2 
+RETURNING created_at, updated_at;
+
+-- name: SetURLCampaign :one
+UPDATE urls 
+SET campaign_id = $3, dedup = dedup AND $3 = '' 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING *;
+
+-- name: SetURLTargeting :one
+UPDATE urls 
+SET targeting = $3, dedup = dedup AND $3 = '[]'::jsonb 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING *;
+
+-- name: SetURLVariants :one
+UPDATE urls 
+SET variants = $3, dedup = dedup AND $3 = '[]'::jsonb 
+WHERE tenant_id = $1 AND short_id = $2 
+RETURNING *;
+
+-- name: StoreClicks :exec
+INSERT INTO clicks (tenant_id, short_id, clicked_at, referrer, user_agent, client_ip, variant) 
+SELECT unnest(@tenant_ids::text[]), unnest(@short_ids::text[]), unnest(@clicked_ats::timestamptz[]), unnest(@referrers::text[]), unnest(@user_agents::text[]), unnest(@client_ips::text[]), unnest(@variants::text[]);
+
+-- name: GetAPIKey :one
+SELECT * FROM api_keys 
+WHERE key_hash = $1 AND NOT disabled;
+
+-- name: ReserveLinks :one
+INSERT INTO tenant_usage AS u (tenant_id, day, daily_links, total_links) 
+VALUES (@tenant_id, @day, @links, @links) 
+ON CONFLICT (tenant_id) DO UPDATE SET 
+    daily_links = CASE WHEN u.day = EXCLUDED.day THEN u.daily_links ELSE 0 END + EXCLUDED.daily_links, 
+    total_links = u.total_links + EXCLUDED.total_links, 
+    day = EXCLUDED.day 
+WHERE (@daily_limit::bigint <= 0 OR CASE WHEN u.day = EXCLUDED.day THEN u.daily_links ELSE 0 END + EXCLUDED.daily_links <= @daily_limit::bigint) 
+AND (@total_limit::bigint <= 0 OR u.total_links + EXCLUDED.total_links <= @total_limit::bigint) 
+RETURNING day, daily_links, total_links;
+
+-- name: ReleaseLinks :exec
+UPDATE tenant_usage 
+SET daily_links = CASE WHEN day = @day THEN GREATEST(daily_links - @links, 0) ELSE daily_links END, 
+    total_links = GREATEST(total_links - @links, 0) 
+WHERE tenant_id = @tenant_id;
+
+-- name: GetUsage :one
+SELECT * FROM tenant_usage WHERE tenant_id = $1;
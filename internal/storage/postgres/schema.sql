@@ -1,6 +1,72 @@
 CREATE TABLE IF NOT EXISTS urls (
-    short_id VARCHAR(10) PRIMARY KEY,
-    original_url TEXT NOT NULL UNIQUE,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
-    last_accessed TIMESTAMP WITH TIME ZONE
-); 
\ No newline at end of file
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    short_id VARCHAR(255) NOT NULL,
+    original_url TEXT NOT NULL,
+    canonical_url TEXT NOT NULL DEFAULT '',
+    dedup BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_accessed TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    click_count BIGINT NOT NULL DEFAULT 0,
+    password_hash TEXT NOT NULL DEFAULT '',
+    max_clicks BIGINT NOT NULL DEFAULT 0,
+    remaining_clicks BIGINT NOT NULL DEFAULT 0,
+    quarantine_reason TEXT NOT NULL DEFAULT '',
+    campaign_id VARCHAR(64) NOT NULL DEFAULT '',
+    targeting JSONB NOT NULL DEFAULT '[]',
+    variants JSONB NOT NULL DEFAULT '[]',
+    not_before TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (tenant_id, short_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_urls_canonical_url ON urls (tenant_id, canonical_url) WHERE dedup;
+
+CREATE TABLE IF NOT EXISTS clicks (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id VARCHAR(64) NOT NULL DEFAULT 'default',
+    short_id VARCHAR(255) NOT NULL,
+    clicked_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    referrer TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    client_ip TEXT NOT NULL DEFAULT '',
+    variant VARCHAR(32) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_clicks_short_id_clicked_at ON clicks (tenant_id, short_id, clicked_at);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id VARCHAR(64) PRIMARY KEY,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS tenant_usage (
+    tenant_id VARCHAR(64) PRIMARY KEY,
+    day DATE NOT NULL,
+    daily_links BIGINT NOT NULL DEFAULT 0,
+    total_links BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS url_tags (
+    tenant_id VARCHAR(64) NOT NULL,
+    short_id VARCHAR(255) NOT NULL,
+    tag VARCHAR(64) NOT NULL,
+    PRIMARY KEY (tenant_id, tag, short_id),
+    FOREIGN KEY (tenant_id, short_id) REFERENCES urls (tenant_id, short_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_url_tags_short_id ON url_tags (tenant_id, short_id);
+
+CREATE TABLE IF NOT EXISTS campaigns (
+    tenant_id VARCHAR(64) NOT NULL,
+    id VARCHAR(64) NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    params JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, id)
+);
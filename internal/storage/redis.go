@@ -2,20 +2,24 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/hohotang/shortlink-core/internal/logger"
 	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/tenant"
 	"go.uber.org/zap"
 )
 
 // RedisStorage implements URLStorage with Redis
+// Keys are scoped to the tenant of the request context
 type RedisStorage struct {
 	client *redis.Client
 	ttl    time.Duration
-	ctx    context.Context
 }
 
 // NewRedisStorage creates a new RedisStorage instance
@@ -49,18 +53,17 @@ func NewRedisStorage(redisURL string, ttl int) (*RedisStorage, error) {
 	return &RedisStorage{
 		client: client,
 		ttl:    time.Duration(ttl) * time.Second,
-		ctx:    ctx,
 	}, nil
 }
 
 // FindShortIDByURL checks if a URL already has a short ID in Redis
-func (s *RedisStorage) FindShortIDByURL(originalURL string) (string, error) {
+func (s *RedisStorage) FindShortIDByURL(ctx context.Context, canonicalURL string) (string, error) {
 	log := logger.L()
 
-	shortID, err := s.client.HGet(s.ctx, models.ReverseURLsKey, originalURL).Result()
+	shortID, err := s.client.HGet(ctx, reverseKey(ctx), canonicalURL).Result()
 	if err != nil {
 		if err == redis.Nil {
-			log.Debug("No existing short ID found in Redis", zap.String("url", originalURL))
+			log.Debug("No existing short ID found in Redis", zap.String("url", canonicalURL))
 			return "", ErrNotFound
 		}
 		log.Error("Failed to query Redis for existing URL", zap.Error(err))
@@ -68,7 +71,7 @@ func (s *RedisStorage) FindShortIDByURL(originalURL string) (string, error) {
 	}
 
 	// Check if the shortID actually exists (in case of inconsistency)
-	exists, err := s.client.Exists(s.ctx, models.ShortIDKeyPrefix+shortID).Result()
+	exists, err := s.client.Exists(ctx, urlKey(ctx, shortID)).Result()
 	if err != nil {
 		log.Error("Failed to check if short ID exists in Redis", zap.Error(err))
 		return "", fmt.Errorf("failed to check if short ID exists: %w", err)
@@ -79,56 +82,943 @@ func (s *RedisStorage) FindShortIDByURL(originalURL string) (string, error) {
 		// Let's clean up the inconsistency
 		log.Warn("Inconsistent Redis state: cleaning up stale reverse mapping",
 			zap.String("shortID", shortID),
-			zap.String("url", originalURL))
-		s.client.HDel(s.ctx, models.ReverseURLsKey, originalURL)
+			zap.String("url", canonicalURL))
+		s.client.HDel(ctx, reverseKey(ctx), canonicalURL)
 		return "", ErrNotFound
 	}
 
 	log.Debug("Found existing short ID in Redis",
 		zap.String("shortID", shortID),
-		zap.String("url", originalURL))
+		zap.String("url", canonicalURL))
 	return shortID, nil
 }
 
 // Find implements URLStorage.Find
-func (s *RedisStorage) Find(ctx context.Context, originalURL string) (string, error) {
-	if originalURL == "" {
+func (s *RedisStorage) Find(ctx context.Context, canonicalURL string) (string, error) {
+	if canonicalURL == "" {
 		return "", ErrInvalidURL
 	}
 
-	shortID, err := s.client.Get(ctx, originalURL).Result()
-	if err == redis.Nil {
-		return "", ErrNotFound
+	return s.FindShortIDByURL(ctx, canonicalURL)
+}
+
+// FindMany implements URLStorage.FindMany
+func (s *RedisStorage) FindMany(ctx context.Context, canonicalURLs []string) (map[string]string, error) {
+	found := make(map[string]string, len(canonicalURLs))
+	if len(canonicalURLs) == 0 {
+		return found, nil
 	}
+
+	values, err := s.client.HMGet(ctx, reverseKey(ctx), canonicalURLs...).Result()
 	if err != nil {
-		return "", fmt.Errorf("failed to get URL from Redis: %w", err)
+		return nil, fmt.Errorf("failed to query for existing URLs: %w", err)
 	}
-	return shortID, nil
+
+	// Check that the short IDs actually exist, as FindShortIDByURL does
+	pipe := s.client.Pipeline()
+	checks := make(map[string]*redis.IntCmd)
+	for i, value := range values {
+		if shortID, ok := value.(string); ok {
+			found[canonicalURLs[i]] = shortID
+			checks[canonicalURLs[i]] = pipe.Exists(ctx, urlKey(ctx, shortID))
+		}
+	}
+	if len(checks) == 0 {
+		return found, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to check if short IDs exist: %w", err)
+	}
+
+	for canonicalURL, check := range checks {
+		if check.Val() == 0 {
+			delete(found, canonicalURL)
+		}
+	}
+	return found, nil
 }
 
 // StoreWithID implements URLStorage.StoreWithID
-func (s *RedisStorage) StoreWithID(ctx context.Context, shortID string, originalURL string) error {
-	if originalURL == "" {
+func (s *RedisStorage) StoreWithID(ctx context.Context, link *models.URL) error {
+	if link.OriginalURL == "" {
 		return ErrInvalidURL
 	}
 
-	err := s.client.Set(ctx, shortID, originalURL, s.ttl).Err()
+	// A legacy link still holds its short ID; moving it makes the claim below fail
+	if _, err := s.getLegacy(ctx, []string{link.ShortID}); err != nil {
+		return err
+	}
+
+	stored := *link
+	stored.CreatedAt = time.Now()
+	stored.RemainingClicks = link.MaxClicks
+	data, err := json.Marshal(&stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode URL for Redis: %w", err)
+	}
+
+	// Claim the short ID together with its click countdown and reverse mapping
+	claimed, err := s.storeLink(ctx, s.client, link, data, s.ttlFor(link)).Int64()
 	if err != nil {
 		return fmt.Errorf("failed to store URL in Redis: %w", err)
 	}
+	if claimed != 1 {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// storeLink queues storeLinkScript for a link on c, a client or pipeline
+// The countdown lives in its own key so ConsumeClick can decrement it atomically
+func (s *RedisStorage) storeLink(ctx context.Context, c redis.Scripter, link *models.URL, data []byte, ttl time.Duration) *redis.Cmd {
+	var maxClicks int64
+	if link.ClickLimited() {
+		maxClicks = link.MaxClicks
+	}
+	var dedupKey string
+	if link.Dedup {
+		dedupKey = link.DedupKey()
+	}
+	return c.Eval(ctx, storeLinkScript,
+		[]string{urlKey(ctx, link.ShortID), remainingClicksKey(ctx, link.ShortID), reverseKey(ctx)},
+		data, ttl.Milliseconds(), maxClicks, dedupKey, link.ShortID, urlKey(ctx, ""))
+}
+
+// StoreMany implements URLStorage.StoreMany
+func (s *RedisStorage) StoreMany(ctx context.Context, links []*models.URL) ([]string, error) {
+	stored := make([]string, 0, len(links))
+	if len(links) == 0 {
+		return stored, nil
+	}
+
+	// Legacy links still hold their short IDs; moving them makes their claims fail
+	shortIDs := make([]string, len(links))
+	for i, link := range links {
+		shortIDs[i] = link.ShortID
+	}
+	if _, err := s.getLegacy(ctx, shortIDs); err != nil {
+		return nil, err
+	}
+
+	// Claim all short IDs, each with its reverse mapping, in one pipeline
+	pipe := s.client.Pipeline()
+	claims := make([]*redis.Cmd, len(links))
+	for i, link := range links {
+		claim := &models.URL{ShortID: link.ShortID, OriginalURL: link.OriginalURL, CanonicalURL: link.CanonicalURL, Dedup: true, CreatedAt: time.Now()}
+		data, err := json.Marshal(claim)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode URL for Redis: %w", err)
+		}
+		claims[i] = s.storeLink(ctx, pipe, claim, data, s.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to store URLs in Redis: %w", err)
+	}
+
+	for i, link := range links {
+		if claimed, _ := claims[i].Int64(); claimed == 1 {
+			stored = append(stored, link.ShortID)
+		}
+	}
+	return stored, nil
+}
+
+// cacheMany writes links to Redis in a single pipeline, overwriting existing entries
+func (s *RedisStorage) cacheMany(ctx context.Context, links []*models.URL) error {
+	pipe := s.client.Pipeline()
+	for _, link := range links {
+		data, err := json.Marshal(link)
+		if err != nil {
+			return fmt.Errorf("failed to encode URL for Redis: %w", err)
+		}
+		pipe.Set(ctx, urlKey(ctx, link.ShortID), data, s.ttlFor(link))
+		if link.Dedup {
+			pipe.HSet(ctx, reverseKey(ctx), link.DedupKey(), link.ShortID)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to cache URLs in Redis: %w", err)
+	}
+	return nil
+}
+
+// cacheReverseMany records many dedup short IDs, keyed by URL, in the reverse mapping
+func (s *RedisStorage) cacheReverseMany(ctx context.Context, shortIDs map[string]string) error {
+	if len(shortIDs) == 0 {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(shortIDs))
+	for canonicalURL, shortID := range shortIDs {
+		values[canonicalURL] = shortID
+	}
+	if err := s.client.HSet(ctx, reverseKey(ctx), values).Err(); err != nil {
+		return fmt.Errorf("failed to cache reverse mappings in Redis: %w", err)
+	}
+	return nil
+}
+
+// cache writes a link to Redis, overwriting any existing entry
+// It is used by CombinedStorage, where PostgreSQL owns short ID uniqueness
+func (s *RedisStorage) cache(ctx context.Context, link *models.URL) error {
+	data, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("failed to encode URL for Redis: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, urlKey(ctx, link.ShortID), data, s.ttlFor(link))
+	if link.Dedup {
+		pipe.HSet(ctx, reverseKey(ctx), link.DedupKey(), link.ShortID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to cache URL in Redis: %w", err)
+	}
+	return nil
+}
+
+// ttlFor returns the cache TTL for a link, which never outlives the link's own expiry
+func (s *RedisStorage) ttlFor(link *models.URL) time.Duration {
+	if link.ExpiresAt == nil {
+		return s.ttl
+	}
+
+	remaining := time.Until(*link.ExpiresAt)
+	if remaining >= s.ttl {
+		return s.ttl
+	}
+	// Redis treats a zero TTL as "no expiry", so keep already expired links briefly
+	if remaining < time.Second {
+		return time.Second
+	}
+	return remaining
+}
+
+// cacheReverse records the dedup short ID of a URL in the reverse mapping
+func (s *RedisStorage) cacheReverse(ctx context.Context, canonicalURL string, shortID string) error {
+	if err := s.client.HSet(ctx, reverseKey(ctx), canonicalURL, shortID).Err(); err != nil {
+		return fmt.Errorf("failed to cache reverse mapping in Redis: %w", err)
+	}
 	return nil
 }
 
 // Get implements URLStorage.Get
-func (s *RedisStorage) Get(ctx context.Context, shortID string) (string, error) {
-	originalURL, err := s.client.Get(ctx, shortID).Result()
+func (s *RedisStorage) Get(ctx context.Context, shortID string) (*models.URL, error) {
+	link, err := s.getCached(ctx, shortID)
+	if err != ErrNotFound {
+		return link, err
+	}
+
+	legacy, err := s.getLegacy(ctx, []string{shortID})
+	if err != nil {
+		return nil, err
+	}
+	if link, ok := legacy[shortID]; ok {
+		return link, nil
+	}
+	return nil, ErrNotFound
+}
+
+// getCached reads a link in the current layout only
+// CombinedStorage reads the cache through it, as PostgreSQL holds the
+// current state of links written before that layout
+func (s *RedisStorage) getCached(ctx context.Context, shortID string) (*models.URL, error) {
+	data, err := s.client.Get(ctx, urlKey(ctx, shortID)).Bytes()
 	if err == redis.Nil {
-		return "", ErrNotFound
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get URL from Redis: %w", err)
+	}
+	return decodeLink(shortID, data)
+}
+
+// getLegacy reads links written before links were stored as JSON records:
+// the plain original URL under the bare short ID, in what is now the default
+// tenant. Each one found is moved to the current layout, keeping its TTL, so
+// every other operation sees it from then on
+func (s *RedisStorage) getLegacy(ctx context.Context, shortIDs []string) (map[string]*models.URL, error) {
+	found := make(map[string]*models.URL)
+	if len(shortIDs) == 0 || tenant.FromContext(ctx) != tenant.DefaultID {
+		return found, nil
+	}
+
+	// MGET reads keys of other types as missing, so a hash that happens to
+	// share its name with a short ID is skipped
+	values, err := s.client.MGet(ctx, shortIDs...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legacy URLs from Redis: %w", err)
+	}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok || data == "" {
+			continue
+		}
+		link, err := decodeLink(shortIDs[i], []byte(data))
+		if err != nil {
+			return nil, err
+		}
+		if err := s.migrateLegacy(ctx, link); err != nil {
+			return nil, err
+		}
+		found[shortIDs[i]] = link
+	}
+	return found, nil
+}
+
+// migrateLegacy moves a link read by getLegacy to its current key
+func (s *RedisStorage) migrateLegacy(ctx context.Context, link *models.URL) error {
+	ttl, err := s.client.PTTL(ctx, link.ShortID).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get legacy URL TTL from Redis: %w", err)
+	}
+	if ttl <= 0 {
+		ttl = s.ttl
+	}
+	data, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("failed to encode URL for Redis: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.SetNX(ctx, urlKey(ctx, link.ShortID), data, ttl)
+	pipe.Del(ctx, link.ShortID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to migrate legacy URL in Redis: %w", err)
+	}
+	logger.L().Info("Migrated legacy URL in Redis", zap.String("shortID", link.ShortID))
+	return nil
+}
+
+// decodeLink decodes a stored link
+// A value that is not a JSON object is a plain original URL, as links were
+// stored before they carried more than their destination
+func decodeLink(shortID string, data []byte) (*models.URL, error) {
+	if len(data) > 0 && data[0] != '{' {
+		return &models.URL{ShortID: shortID, OriginalURL: string(data)}, nil
+	}
+
+	link := &models.URL{}
+	if err := json.Unmarshal(data, link); err != nil {
+		return nil, fmt.Errorf("failed to decode URL from Redis: %w", err)
+	}
+	return link, nil
+}
+
+// GetInfo implements URLStorage.GetInfo
+// The access stats and the click countdown live in their own keys, so they
+// are read from there
+func (s *RedisStorage) GetInfo(ctx context.Context, shortID string) (*models.URL, error) {
+	link, err := s.Get(ctx, shortID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.client.HMGet(ctx, accessKey(ctx, shortID), "clicks", "last").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access stats from Redis: %w", err)
+	}
+	link.ClickCount = parseCounter(stats[0])
+	if last := parseCounter(stats[1]); last > 0 {
+		lastAccessed := time.UnixMilli(last).UTC()
+		link.LastAccessed = &lastAccessed
+	}
+
+	if !link.ClickLimited() {
+		return link, nil
+	}
+	remaining, err := s.client.Get(ctx, remainingClicksKey(ctx, shortID)).Int64()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get click limit from Redis: %w", err)
+	}
+	link.RemainingClicks = remaining
+	return link, nil
+}
+
+// RecordAccess implements URLStorage.RecordAccess
+// The stats are kept in their own key, so counting never rewrites the link
+func (s *RedisStorage) RecordAccess(ctx context.Context, clicks map[string]int64) error {
+	if len(clicks) == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	pipe := s.client.Pipeline()
+	for shortID, n := range clicks {
+		pipe.Eval(ctx, recordAccessScript, []string{urlKey(ctx, shortID), accessKey(ctx, shortID)}, now, n)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record access in Redis: %w", err)
+	}
+	return nil
+}
+
+// ConsumeClick implements URLStorage.ConsumeClick
+func (s *RedisStorage) ConsumeClick(ctx context.Context, shortID string) (int64, error) {
+	remaining, err := s.client.Eval(ctx, decrIfPositiveScript, []string{remainingClicksKey(ctx, shortID)}).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to consume click in Redis: %w", err)
+	}
+	if remaining < 0 {
+		return 0, ErrClicksExhausted
+	}
+	return remaining, nil
+}
+
+// ReserveLinks implements UsageStorage.ReserveLinks
+// Counters have no TTL, so they persist as long as Redis does
+func (s *RedisStorage) ReserveLinks(ctx context.Context, day time.Time, n int64, quota models.Quota) (*models.Usage, error) {
+	result, err := s.client.Eval(ctx, reserveLinksScript, []string{usageKey(ctx)},
+		day.Format(usageDayLayout), n, quota.Daily, quota.Total).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve links in Redis: %w", err)
+	}
+	if result[0] == 0 {
+		return nil, ErrQuotaExceeded
+	}
+	return &models.Usage{Day: day, DailyLinks: result[1], TotalLinks: result[2]}, nil
+}
+
+// ReleaseLinks implements UsageStorage.ReleaseLinks
+func (s *RedisStorage) ReleaseLinks(ctx context.Context, day time.Time, n int64) error {
+	if err := s.client.Eval(ctx, releaseLinksScript, []string{usageKey(ctx)}, day.Format(usageDayLayout), n).Err(); err != nil {
+		return fmt.Errorf("failed to release links in Redis: %w", err)
+	}
+	return nil
+}
+
+// GetUsage implements UsageStorage.GetUsage
+func (s *RedisStorage) GetUsage(ctx context.Context, day time.Time) (*models.Usage, error) {
+	values, err := s.client.HMGet(ctx, usageKey(ctx), "day", "daily", "total").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage from Redis: %w", err)
+	}
+
+	usage := &models.Usage{Day: day}
+	if storedDay, _ := values[0].(string); storedDay == day.Format(usageDayLayout) {
+		usage.DailyLinks = parseCounter(values[1])
+	}
+	usage.TotalLinks = parseCounter(values[2])
+	return usage, nil
+}
+
+// parseCounter reads a counter returned by HMGET, treating a missing field as zero
+func parseCounter(value interface{}) int64 {
+	text, _ := value.(string)
+	count, _ := strconv.ParseInt(text, 10, 64)
+	return count
+}
+
+// GetMany implements URLStorage.GetMany
+func (s *RedisStorage) GetMany(ctx context.Context, shortIDs []string) (map[string]*models.URL, error) {
+	found, err := s.getManyCached(ctx, shortIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	misses := make([]string, 0, len(shortIDs)-len(found))
+	for _, shortID := range shortIDs {
+		if _, ok := found[shortID]; !ok {
+			misses = append(misses, shortID)
+		}
+	}
+	legacy, err := s.getLegacy(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	for shortID, link := range legacy {
+		found[shortID] = link
+	}
+	return found, nil
+}
+
+// getManyCached reads many links in the current layout only, like getCached
+func (s *RedisStorage) getManyCached(ctx context.Context, shortIDs []string) (map[string]*models.URL, error) {
+	found := make(map[string]*models.URL, len(shortIDs))
+	if len(shortIDs) == 0 {
+		return found, nil
+	}
+
+	keys := make([]string, len(shortIDs))
+	for i, shortID := range shortIDs {
+		keys[i] = urlKey(ctx, shortID)
 	}
+	values, err := s.client.MGet(ctx, keys...).Result()
 	if err != nil {
-		return "", fmt.Errorf("failed to get URL from Redis: %w", err)
+		return nil, fmt.Errorf("failed to get URLs from Redis: %w", err)
 	}
-	return originalURL, nil
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		link, err := decodeLink(shortIDs[i], []byte(data))
+		if err != nil {
+			return nil, err
+		}
+		found[shortIDs[i]] = link
+	}
+	return found, nil
+}
+
+// List implements URLStorage.List
+// Redis keeps no ordered index of links, so listing is not supported
+func (s *RedisStorage) List(ctx context.Context, opts ListOptions) ([]*models.URL, error) {
+	return nil, ErrUnsupported
+}
+
+// Delete implements URLStorage.Delete
+func (s *RedisStorage) Delete(ctx context.Context, shortID string) error {
+	link, err := s.Get(ctx, shortID)
+	if err != nil {
+		return err
+	}
+	if err := s.evict(ctx, link); err != nil {
+		return err
+	}
+
+	tags, err := s.client.SMembers(ctx, linkTagsKey(ctx, shortID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get tags from Redis: %w", err)
+	}
+	return s.RemoveTags(ctx, shortID, tags)
+}
+
+// AddTags implements TagStorage.AddTags
+// Each tag is a sorted set of short IDs with equal scores, so it pages in
+// lexicographic order
+func (s *RedisStorage) AddTags(ctx context.Context, shortID string, tags []string) error {
+	exists, err := s.client.Exists(ctx, urlKey(ctx, shortID)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check URL in Redis: %w", err)
+	}
+	if exists == 0 {
+		return ErrNotFound
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	pipe := s.client.TxPipeline()
+	for _, tag := range tags {
+		pipe.SAdd(ctx, linkTagsKey(ctx, shortID), tag)
+		pipe.ZAdd(ctx, tagKey(ctx, tag), &redis.Z{Member: shortID})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to add tags in Redis: %w", err)
+	}
+	return nil
+}
+
+// RemoveTags implements TagStorage.RemoveTags
+func (s *RedisStorage) RemoveTags(ctx context.Context, shortID string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	pipe := s.client.TxPipeline()
+	for _, tag := range tags {
+		pipe.SRem(ctx, linkTagsKey(ctx, shortID), tag)
+		pipe.ZRem(ctx, tagKey(ctx, tag), shortID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to remove tags in Redis: %w", err)
+	}
+	return nil
+}
+
+// GetTags implements TagStorage.GetTags
+func (s *RedisStorage) GetTags(ctx context.Context, shortIDs []string) (map[string][]string, error) {
+	found := make(map[string][]string, len(shortIDs))
+	if len(shortIDs) == 0 {
+		return found, nil
+	}
+
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.StringSliceCmd, len(shortIDs))
+	for i, shortID := range shortIDs {
+		cmds[i] = pipe.SMembers(ctx, linkTagsKey(ctx, shortID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get tags from Redis: %w", err)
+	}
+
+	for i, cmd := range cmds {
+		if tags := cmd.Val(); len(tags) > 0 {
+			sort.Strings(tags)
+			found[shortIDs[i]] = tags
+		}
+	}
+	return found, nil
+}
+
+// ListByTag implements TagStorage.ListByTag
+// Links that expired from Redis are dropped from the tag as they are met
+func (s *RedisStorage) ListByTag(ctx context.Context, tag string, afterShortID string, limit int) ([]*models.URL, error) {
+	links := make([]*models.URL, 0, limit)
+	for len(links) < limit {
+		from := "-"
+		if afterShortID != "" {
+			from = "(" + afterShortID
+		}
+		want := limit - len(links)
+		shortIDs, err := s.client.ZRangeByLex(ctx, tagKey(ctx, tag), &redis.ZRangeBy{
+			Min:   from,
+			Max:   "+",
+			Count: int64(want),
+		}).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tag from Redis: %w", err)
+		}
+
+		found, err := s.GetMany(ctx, shortIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, shortID := range shortIDs {
+			if link, ok := found[shortID]; ok {
+				links = append(links, link)
+			} else if err := s.RemoveTags(ctx, shortID, []string{tag}); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(shortIDs) < want {
+			break
+		}
+		afterShortID = shortIDs[len(shortIDs)-1]
+	}
+	return links, nil
+}
+
+// SetDisabled implements URLStorage.SetDisabled
+func (s *RedisStorage) SetDisabled(ctx context.Context, shortID string, disabled bool) error {
+	link, err := s.Get(ctx, shortID)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	if disabled {
+		s.unindex(ctx, pipe, link)
+		link.Dedup = false
+	}
+	link.Disabled = disabled
+
+	return s.rewrite(ctx, pipe, link)
+}
+
+// Update implements URLStorage.Update
+func (s *RedisStorage) Update(ctx context.Context, shortID string, originalURL string, canonicalURL string) error {
+	if originalURL == "" {
+		return ErrInvalidURL
+	}
+
+	link, err := s.Get(ctx, shortID)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	s.unindex(ctx, pipe, link)
+	link.OriginalURL = originalURL
+	link.CanonicalURL = canonicalURL
+	link.Dedup = false
+	link.QuarantineReason = ""
+
+	return s.rewrite(ctx, pipe, link)
+}
+
+// CreateCampaign implements CampaignStorage.CreateCampaign
+// Campaigns are kept without a TTL, as links refer to them
+func (s *RedisStorage) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	now := time.Now()
+	campaign.CreatedAt = now
+	campaign.UpdatedAt = now
+
+	data, err := json.Marshal(campaign)
+	if err != nil {
+		return fmt.Errorf("failed to encode campaign for Redis: %w", err)
+	}
+	created, err := s.client.SetNX(ctx, campaignKey(ctx, campaign.ID), data, 0).Result()
+	if err != nil {
+		return fmt.Errorf("failed to store campaign in Redis: %w", err)
+	}
+	if !created {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// GetCampaign implements CampaignStorage.GetCampaign
+func (s *RedisStorage) GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error) {
+	data, err := s.client.Get(ctx, campaignKey(ctx, campaignID)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign from Redis: %w", err)
+	}
+
+	campaign := &models.Campaign{}
+	if err := json.Unmarshal(data, campaign); err != nil {
+		return nil, fmt.Errorf("failed to decode campaign from Redis: %w", err)
+	}
+	return campaign, nil
+}
+
+// UpdateCampaign implements CampaignStorage.UpdateCampaign
+func (s *RedisStorage) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	existing, err := s.GetCampaign(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+	campaign.CreatedAt = existing.CreatedAt
+	campaign.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(campaign)
+	if err != nil {
+		return fmt.Errorf("failed to encode campaign for Redis: %w", err)
+	}
+	if err := s.client.Set(ctx, campaignKey(ctx, campaign.ID), data, redis.KeepTTL).Err(); err != nil {
+		return fmt.Errorf("failed to update campaign in Redis: %w", err)
+	}
+	return nil
+}
+
+// SetCampaign implements CampaignStorage.SetCampaign
+func (s *RedisStorage) SetCampaign(ctx context.Context, shortID string, campaignID string) error {
+	link, err := s.Get(ctx, shortID)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	if campaignID != "" {
+		s.unindex(ctx, pipe, link)
+		link.Dedup = false
+	}
+	link.CampaignID = campaignID
+
+	return s.rewrite(ctx, pipe, link)
+}
+
+// SetTargeting implements TargetingStorage.SetTargeting
+func (s *RedisStorage) SetTargeting(ctx context.Context, shortID string, rules []models.TargetingRule) error {
+	link, err := s.Get(ctx, shortID)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	if len(rules) > 0 {
+		s.unindex(ctx, pipe, link)
+		link.Dedup = false
+	}
+	link.Targeting = rules
+
+	return s.rewrite(ctx, pipe, link)
+}
+
+// SetVariants implements TargetingStorage.SetVariants
+func (s *RedisStorage) SetVariants(ctx context.Context, shortID string, variants []models.Variant) error {
+	link, err := s.Get(ctx, shortID)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	if len(variants) > 0 {
+		s.unindex(ctx, pipe, link)
+		link.Dedup = false
+	}
+	link.Variants = variants
+
+	return s.rewrite(ctx, pipe, link)
+}
+
+// cacheCampaign stores a copy of a campaign read from the primary storage
+func (s *RedisStorage) cacheCampaign(ctx context.Context, campaign *models.Campaign) error {
+	data, err := json.Marshal(campaign)
+	if err != nil {
+		return fmt.Errorf("failed to encode campaign for Redis: %w", err)
+	}
+	if err := s.client.Set(ctx, campaignKey(ctx, campaign.ID), data, s.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache campaign in Redis: %w", err)
+	}
+	return nil
+}
+
+// evictCampaign removes a cached campaign
+func (s *RedisStorage) evictCampaign(ctx context.Context, campaignID string) error {
+	if err := s.client.Del(ctx, campaignKey(ctx, campaignID)).Err(); err != nil {
+		return fmt.Errorf("failed to evict campaign from Redis: %w", err)
+	}
+	return nil
+}
+
+// rewrite queues an overwrite of a link that keeps its TTL and executes the pipeline
+func (s *RedisStorage) rewrite(ctx context.Context, pipe redis.Pipeliner, link *models.URL) error {
+	data, err := json.Marshal(link)
+	if err != nil {
+		return fmt.Errorf("failed to encode URL for Redis: %w", err)
+	}
+	pipe.Set(ctx, urlKey(ctx, link.ShortID), data, redis.KeepTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update URL in Redis: %w", err)
+	}
+	return nil
+}
+
+// evict removes both the forward and the reverse entry of a link
+func (s *RedisStorage) evict(ctx context.Context, link *models.URL) error {
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, urlKey(ctx, link.ShortID), remainingClicksKey(ctx, link.ShortID), accessKey(ctx, link.ShortID))
+	s.unindex(ctx, pipe, link)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to evict URL from Redis: %w", err)
+	}
+	return nil
+}
+
+// unindex queues removal of the reverse mapping of a link
+// The mapping is only removed if it still points to this link
+func (s *RedisStorage) unindex(ctx context.Context, pipe redis.Pipeliner, link *models.URL) {
+	pipe.Eval(ctx, hdelIfEqualScript, []string{reverseKey(ctx)}, link.DedupKey(), link.ShortID)
+}
+
+// hdelIfEqualScript deletes a hash field only if it holds the expected value
+const hdelIfEqualScript = `
+if redis.call("HGET", KEYS[1], ARGV[1]) == ARGV[2] then
+	return redis.call("HDEL", KEYS[1], ARGV[1])
+end
+return 0`
+
+// storeLinkScript claims the short ID KEYS[1] for the encoded link ARGV[1]
+// with a TTL of ARGV[2] ms. A positive ARGV[3] starts the click countdown
+// KEYS[2] with the same TTL, and a non-empty ARGV[4] maps that dedup key to
+// the short ID ARGV[5] in the reverse hash KEYS[3]. ARGV[6] is the key prefix
+// of the tenant's links, used to tell a live dedup link from a stale mapping.
+// It returns 1 after a claim, 0 if the short ID is taken, or -1 if the dedup
+// key already has a live link; nothing is written unless it returns 1
+const storeLinkScript = `
+if ARGV[4] ~= "" then
+	local owner = redis.call("HGET", KEYS[3], ARGV[4])
+	if owner and redis.call("EXISTS", ARGV[6] .. owner) == 1 then
+		return -1
+	end
+end
+if not redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2], "NX") then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[2], ARGV[3], "PX", ARGV[2])
+end
+if ARGV[4] ~= "" then
+	redis.call("HSET", KEYS[3], ARGV[4], ARGV[5])
+end
+return 1`
+
+// decrIfPositiveScript decrements a counter only if it is above zero
+// It returns the new value, or -1 if the counter is missing or already zero
+const decrIfPositiveScript = `
+local remaining = tonumber(redis.call("GET", KEYS[1]) or "0")
+if remaining <= 0 then
+	return -1
+end
+return redis.call("DECR", KEYS[1])`
+
+// recordAccessScript counts ARGV[2] resolutions of the link KEYS[1] in its
+// access stats hash KEYS[2] and sets the last access time to ARGV[1] (Unix ms).
+// The stats expire with the link; links that no longer exist are skipped
+const recordAccessScript = `
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl == -2 then
+	return 0
+end
+redis.call("HINCRBY", KEYS[2], "clicks", ARGV[2])
+redis.call("HSET", KEYS[2], "last", ARGV[1])
+if ttl > 0 then
+	redis.call("PEXPIRE", KEYS[2], ttl)
+end
+return 1`
+
+// usageDayLayout formats the day stored with the usage counters
+const usageDayLayout = "2006-01-02"
+
+// reserveLinksScript adds ARGV[2] links to a tenant's usage hash unless that
+// would exceed the daily limit ARGV[3] or total limit ARGV[4] (0 is unlimited).
+// The daily count restarts when the day ARGV[1] changes. It returns
+// {1, daily, total} after a reservation, or {0, daily, total} if refused
+const reserveLinksScript = `
+local state = redis.call("HMGET", KEYS[1], "day", "daily", "total")
+local n = tonumber(ARGV[2])
+local daily = 0
+if state[1] == ARGV[1] then
+	daily = tonumber(state[2]) or 0
+end
+local total = tonumber(state[3]) or 0
+local dailyLimit = tonumber(ARGV[3])
+local totalLimit = tonumber(ARGV[4])
+if (dailyLimit > 0 and daily + n > dailyLimit) or (totalLimit > 0 and total + n > totalLimit) then
+	return {0, daily, total}
+end
+redis.call("HSET", KEYS[1], "day", ARGV[1], "daily", daily + n, "total", total + n)
+return {1, daily + n, total + n}`
+
+// releaseLinksScript takes ARGV[2] links back from a tenant's usage hash
+// The daily count is only lowered if it still counts the day ARGV[1]
+const releaseLinksScript = `
+local state = redis.call("HMGET", KEYS[1], "day", "daily", "total")
+local n = tonumber(ARGV[2])
+if state[1] == ARGV[1] then
+	redis.call("HSET", KEYS[1], "daily", math.max((tonumber(state[2]) or 0) - n, 0))
+end
+redis.call("HSET", KEYS[1], "total", math.max((tonumber(state[3]) or 0) - n, 0))
+return 0`
+
+// urlKey returns the key of a link in the namespace of the context's tenant
+func urlKey(ctx context.Context, shortID string) string {
+	return models.ShortIDKey(tenant.FromContext(ctx), shortID)
+}
+
+// reverseKey returns the reverse mapping hash of the context's tenant
+func reverseKey(ctx context.Context) string {
+	return models.ReverseURLsKeyFor(tenant.FromContext(ctx))
+}
+
+// remainingClicksKey returns the click countdown key of a link in the
+// namespace of the context's tenant
+func remainingClicksKey(ctx context.Context, shortID string) string {
+	return models.RemainingClicksKey(tenant.FromContext(ctx), shortID)
+}
+
+// accessKey returns the access stats of a link in the namespace of the context's tenant
+func accessKey(ctx context.Context, shortID string) string {
+	return models.AccessKey(tenant.FromContext(ctx), shortID)
+}
+
+// tagKey returns the short IDs carrying a tag in the namespace of the context's tenant
+func tagKey(ctx context.Context, tag string) string {
+	return models.TagKey(tenant.FromContext(ctx), tag)
+}
+
+// linkTagsKey returns the tags of a link in the namespace of the context's tenant
+func linkTagsKey(ctx context.Context, shortID string) string {
+	return models.LinkTagsKey(tenant.FromContext(ctx), shortID)
+}
+
+// campaignKey returns the key of a campaign in the namespace of the context's tenant
+func campaignKey(ctx context.Context, campaignID string) string {
+	return models.CampaignKey(tenant.FromContext(ctx), campaignID)
+}
+
+// usageKey returns the usage counters of the context's tenant
+func usageKey(ctx context.Context) string {
+	return models.UsageKeyPrefix + tenant.FromContext(ctx)
 }
 
 // Close implements URLStorage.Close
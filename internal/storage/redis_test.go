@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+	"github.com/hohotang/shortlink-core/internal/tenant"
+)
+
+// newTestRedisStorage connects to the Redis named by SHORTLINK_TEST_REDIS_URL
+// and returns a context in a tenant of its own; the test is skipped without it
+func newTestRedisStorage(t *testing.T) (*RedisStorage, context.Context) {
+	t.Helper()
+
+	redisURL := os.Getenv("SHORTLINK_TEST_REDIS_URL")
+	if redisURL == "" {
+		t.Skip("SHORTLINK_TEST_REDIS_URL not set")
+	}
+	s, err := NewRedisStorage(redisURL, 60)
+	if err != nil {
+		t.Fatalf("NewRedisStorage returned unexpected error: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	return s, tenant.WithContext(context.Background(), "test-"+strconv.FormatInt(time.Now().UnixNano(), 36))
+}
+
+func TestDecodeLink(t *testing.T) {
+	link, err := decodeLink("abc", []byte(`{"short_id":"abc","original_url":"https://example.com","dedup":true,"max_clicks":3}`))
+	if err != nil {
+		t.Fatalf("decodeLink returned unexpected error: %v", err)
+	}
+	if link.OriginalURL != "https://example.com" || !link.Dedup || link.MaxClicks != 3 {
+		t.Errorf("Unexpected link decoded from JSON: %+v", link)
+	}
+
+	// Links written before the JSON layout are the plain original URL
+	link, err = decodeLink("old", []byte("https://example.com/old"))
+	if err != nil {
+		t.Fatalf("decodeLink returned unexpected error for a plain URL: %v", err)
+	}
+	if link.ShortID != "old" || link.OriginalURL != "https://example.com/old" || link.Dedup {
+		t.Errorf("Unexpected link decoded from a plain URL: %+v", link)
+	}
+
+	if _, err := decodeLink("bad", []byte(`{"short_id":`)); err == nil {
+		t.Error("Expected an error for a truncated JSON record")
+	}
+}
+
+func TestRedisStorage_DedupPerURL(t *testing.T) {
+	s, ctx := newTestRedisStorage(t)
+
+	first := &models.URL{ShortID: "first", OriginalURL: "https://example.com/a", Dedup: true}
+	if err := s.StoreWithID(ctx, first); err != nil {
+		t.Fatalf("StoreWithID returned unexpected error: %v", err)
+	}
+	t.Cleanup(func() { s.Delete(ctx, first.ShortID) })
+
+	// A second dedup link for the same URL is refused and leaves nothing behind
+	second := &models.URL{ShortID: "second", OriginalURL: "https://example.com/a", Dedup: true}
+	if err := s.StoreWithID(ctx, second); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("Expected ErrAlreadyExists for a second dedup link, got %v", err)
+	}
+	if _, err := s.Get(ctx, second.ShortID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the refused link not to be stored, got %v", err)
+	}
+	if shortID, err := s.Find(ctx, first.OriginalURL); err != nil || shortID != first.ShortID {
+		t.Errorf("Expected the mapping to keep %s, got %s (%v)", first.ShortID, shortID, err)
+	}
+
+	// StoreMany skips it as well
+	stored, err := s.StoreMany(ctx, []*models.URL{{ShortID: "third", OriginalURL: "https://example.com/a"}})
+	if err != nil {
+		t.Fatalf("StoreMany returned unexpected error: %v", err)
+	}
+	if len(stored) != 0 {
+		t.Errorf("Expected StoreMany to skip a URL that has a dedup link, got %v", stored)
+	}
+
+	// A non-dedup link for the same URL is fine
+	custom := &models.URL{ShortID: "custom", OriginalURL: "https://example.com/a"}
+	if err := s.StoreWithID(ctx, custom); err != nil {
+		t.Fatalf("StoreWithID returned unexpected error for a non-dedup link: %v", err)
+	}
+	t.Cleanup(func() { s.Delete(ctx, custom.ShortID) })
+
+	// Once the dedup link is gone, the URL may get a new one
+	if err := s.Delete(ctx, first.ShortID); err != nil {
+		t.Fatalf("Delete returned unexpected error: %v", err)
+	}
+	if err := s.StoreWithID(ctx, second); err != nil {
+		t.Fatalf("StoreWithID returned unexpected error after the first link was deleted: %v", err)
+	}
+	t.Cleanup(func() { s.Delete(ctx, second.ShortID) })
+}
@@ -3,6 +3,9 @@ package storage
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/hohotang/shortlink-core/internal/models"
 )
 
 var (
@@ -10,20 +13,216 @@ var (
 	ErrNotFound = errors.New("url not found")
 	// ErrInvalidURL is returned when a URL is invalid
 	ErrInvalidURL = errors.New("invalid url")
+	// ErrAlreadyExists is returned when a short ID is already taken, or when
+	// a dedup link is stored for a URL that already has one
+	ErrAlreadyExists = errors.New("short id already exists")
+	// ErrUnsupported is returned when a storage backend cannot perform an operation
+	ErrUnsupported = errors.New("operation not supported by storage")
+	// ErrClicksExhausted is returned when a click-limited link has no clicks left
+	ErrClicksExhausted = errors.New("click limit reached")
+	// ErrQuotaExceeded is returned when a tenant may not create more links
+	ErrQuotaExceeded = errors.New("link quota exceeded")
 )
 
+// ClickStorage is implemented by backends that can persist click events
+type ClickStorage interface {
+	// StoreClicks saves a batch of click events in a single round trip
+	StoreClicks(ctx context.Context, clicks []*models.Click) error
+}
+
+// APIKeyStorage is implemented by backends that can look up API keys
+type APIKeyStorage interface {
+	// GetAPIKey returns the enabled key with the given SHA-256 hash
+	// Returns ErrNotFound if there is none
+	GetAPIKey(ctx context.Context, keyHash string) (*models.APIKey, error)
+}
+
+// UsageStorage is implemented by backends that meter link creation per tenant
+// Counters belong to the tenant of the context; day is a UTC day at midnight
+type UsageStorage interface {
+	// ReserveLinks counts n new links, unless that would take the tenant over
+	// a limit of quota. Returns ErrQuotaExceeded, counting nothing, if it would
+	ReserveLinks(ctx context.Context, day time.Time, n int64, quota models.Quota) (*models.Usage, error)
+
+	// ReleaseLinks takes back reserved links that were not created after all
+	ReleaseLinks(ctx context.Context, day time.Time, n int64) error
+
+	// GetUsage returns the counters for day; a tenant without links has zero usage
+	GetUsage(ctx context.Context, day time.Time) (*models.Usage, error)
+}
+
+// usageOn returns usage as seen on day; the daily count of an earlier day is reset
+func usageOn(usage models.Usage, day time.Time) models.Usage {
+	if !usage.Day.Equal(day) {
+		usage.Day = day
+		usage.DailyLinks = 0
+	}
+	return usage
+}
+
+// quotaExceeded reports whether n more links would take usage over quota
+func quotaExceeded(usage models.Usage, n int64, quota models.Quota) bool {
+	return (quota.Daily > 0 && usage.DailyLinks+n > quota.Daily) ||
+		(quota.Total > 0 && usage.TotalLinks+n > quota.Total)
+}
+
+// QuarantineStorage is implemented by backends that can re-check the links
+// of every tenant and quarantine the flagged ones
+type QuarantineStorage interface {
+	// ScanURLs returns up to limit links of all tenants ordered by tenant and
+	// short ID, starting after afterTenantID and afterShortID; empty values
+	// start from the beginning
+	ScanURLs(ctx context.Context, afterTenantID string, afterShortID string, limit int) ([]*ScannedURL, error)
+
+	// Quarantine stops a link from resolving without deleting it, and takes it out of dedup
+	// Returns ErrNotFound if the short ID does not exist
+	Quarantine(ctx context.Context, shortID string, reason string) error
+}
+
+// ScannedURL is a link returned by QuarantineStorage.ScanURLs with its tenant
+type ScannedURL struct {
+	TenantID string
+	Link     *models.URL
+}
+
+// TagStorage is implemented by backends that can label links with tags
+// Tags belong to the tenant of the context and are deleted with their link
+type TagStorage interface {
+	// AddTags labels a link; tags it already has are kept
+	// Returns ErrNotFound if the short ID does not exist
+	AddTags(ctx context.Context, shortID string, tags []string) error
+
+	// RemoveTags takes labels off a link; tags it does not have are ignored
+	RemoveTags(ctx context.Context, shortID string, tags []string) error
+
+	// GetTags returns the sorted tags of many links, keyed by short ID
+	// Links without tags are missing from the result
+	GetTags(ctx context.Context, shortIDs []string) (map[string][]string, error)
+
+	// ListByTag returns up to limit links carrying tag, ordered by short ID,
+	// that come after afterShortID; an empty afterShortID starts from the beginning
+	ListByTag(ctx context.Context, tag string, afterShortID string, limit int) ([]*models.URL, error)
+}
+
+// CampaignStorage is implemented by backends that can keep campaigns
+// Campaigns belong to the tenant of the context
+type CampaignStorage interface {
+	// CreateCampaign saves a new campaign and sets its timestamps
+	// Returns ErrAlreadyExists if the ID is taken
+	CreateCampaign(ctx context.Context, campaign *models.Campaign) error
+
+	// GetCampaign returns a campaign by ID
+	// Returns ErrNotFound if there is none
+	GetCampaign(ctx context.Context, campaignID string) (*models.Campaign, error)
+
+	// UpdateCampaign replaces the name and parameters of a campaign and sets UpdatedAt
+	// Returns ErrNotFound if there is none
+	UpdateCampaign(ctx context.Context, campaign *models.Campaign) error
+
+	// SetCampaign attaches a link to a campaign, taking it out of dedup; an
+	// empty campaignID detaches it. Returns ErrNotFound if the short ID does not exist
+	SetCampaign(ctx context.Context, shortID string, campaignID string) error
+}
+
+// TargetingStorage is implemented by backends that can keep targeting rules
+// and split variants. Both are stored with the link, so they are read along with it
+type TargetingStorage interface {
+	// SetTargeting replaces the targeting rules of a link, taking it out of
+	// dedup unless rules is empty. Returns ErrNotFound if the short ID does not exist
+	SetTargeting(ctx context.Context, shortID string, rules []models.TargetingRule) error
+
+	// SetVariants replaces the split variants of a link, taking it out of
+	// dedup unless variants is empty. Returns ErrNotFound if the short ID does not exist
+	SetVariants(ctx context.Context, shortID string, variants []models.Variant) error
+}
+
+// ListOptions selects a page of links for URLStorage.List
+// Links are ordered by creation time, then short ID; nil bounds are ignored
+type ListOptions struct {
+	// AfterCreatedAt and AfterShortID resume the listing after the last link of
+	// the previous page; zero values start from the beginning
+	AfterCreatedAt time.Time
+	AfterShortID   string
+
+	// CreatedFrom and CreatedTo bound the creation time (from inclusive, to exclusive)
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+
+	// AccessedFrom and AccessedTo bound the last access time (from inclusive, to exclusive)
+	AccessedFrom *time.Time
+	AccessedTo   *time.Time
+
+	// Domain keeps only links whose destination host equals it, ignoring case
+	Domain string
+
+	// Limit is the maximum number of links to return
+	Limit int
+}
+
 // URLStorage defines the interface for URL storage operations
 type URLStorage interface {
-	// Find saves a URL and returns a short ID
+	// Find returns the short ID of the dedup link for a canonical URL
 	// Note: Some implementations may not support this method directly
-	Find(ctx context.Context, originalURL string) (string, error)
+	Find(ctx context.Context, canonicalURL string) (string, error)
+
+	// FindMany returns the dedup short IDs of many canonical URLs, keyed by
+	// canonical URL. URLs without a dedup link are missing from the result
+	FindMany(ctx context.Context, canonicalURLs []string) (map[string]string, error)
+
+	// StoreWithID saves a link under its short ID
+	// Returns ErrAlreadyExists if the short ID is taken, or if the link is a
+	// dedup link and its URL already has one; the checks and the insert happen
+	// atomically so concurrent callers cannot both win
+	StoreWithID(ctx context.Context, link *models.URL) error
+
+	// StoreMany saves many plain dedup links in as few round trips as possible
+	// Only the short ID, original and canonical URL of each link are stored. It returns
+	// the short IDs that were stored; links whose short ID or URL is already
+	// taken are skipped rather than failing the whole batch
+	StoreMany(ctx context.Context, links []*models.URL) ([]string, error)
+
+	// Get retrieves the link stored under a short ID without recording an access
+	// Expired links are still returned; callers decide how to treat them. The
+	// copy may come from a cache, so its access time and click count can be stale
+	Get(ctx context.Context, shortID string) (*models.URL, error)
+
+	// GetInfo retrieves the link stored under a short ID from the copy that
+	// RecordAccess updates, so the access time and click count are as recent
+	// as the last recorded access
+	GetInfo(ctx context.Context, shortID string) (*models.URL, error)
+
+	// GetMany retrieves the links stored under many short IDs, keyed by short ID,
+	// without recording an access. Unknown short IDs are missing from the result
+	GetMany(ctx context.Context, shortIDs []string) (map[string]*models.URL, error)
+
+	// RecordAccess adds clicks[shortID] resolutions to each link and sets the
+	// last access time of those links. Unknown short IDs are ignored
+	RecordAccess(ctx context.Context, clicks map[string]int64) error
+
+	// ConsumeClick atomically takes one click from a click-limited link and
+	// returns the clicks left afterwards. It always decides on the
+	// authoritative copy, never a cache, so a limit cannot be exceeded.
+	// Returns ErrClicksExhausted if no clicks are left
+	ConsumeClick(ctx context.Context, shortID string) (int64, error)
+
+	// List returns a page of links, including disabled and expired ones
+	// Returns ErrUnsupported if the backend cannot list links
+	List(ctx context.Context, opts ListOptions) ([]*models.URL, error)
+
+	// Delete permanently removes a link
+	// Returns ErrNotFound if the short ID does not exist
+	Delete(ctx context.Context, shortID string) error
 
-	// StoreWithID saves a URL with a specific short ID
-	// Returns an error if the operation fails
-	StoreWithID(ctx context.Context, shortID string, originalURL string) error
+	// SetDisabled disables or re-enables a link without deleting it
+	// Disabling a dedup link also removes it from dedup, so later requests
+	// for the same URL get a fresh short ID
+	SetDisabled(ctx context.Context, shortID string, disabled bool) error
 
-	// Get retrieves the original URL for a short ID
-	Get(ctx context.Context, shortID string) (string, error)
+	// Update points an existing short ID at a new original URL and its canonical form
+	// The link leaves dedup, since its destination is now chosen by the caller,
+	// and leaves quarantine, since the flagged destination is gone
+	// Returns ErrNotFound if the short ID does not exist
+	Update(ctx context.Context, shortID string, originalURL string, canonicalURL string) error
 
 	// Close closes any connections
 	Close() error
@@ -0,0 +1,91 @@
+package targeting
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+)
+
+const (
+	// MaxVariants is the most variants a split link may have
+	MaxVariants = 10
+	// MaxWeight is the largest weight of a variant
+	MaxWeight = 10000
+)
+
+// ErrInvalidVariants is returned when the variants of a split fail validation
+var ErrInvalidVariants = errors.New("invalid variants")
+
+var variantID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// NormalizeVariants validates the variants of a split link. A split has
+// between 2 and MaxVariants variants with distinct IDs of 1-32 letters,
+// digits, '-' or '_' and weights from 1 to MaxWeight. Destinations are only
+// checked for presence; the caller vets them as URLs
+func NormalizeVariants(variants []models.Variant) ([]models.Variant, error) {
+	if len(variants) < 2 || len(variants) > MaxVariants {
+		return nil, fmt.Errorf("%w: a split needs between 2 and %d variants", ErrInvalidVariants, MaxVariants)
+	}
+
+	normalized := make([]models.Variant, len(variants))
+	seen := make(map[string]bool, len(variants))
+	for i, variant := range variants {
+		if !variantID.MatchString(variant.ID) {
+			return nil, fmt.Errorf("%w: variant ID %q must be 1-32 letters, digits, '-' or '_'", ErrInvalidVariants, variant.ID)
+		}
+		if seen[variant.ID] {
+			return nil, fmt.Errorf("%w: variant ID %q is used twice", ErrInvalidVariants, variant.ID)
+		}
+		seen[variant.ID] = true
+
+		if variant.Weight == 0 || variant.Weight > MaxWeight {
+			return nil, fmt.Errorf("%w: weight of variant %q must be between 1 and %d", ErrInvalidVariants, variant.ID, MaxWeight)
+		}
+		destination := strings.TrimSpace(variant.DestinationURL)
+		if destination == "" {
+			return nil, fmt.Errorf("%w: variant %q has no destination_url", ErrInvalidVariants, variant.ID)
+		}
+		normalized[i] = models.Variant{ID: variant.ID, DestinationURL: destination, Weight: variant.Weight}
+	}
+	return normalized, nil
+}
+
+// Pick returns the variant of a link served to a visitor, or nil if there
+// are no variants. Each variant is picked in proportion to its weight:
+// at random for anonymous visitors, and from a hash of the short ID and
+// visitor ID otherwise, so a visitor keeps getting the same variant of a
+// link for as long as its variants stay the same
+func Pick(variants []models.Variant, shortID string, visitorID string) *models.Variant {
+	var total uint64
+	for _, variant := range variants {
+		total += uint64(variant.Weight)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	var n uint64
+	if visitorID == "" {
+		n = rand.Uint64N(total)
+	} else {
+		h := fnv.New64a()
+		h.Write([]byte(shortID))
+		h.Write([]byte{0})
+		h.Write([]byte(visitorID))
+		n = h.Sum64() % total
+	}
+
+	for i := range variants {
+		weight := uint64(variants[i].Weight)
+		if n < weight {
+			return &variants[i]
+		}
+		n -= weight
+	}
+	return nil
+}
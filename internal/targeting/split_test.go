@@ -0,0 +1,82 @@
+package targeting
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/models"
+)
+
+func TestNormalizeVariants(t *testing.T) {
+	variants, err := NormalizeVariants([]models.Variant{
+		{ID: "control", DestinationURL: " https://example.com/a ", Weight: 3},
+		{ID: "b", DestinationURL: "https://example.com/b", Weight: 1},
+	})
+	if err != nil {
+		t.Fatalf("NormalizeVariants returned unexpected error: %v", err)
+	}
+	if variants[0].DestinationURL != "https://example.com/a" {
+		t.Errorf("Expected a trimmed destination, got %q", variants[0].DestinationURL)
+	}
+
+	valid := models.Variant{ID: "a", DestinationURL: "https://example.com/a", Weight: 1}
+	invalid := map[string][]models.Variant{
+		"Single variant":   {valid},
+		"Duplicate ID":     {valid, valid},
+		"Bad ID":           {valid, {ID: "b c", DestinationURL: "https://example.com/b", Weight: 1}},
+		"Zero weight":      {valid, {ID: "b", DestinationURL: "https://example.com/b"}},
+		"Too heavy":        {valid, {ID: "b", DestinationURL: "https://example.com/b", Weight: MaxWeight + 1}},
+		"No destination":   {valid, {ID: "b", Weight: 1}},
+		"Too many":         make([]models.Variant, MaxVariants+1),
+		"Missing variants": nil,
+	}
+	for name, variants := range invalid {
+		if _, err := NormalizeVariants(variants); !errors.Is(err, ErrInvalidVariants) {
+			t.Errorf("%s: expected ErrInvalidVariants, got %v", name, err)
+		}
+	}
+}
+
+func TestPick_StickyPerVisitor(t *testing.T) {
+	variants := []models.Variant{
+		{ID: "a", DestinationURL: "https://example.com/a", Weight: 1},
+		{ID: "b", DestinationURL: "https://example.com/b", Weight: 1},
+	}
+
+	served := map[string]int{}
+	for i := 0; i < 200; i++ {
+		visitorID := fmt.Sprintf("visitor-%d", i)
+		first := Pick(variants, "abc", visitorID)
+		for j := 0; j < 5; j++ {
+			if again := Pick(variants, "abc", visitorID); again.ID != first.ID {
+				t.Fatalf("Visitor %s got variant %s, then %s", visitorID, first.ID, again.ID)
+			}
+		}
+		served[first.ID]++
+	}
+	if served["a"] == 0 || served["b"] == 0 {
+		t.Errorf("Expected visitors to be spread over both variants, got %v", served)
+	}
+
+	if Pick(nil, "abc", "visitor") != nil {
+		t.Errorf("Expected no variant without variants")
+	}
+}
+
+func TestPick_Weights(t *testing.T) {
+	variants := []models.Variant{
+		{ID: "a", DestinationURL: "https://example.com/a", Weight: 3},
+		{ID: "b", DestinationURL: "https://example.com/b", Weight: 1},
+	}
+
+	const picks = 10000
+	served := map[string]int{}
+	for i := 0; i < picks; i++ {
+		served[Pick(variants, "abc", "").ID]++
+	}
+	if share := float64(served["a"]) / picks; math.Abs(share-0.75) > 0.05 {
+		t.Errorf("Expected variant a to get about 75%% of random picks, got %.1f%%", share*100)
+	}
+}
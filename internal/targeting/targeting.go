@@ -0,0 +1,250 @@
+package targeting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hohotang/shortlink-core/internal/analytics"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"google.golang.org/grpc/metadata"
+)
+
+// Device classes a rule can target
+const (
+	DeviceIOS     = "ios"
+	DeviceAndroid = "android"
+	DeviceMobile  = "mobile"
+	DeviceDesktop = "desktop"
+)
+
+const (
+	// MaxRules is the most rules a link may have
+	MaxRules = 20
+	// MaxValues is the most values one condition of a rule may list
+	MaxValues = 20
+)
+
+// ErrInvalidRule is returned when a targeting rule fails validation
+var ErrInvalidRule = errors.New("invalid targeting rule")
+
+var (
+	devices     = map[string]bool{DeviceIOS: true, DeviceAndroid: true, DeviceMobile: true, DeviceDesktop: true}
+	languageTag = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{1,8})*$`)
+	countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// Visitor is what rules and splits know about the visitor behind a request
+// Any field may be empty when the caller did not report it
+type Visitor struct {
+	ID        string
+	Device    string
+	Languages []string
+	Country   string
+}
+
+// VisitorFromContext describes the visitor from the incoming request metadata
+func VisitorFromContext(ctx context.Context) Visitor {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Visitor{}
+	}
+	return Visitor{
+		ID:        strings.TrimSpace(firstValue(md, analytics.MetadataVisitorID)),
+		Device:    DetectDevice(firstValue(md, analytics.MetadataUserAgent)),
+		Languages: ParseAcceptLanguage(firstValue(md, analytics.MetadataAcceptLanguage)),
+		Country:   strings.ToUpper(strings.TrimSpace(firstValue(md, analytics.MetadataCountry))),
+	}
+}
+
+// DetectDevice returns the device class of a User-Agent, or an empty string
+// if the User-Agent is empty. iOS and Android devices are told apart from
+// other mobile devices; everything else counts as desktop
+func DetectDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return ""
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return DeviceIOS
+	case strings.Contains(ua, "android"):
+		return DeviceAndroid
+	case strings.Contains(ua, "mobi"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
+
+// ParseAcceptLanguage returns the lowercase language tags of an
+// Accept-Language header, most preferred first. Wildcards, malformed tags
+// and tags with a quality of 0 are left out
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var parsed []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(fields[0])), "_", "-")
+		if !languageTag.MatchString(tag) {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				q = 0
+			}
+			quality = q
+		}
+		if quality > 0 {
+			parsed = append(parsed, weighted{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[i].quality > parsed[j].quality
+	})
+	tags := make([]string, len(parsed))
+	for i, p := range parsed {
+		tags[i] = p.tag
+	}
+	return tags
+}
+
+// Normalize validates rules and returns them in stored form: devices and
+// languages lowercased, countries uppercased and duplicate values dropped.
+// Destinations are only checked for presence; the caller vets them as URLs
+func Normalize(rules []models.TargetingRule) ([]models.TargetingRule, error) {
+	if len(rules) > MaxRules {
+		return nil, fmt.Errorf("%w: at most %d rules are allowed", ErrInvalidRule, MaxRules)
+	}
+
+	normalized := make([]models.TargetingRule, len(rules))
+	for i, rule := range rules {
+		var err error
+		n := &normalized[i]
+		if n.Devices, err = normalizeValues(rule.Devices, strings.ToLower, func(v string) bool { return devices[v] }); err != nil {
+			return nil, fmt.Errorf("%w: rule %d: device %v", ErrInvalidRule, i+1, err)
+		}
+		if n.Languages, err = normalizeValues(rule.Languages, strings.ToLower, languageTag.MatchString); err != nil {
+			return nil, fmt.Errorf("%w: rule %d: language %v", ErrInvalidRule, i+1, err)
+		}
+		if n.Countries, err = normalizeValues(rule.Countries, strings.ToUpper, countryCode.MatchString); err != nil {
+			return nil, fmt.Errorf("%w: rule %d: country %v", ErrInvalidRule, i+1, err)
+		}
+		if len(n.Devices) == 0 && len(n.Languages) == 0 && len(n.Countries) == 0 {
+			return nil, fmt.Errorf("%w: rule %d has no conditions", ErrInvalidRule, i+1)
+		}
+
+		n.DestinationURL = strings.TrimSpace(rule.DestinationURL)
+		if n.DestinationURL == "" {
+			return nil, fmt.Errorf("%w: rule %d has no destination_url", ErrInvalidRule, i+1)
+		}
+	}
+	return normalized, nil
+}
+
+// normalizeValues converts the values of one condition and drops duplicates
+// It fails on the first value valid rejects
+func normalizeValues(values []string, convert func(string) string, valid func(string) bool) ([]string, error) {
+	if len(values) > MaxValues {
+		return nil, fmt.Errorf("lists more than %d values", MaxValues)
+	}
+
+	var normalized []string
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = convert(strings.TrimSpace(value))
+		if !valid(value) {
+			return nil, fmt.Errorf("%q is not supported", value)
+		}
+		if !seen[value] {
+			seen[value] = true
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized, nil
+}
+
+// Match returns the first rule the visitor matches, or nil if none does
+func Match(rules []models.TargetingRule, visitor Visitor) *models.TargetingRule {
+	for i := range rules {
+		if matches(&rules[i], visitor) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// matches reports whether the visitor meets every condition a rule sets
+func matches(rule *models.TargetingRule, visitor Visitor) bool {
+	if len(rule.Devices) > 0 && !matchDevice(rule.Devices, visitor.Device) {
+		return false
+	}
+	if len(rule.Languages) > 0 && !matchLanguage(rule.Languages, visitor.Languages) {
+		return false
+	}
+	if len(rule.Countries) > 0 && !contains(rule.Countries, visitor.Country) {
+		return false
+	}
+	return true
+}
+
+// matchDevice reports whether a device class is listed
+// "mobile" also covers iOS and Android devices
+func matchDevice(listed []string, device string) bool {
+	if device == "" {
+		return false
+	}
+	if contains(listed, device) {
+		return true
+	}
+	return (device == DeviceIOS || device == DeviceAndroid) && contains(listed, DeviceMobile)
+}
+
+// matchLanguage reports whether any accepted language is listed, either
+// exactly or as a subtag of a listed language
+func matchLanguage(listed []string, accepted []string) bool {
+	for _, language := range accepted {
+		for _, l := range listed {
+			if language == l || strings.HasPrefix(language, l+"-") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contains reports whether value is a non-empty member of values
+func contains(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// firstValue returns the first value of a metadata key, or an empty string
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package targeting
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hohotang/shortlink-core/internal/analytics"
+	"github.com/hohotang/shortlink-core/internal/models"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestDetectDevice(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		device    string
+	}{
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148", DeviceIOS},
+		{"Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15", DeviceIOS},
+		{"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Mobile Safari/537.36", DeviceAndroid},
+		{"Mozilla/5.0 (Mobile; rv:48.0) Gecko/48.0 Firefox/48.0 KAIOS/2.5", DeviceMobile},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36", DeviceDesktop},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := DetectDevice(tt.userAgent); got != tt.device {
+			t.Errorf("DetectDevice(%q) = %q, want %q", tt.userAgent, got, tt.device)
+		}
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{"", []string{}},
+		{"en-US", []string{"en-us"}},
+		{"fr;q=0.5, zh_TW, en;q=0.8, *;q=0.1", []string{"zh-tw", "en", "fr"}},
+		{"de;q=0, nl;q=bad, es", []string{"es"}},
+		{"not a tag, ja", []string{"ja"}},
+	}
+	for _, tt := range tests {
+		if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseAcceptLanguage(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestVisitorFromContext(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		analytics.MetadataUserAgent, "Mozilla/5.0 (Linux; Android 14)",
+		analytics.MetadataAcceptLanguage, "pt-BR,pt;q=0.9",
+		analytics.MetadataCountry, " br ",
+		analytics.MetadataVisitorID, "v-123",
+	))
+
+	visitor := VisitorFromContext(ctx)
+	want := Visitor{ID: "v-123", Device: DeviceAndroid, Languages: []string{"pt-br", "pt"}, Country: "BR"}
+	if !reflect.DeepEqual(visitor, want) {
+		t.Errorf("Expected %+v, got %+v", want, visitor)
+	}
+
+	if visitor := VisitorFromContext(context.Background()); !reflect.DeepEqual(visitor, Visitor{}) {
+		t.Errorf("Expected an empty visitor without metadata, got %+v", visitor)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	rules, err := Normalize([]models.TargetingRule{{
+		Devices:        []string{"iOS", "ios"},
+		Languages:      []string{"EN-us"},
+		Countries:      []string{"tw", " US"},
+		DestinationURL: " https://example.com/app ",
+	}})
+	if err != nil {
+		t.Fatalf("Normalize returned unexpected error: %v", err)
+	}
+	want := models.TargetingRule{
+		Devices:        []string{"ios"},
+		Languages:      []string{"en-us"},
+		Countries:      []string{"TW", "US"},
+		DestinationURL: "https://example.com/app",
+	}
+	if !reflect.DeepEqual(rules[0], want) {
+		t.Errorf("Expected %+v, got %+v", want, rules[0])
+	}
+
+	invalid := map[string]models.TargetingRule{
+		"No conditions":  {DestinationURL: "https://example.com"},
+		"No destination": {Devices: []string{"ios"}},
+		"Unknown device": {Devices: []string{"toaster"}, DestinationURL: "https://example.com"},
+		"Bad language":   {Languages: []string{"english"}, DestinationURL: "https://example.com"},
+		"Bad country":    {Countries: []string{"USA"}, DestinationURL: "https://example.com"},
+	}
+	for name, rule := range invalid {
+		if _, err := Normalize([]models.TargetingRule{rule}); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("%s: expected ErrInvalidRule, got %v", name, err)
+		}
+	}
+
+	tooMany := make([]models.TargetingRule, MaxRules+1)
+	if _, err := Normalize(tooMany); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("Expected ErrInvalidRule for %d rules, got %v", len(tooMany), err)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	rules := []models.TargetingRule{
+		{Devices: []string{DeviceIOS}, DestinationURL: "https://apps.apple.com/app"},
+		{Devices: []string{DeviceAndroid}, DestinationURL: "https://play.google.com/app"},
+		{Devices: []string{DeviceMobile}, Countries: []string{"DE"}, DestinationURL: "https://m.example.de"},
+		{Languages: []string{"zh"}, DestinationURL: "https://example.com/zh"},
+	}
+
+	tests := []struct {
+		name    string
+		visitor Visitor
+		want    string
+	}{
+		{"iOS", Visitor{Device: DeviceIOS, Country: "DE"}, "https://apps.apple.com/app"},
+		{"Android", Visitor{Device: DeviceAndroid}, "https://play.google.com/app"},
+		{"Other mobile in Germany", Visitor{Device: DeviceMobile, Country: "DE"}, "https://m.example.de"},
+		{"Other mobile elsewhere", Visitor{Device: DeviceMobile, Country: "FR"}, ""},
+		{"Language subtag", Visitor{Device: DeviceDesktop, Languages: []string{"fr", "zh-tw"}}, "https://example.com/zh"},
+		{"Language prefix only", Visitor{Languages: []string{"zhx"}}, ""},
+		{"Unknown visitor", Visitor{}, ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if rule := Match(rules, tt.visitor); rule != nil {
+			got = rule.DestinationURL
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
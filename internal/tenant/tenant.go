@@ -0,0 +1,53 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// MetadataKey is the gRPC metadata key that names the caller's tenant
+	MetadataKey = "x-tenant-id"
+
+	// DefaultID is the tenant of requests that do not name one, and of all
+	// links stored before tenants existed
+	DefaultID = "default"
+
+	// MaxIDLength is the longest tenant ID accepted
+	MaxIDLength = 64
+)
+
+// ErrInvalidID is returned when a tenant ID fails validation
+var ErrInvalidID = errors.New("invalid tenant id")
+
+// idPattern allows lowercase letters, digits, '-' and '_', starting with a
+// letter or digit; ':' is excluded because it separates Redis key segments
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+type contextKey struct{}
+
+// Validate checks a tenant ID against the allowed charset and length
+func Validate(id string) error {
+	if len(id) > MaxIDLength {
+		return fmt.Errorf("%w: must be at most %d characters", ErrInvalidID, MaxIDLength)
+	}
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("%w: only lowercase letters, digits, '-' and '_' are allowed", ErrInvalidID)
+	}
+	return nil
+}
+
+// WithContext returns a context that scopes storage calls to a tenant
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant of a context, or DefaultID if it has none
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+		return id
+	}
+	return DefaultID
+}
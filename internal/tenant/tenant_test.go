@@ -0,0 +1,47 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		expectErr bool
+	}{
+		{name: "Simple", id: "marketing", expectErr: false},
+		{name: "Digits, dash and underscore", id: "team-42_eu", expectErr: false},
+		{name: "Maximum length", id: strings.Repeat("a", MaxIDLength), expectErr: false},
+		{name: "Empty", id: "", expectErr: true},
+		{name: "Too long", id: strings.Repeat("a", MaxIDLength+1), expectErr: true},
+		{name: "Uppercase", id: "Marketing", expectErr: true},
+		{name: "Colon", id: "team:a", expectErr: true},
+		{name: "Leading dash", id: "-team", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.id)
+			if tt.expectErr && !errors.Is(err, ErrInvalidID) {
+				t.Errorf("Validate(%q): expected ErrInvalidID, got %v", tt.id, err)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Validate(%q): unexpected error %v", tt.id, err)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+	if id := FromContext(ctx); id != DefaultID {
+		t.Errorf("Expected %s without a tenant, got %s", DefaultID, id)
+	}
+	if id := FromContext(WithContext(ctx, "marketing")); id != "marketing" {
+		t.Errorf("Expected marketing, got %s", id)
+	}
+}
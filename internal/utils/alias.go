@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// MinAliasLength is the shortest custom alias accepted
+	MinAliasLength = 3
+	// MaxAliasLength is the longest custom alias accepted
+	MaxAliasLength = 32
+)
+
+// ErrInvalidAlias is returned when a custom alias fails validation
+var ErrInvalidAlias = errors.New("invalid custom alias")
+
+// reservedAliases are paths the gateway serves itself, compared case-insensitively
+var reservedAliases = map[string]struct{}{
+	"admin":   {},
+	"api":     {},
+	"assets":  {},
+	"docs":    {},
+	"health":  {},
+	"healthz": {},
+	"login":   {},
+	"logout":  {},
+	"metrics": {},
+	"shorten": {},
+	"static":  {},
+	"swagger": {},
+	"www":     {},
+}
+
+// ValidateAlias checks a custom alias against the allowed charset, length
+// and reserved-word list
+func ValidateAlias(alias string) error {
+	if len(alias) < MinAliasLength || len(alias) > MaxAliasLength {
+		return fmt.Errorf("%w: length must be between %d and %d characters", ErrInvalidAlias, MinAliasLength, MaxAliasLength)
+	}
+
+	prevAlnum := false
+	for i, c := range alias {
+		isAlnum := strings.ContainsRune(Base62Charset, c)
+		if !isAlnum && c != '-' && c != '_' {
+			return fmt.Errorf("%w: character %q is not allowed", ErrInvalidAlias, c)
+		}
+		// Separators may only appear between alphanumeric characters
+		if !isAlnum && (i == 0 || i == len(alias)-1) {
+			return fmt.Errorf("%w: must start and end with a letter or digit", ErrInvalidAlias)
+		}
+		if !isAlnum && !prevAlnum {
+			return fmt.Errorf("%w: separators must not follow each other", ErrInvalidAlias)
+		}
+		prevAlnum = isAlnum
+	}
+
+	if _, reserved := reservedAliases[strings.ToLower(alias)]; reserved {
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidAlias, alias)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAlias(t *testing.T) {
+	tests := []struct {
+		name      string
+		alias     string
+		expectErr bool
+	}{
+		{name: "Simple alias", alias: "spring-sale", expectErr: false},
+		{name: "Alphanumeric with underscore", alias: "Promo_2025", expectErr: false},
+		{name: "Minimum length", alias: "abc", expectErr: false},
+		{name: "Maximum length", alias: strings.Repeat("a", MaxAliasLength), expectErr: false},
+		{name: "Too short", alias: "ab", expectErr: true},
+		{name: "Too long", alias: strings.Repeat("a", MaxAliasLength+1), expectErr: true},
+		{name: "Slash", alias: "spring/sale", expectErr: true},
+		{name: "Space", alias: "spring sale", expectErr: true},
+		{name: "Non-ASCII", alias: "café-sale", expectErr: true},
+		{name: "Leading dash", alias: "-spring", expectErr: true},
+		{name: "Trailing underscore", alias: "spring_", expectErr: true},
+		{name: "Double dash", alias: "a--b", expectErr: true},
+		{name: "Mixed separator run", alias: "a_-_b", expectErr: true},
+		{name: "Separated segments", alias: "a-b_c", expectErr: false},
+		{name: "Reserved word", alias: "admin", expectErr: true},
+		{name: "Reserved word any case", alias: "API", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAlias(tt.alias)
+
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("ValidateAlias(%q) expected error but got nil", tt.alias)
+				} else if !errors.Is(err, ErrInvalidAlias) {
+					t.Errorf("ValidateAlias(%q) error %v does not wrap ErrInvalidAlias", tt.alias, err)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateAlias(%q) returned unexpected error: %v", tt.alias, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxCampaignIDLength is the longest campaign ID accepted
+	MaxCampaignIDLength = 64
+	// MaxUTMParams is the most UTM parameters a campaign may carry
+	MaxUTMParams = 10
+	// MaxUTMValueLength is the longest UTM parameter value accepted
+	MaxUTMValueLength = 256
+)
+
+// ErrInvalidCampaign is returned when a campaign ID or its parameters fail validation
+var ErrInvalidCampaign = errors.New("invalid campaign")
+
+// ValidateCampaignID checks a campaign ID against the allowed charset and length
+// IDs may contain letters, digits, '-' and '_'
+func ValidateCampaignID(id string) error {
+	if id == "" || len(id) > MaxCampaignIDLength {
+		return fmt.Errorf("%w: id length must be between 1 and %d characters", ErrInvalidCampaign, MaxCampaignIDLength)
+	}
+	for _, c := range id {
+		if !strings.ContainsRune(Base62Charset, c) && c != '-' && c != '_' {
+			return fmt.Errorf("%w: character %q is not allowed in the id", ErrInvalidCampaign, c)
+		}
+	}
+	return nil
+}
+
+// NormalizeUTMParams validates campaign parameters and returns them with
+// lowercase names. Names must start with "utm_" followed by letters, digits
+// or '_'; values must not be empty
+func NormalizeUTMParams(params map[string]string) (map[string]string, error) {
+	if len(params) > MaxUTMParams {
+		return nil, fmt.Errorf("%w: at most %d parameters are allowed", ErrInvalidCampaign, MaxUTMParams)
+	}
+
+	normalized := make(map[string]string, len(params))
+	for name, value := range params {
+		name = strings.ToLower(name)
+		suffix, ok := strings.CutPrefix(name, "utm_")
+		if !ok || suffix == "" || strings.Trim(suffix, "abcdefghijklmnopqrstuvwxyz0123456789_") != "" {
+			return nil, fmt.Errorf("%w: parameter %q is not a utm_ parameter", ErrInvalidCampaign, name)
+		}
+		if value == "" || len(value) > MaxUTMValueLength {
+			return nil, fmt.Errorf("%w: value of %s must be between 1 and %d characters", ErrInvalidCampaign, name, MaxUTMValueLength)
+		}
+		if _, dup := normalized[name]; dup {
+			return nil, fmt.Errorf("%w: parameter %s is given twice", ErrInvalidCampaign, name)
+		}
+		normalized[name] = value
+	}
+	return normalized, nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateCampaignID(t *testing.T) {
+	valid := []string{"spring-sale", "Q3_2024", "x"}
+	for _, id := range valid {
+		if err := ValidateCampaignID(id); err != nil {
+			t.Errorf("ValidateCampaignID(%q) returned unexpected error: %v", id, err)
+		}
+	}
+
+	invalid := []string{"", "spring sale", "a/b", string(make([]byte, MaxCampaignIDLength+1))}
+	for _, id := range invalid {
+		if err := ValidateCampaignID(id); !errors.Is(err, ErrInvalidCampaign) {
+			t.Errorf("ValidateCampaignID(%q): expected ErrInvalidCampaign, got %v", id, err)
+		}
+	}
+}
+
+func TestNormalizeUTMParams(t *testing.T) {
+	got, err := NormalizeUTMParams(map[string]string{"UTM_Source": "newsletter", "utm_campaign": "Spring Sale"})
+	if err != nil {
+		t.Fatalf("NormalizeUTMParams returned unexpected error: %v", err)
+	}
+	want := map[string]string{"utm_source": "newsletter", "utm_campaign": "Spring Sale"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeUTMParams = %v, want %v", got, want)
+	}
+
+	invalid := []map[string]string{
+		{"source": "x"},
+		{"utm_": "x"},
+		{"utm-source": "x"},
+		{"utm_source": ""},
+		{"utm_source": "a", "UTM_SOURCE": "b"},
+	}
+	for _, params := range invalid {
+		if _, err := NormalizeUTMParams(params); !errors.Is(err, ErrInvalidCampaign) {
+			t.Errorf("NormalizeUTMParams(%v): expected ErrInvalidCampaign, got %v", params, err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxPasswordLength is the longest link password accepted, in bytes
+	MaxPasswordLength = 128
+
+	// passwordScheme prefixes every stored hash so the format can change later
+	passwordScheme = "pbkdf2-sha256"
+	// passwordIterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256
+	passwordIterations = 600000
+	passwordSaltLength = 16
+	passwordKeyLength  = 32
+)
+
+// ErrInvalidPassword is returned when a link password fails validation
+var ErrInvalidPassword = errors.New("invalid password")
+
+// ValidatePassword checks a link password before it is hashed
+func ValidatePassword(password string) error {
+	if len(password) > MaxPasswordLength {
+		return fmt.Errorf("%w: must be at most %d bytes", ErrInvalidPassword, MaxPasswordLength)
+	}
+	return nil
+}
+
+// HashPassword derives a salted hash of the password for storage
+// The result has the form "pbkdf2-sha256$<iterations>$<salt>$<key>" with the
+// salt and key in unpadded base64
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordIterations, passwordKeyLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return strings.Join([]string{
+		passwordScheme,
+		strconv.Itoa(passwordIterations),
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+// VerifyPassword reports whether the password matches a hash from HashPassword
+// The comparison takes constant time; malformed hashes never match
+func VerifyPassword(hash string, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passwordScheme {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(want) == 0 {
+		return false
+	}
+
+	got, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(want))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
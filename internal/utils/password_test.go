@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("s3cret")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+
+	if strings.Contains(hash, "s3cret") {
+		t.Errorf("Hash must not contain the plaintext password: %s", hash)
+	}
+	if !strings.HasPrefix(hash, passwordScheme+"$") {
+		t.Errorf("Expected hash to start with %s, got %s", passwordScheme, hash)
+	}
+
+	if !VerifyPassword(hash, "s3cret") {
+		t.Errorf("Expected the correct password to verify")
+	}
+	if VerifyPassword(hash, "s3cret ") || VerifyPassword(hash, "") {
+		t.Errorf("Expected a wrong password not to verify")
+	}
+
+	// Each hash gets its own salt
+	other, err := HashPassword("s3cret")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	if other == hash {
+		t.Errorf("Expected different salts to give different hashes")
+	}
+}
+
+func TestVerifyPassword_MalformedHash(t *testing.T) {
+	for _, hash := range []string{
+		"",
+		"s3cret",
+		"pbkdf2-sha256$abc$c2FsdA$a2V5",
+		"pbkdf2-sha256$1000$c2FsdA",
+		"bcrypt$1000$c2FsdA$a2V5",
+		"pbkdf2-sha256$1000$!!!$a2V5",
+	} {
+		if VerifyPassword(hash, "s3cret") {
+			t.Errorf("Expected malformed hash %q not to verify", hash)
+		}
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	if err := ValidatePassword("s3cret"); err != nil {
+		t.Errorf("Expected valid password, got %v", err)
+	}
+	err := ValidatePassword(strings.Repeat("a", MaxPasswordLength+1))
+	if !errors.Is(err, ErrInvalidPassword) {
+		t.Errorf("Expected ErrInvalidPassword, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxTagLength is the longest tag accepted
+	MaxTagLength = 64
+	// MaxTags is the most tags accepted in one request
+	MaxTags = 20
+)
+
+// ErrInvalidTag is returned when a tag fails validation
+var ErrInvalidTag = errors.New("invalid tag")
+
+// NormalizeTags validates tags and returns them lowercased, sorted and
+// without duplicates. Tags may contain letters, digits, '-', '_', '.' and '/'
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) > MaxTags {
+		return nil, fmt.Errorf("%w: at most %d tags are allowed", ErrInvalidTag, MaxTags)
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len(tag) > MaxTagLength {
+			return nil, fmt.Errorf("%w: length must be between 1 and %d characters", ErrInvalidTag, MaxTagLength)
+		}
+		for _, c := range tag {
+			if !strings.ContainsRune(Base62Charset, c) && !strings.ContainsRune("-_./", c) {
+				return nil, fmt.Errorf("%w: character %q is not allowed in %q", ErrInvalidTag, c, tag)
+			}
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
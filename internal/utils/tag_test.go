@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{"Team/Growth", "spring-2025", " campaign.q2 ", "spring-2025", "team/growth"})
+	if err != nil {
+		t.Fatalf("NormalizeTags returned unexpected error: %v", err)
+	}
+	expected := []string{"campaign.q2", "spring-2025", "team/growth"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("NormalizeTags = %v, want %v", tags, expected)
+	}
+
+	invalid := [][]string{
+		{""},
+		{"   "},
+		{strings.Repeat("a", MaxTagLength+1)},
+		{"has space"},
+		{"tenant:tag"},
+		{"café"},
+		make([]string, MaxTags+1),
+	}
+	for _, tags := range invalid {
+		if _, err := NormalizeTags(tags); !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("NormalizeTags(%q) expected ErrInvalidTag, got %v", tags, err)
+		}
+	}
+}
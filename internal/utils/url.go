@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// defaultPorts are the ports CanonicalizeURL drops for each scheme
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Hostname returns the lowercased host of a URL without its port, or an
+// empty string if the URL cannot be parsed
+func Hostname(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// CanonicalizeURL normalizes a URL so that different spellings of the same
+// destination compare equal. It lowercases the scheme and host, drops the
+// default port, resolves dot segments, removes the query parameters named in
+// stripParams and sorts the rest. A stripParams entry ending in '*' matches
+// every parameter with that prefix, and names are compared case-insensitively
+func CanonicalizeURL(rawURL string, stripParams []string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Opaque != "" {
+		return rawURL, nil
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	if port := parsed.Port(); port != "" && defaultPorts[parsed.Scheme] == port {
+		parsed.Host = strings.TrimSuffix(parsed.Host, ":"+port)
+	}
+
+	// Work on the escaped path so percent-encoding is kept as sent
+	escaped := removeDotSegments(parsed.EscapedPath())
+	if escaped == "" && parsed.Host != "" {
+		escaped = "/"
+	}
+	if parsed.Path, err = url.PathUnescape(escaped); err != nil {
+		return "", err
+	}
+	parsed.RawPath = escaped
+
+	// Leave a query that does not parse untouched rather than losing parts of it
+	if query, err := url.ParseQuery(parsed.RawQuery); err == nil {
+		for name := range query {
+			if matchesParam(name, stripParams) {
+				query.Del(name)
+			}
+		}
+		parsed.RawQuery = query.Encode()
+	}
+	parsed.ForceQuery = false
+
+	return parsed.String(), nil
+}
+
+// AddQueryParams appends params to the query of a URL, in name order
+// Parameters the URL already has, compared case-insensitively, keep their
+// value; the rest of the URL is left exactly as it was
+func AddQueryParams(rawURL string, params map[string]string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	present := make(map[string]bool)
+	for _, pair := range strings.Split(parsed.RawQuery, "&") {
+		name, _, _ := strings.Cut(pair, "=")
+		if name, err := url.QueryUnescape(name); err == nil && name != "" {
+			present[strings.ToLower(name)] = true
+		}
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		if !present[strings.ToLower(name)] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return rawURL, nil
+	}
+	sort.Strings(names)
+
+	query := parsed.RawQuery
+	for _, name := range names {
+		if query != "" {
+			query += "&"
+		}
+		query += url.QueryEscape(name) + "=" + url.QueryEscape(params[name])
+	}
+	parsed.RawQuery = query
+	parsed.ForceQuery = false
+	return parsed.String(), nil
+}
+
+// removeDotSegments resolves the "." and ".." segments of a path as described
+// in RFC 3986 section 5.2.4, keeping a trailing slash
+func removeDotSegments(path string) string {
+	if !strings.Contains(path, ".") {
+		return path
+	}
+
+	segments := strings.Split(path, "/")
+	resolved := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		switch segment {
+		case ".":
+		case "..":
+			// The empty first segment of an absolute path is never removed
+			if len(resolved) > 1 {
+				resolved = resolved[:len(resolved)-1]
+			}
+		default:
+			resolved = append(resolved, segment)
+			continue
+		}
+		// A path ending in a dot segment still refers to a directory
+		if i == len(segments)-1 {
+			resolved = append(resolved, "")
+		}
+	}
+	return strings.Join(resolved, "/")
+}
+
+// matchesParam reports whether a query parameter name is in the strip list
+func matchesParam(name string, stripParams []string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range stripParams {
+		pattern = strings.ToLower(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package utils
+
+import "testing"
+
+func TestCanonicalizeURL(t *testing.T) {
+	strip := []string{"utm_*", "fbclid", "gclid"}
+
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{name: "Already canonical", url: "http://example.com/a", expected: "http://example.com/a"},
+		{name: "Uppercase scheme and host", url: "HTTP://Example.COM/Path", expected: "http://example.com/Path"},
+		{name: "Default HTTP port", url: "http://example.com:80/a", expected: "http://example.com/a"},
+		{name: "Default HTTPS port", url: "https://example.com:443/a", expected: "https://example.com/a"},
+		{name: "Non-default port kept", url: "https://example.com:80/a", expected: "https://example.com:80/a"},
+		{name: "IPv6 default port", url: "http://[::1]:80/a", expected: "http://[::1]/a"},
+		{name: "Empty path", url: "https://example.com", expected: "https://example.com/"},
+		{name: "Dot segments", url: "http://example.com/a/./b/../c", expected: "http://example.com/a/c"},
+		{name: "Trailing dot segment", url: "http://example.com/a/b/..", expected: "http://example.com/a/"},
+		{name: "Dot segments above root", url: "http://example.com/../a", expected: "http://example.com/a"},
+		{name: "Trailing slash kept", url: "http://example.com/a/", expected: "http://example.com/a/"},
+		{name: "Query sorted", url: "http://example.com/?b=2&a=1", expected: "http://example.com/?a=1&b=2"},
+		{name: "Tracking params stripped", url: "http://example.com/a?utm_source=x&id=7&UTM_Medium=y&fbclid=z", expected: "http://example.com/a?id=7"},
+		{name: "Only tracking params", url: "http://example.com/a?utm_source=x", expected: "http://example.com/a"},
+		{name: "Empty query", url: "http://example.com/a?", expected: "http://example.com/a"},
+		{name: "Escaping kept", url: "http://example.com/a%2Fb/c%20d", expected: "http://example.com/a%2Fb/c%20d"},
+		{name: "Fragment kept", url: "http://example.com/a#Top", expected: "http://example.com/a#Top"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalizeURL(tt.url, strip)
+			if err != nil {
+				t.Fatalf("CanonicalizeURL(%q) returned unexpected error: %v", tt.url, err)
+			}
+			if got != tt.expected {
+				t.Errorf("CanonicalizeURL(%q) = %q, want %q", tt.url, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeURL_InvalidURL(t *testing.T) {
+	if _, err := CanonicalizeURL("http://example.com/%zz", nil); err == nil {
+		t.Error("CanonicalizeURL expected error for invalid escape but got nil")
+	}
+}
+
+func TestAddQueryParams(t *testing.T) {
+	params := map[string]string{"utm_source": "newsletter", "utm_campaign": "spring sale"}
+
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{name: "No query", url: "https://example.com/a", expected: "https://example.com/a?utm_campaign=spring+sale&utm_source=newsletter"},
+		{name: "Existing query kept as is", url: "https://example.com/a?b=2&a=%2F", expected: "https://example.com/a?b=2&a=%2F&utm_campaign=spring+sale&utm_source=newsletter"},
+		{name: "Present params win", url: "https://example.com/a?UTM_Source=partner", expected: "https://example.com/a?UTM_Source=partner&utm_campaign=spring+sale"},
+		{name: "Fragment kept", url: "https://example.com/a#top", expected: "https://example.com/a?utm_campaign=spring+sale&utm_source=newsletter#top"},
+		{name: "Nothing to add", url: "https://example.com/a?utm_source=x&utm_campaign=y", expected: "https://example.com/a?utm_source=x&utm_campaign=y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddQueryParams(tt.url, params)
+			if err != nil {
+				t.Fatalf("AddQueryParams(%q) returned unexpected error: %v", tt.url, err)
+			}
+			if got != tt.expected {
+				t.Errorf("AddQueryParams(%q) = %q, want %q", tt.url, got, tt.expected)
+			}
+		})
+	}
+}
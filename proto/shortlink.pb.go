@@ -9,6 +9,8 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,10 +23,103 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// URLStatus tells whether a short URL currently resolves
+type URLStatus int32
+
+const (
+	URLStatus_URL_STATUS_UNSPECIFIED URLStatus = 0
+	URLStatus_URL_STATUS_ACTIVE      URLStatus = 1
+	URLStatus_URL_STATUS_DISABLED    URLStatus = 2
+	URLStatus_URL_STATUS_EXPIRED     URLStatus = 3
+	URLStatus_URL_STATUS_EXHAUSTED   URLStatus = 4 // Click limit reached
+	URLStatus_URL_STATUS_QUARANTINED URLStatus = 5 // Flagged by a reputation check
+	URLStatus_URL_STATUS_SCHEDULED   URLStatus = 6 // Activation window not open yet
+)
+
+// Enum value maps for URLStatus.
+var (
+	URLStatus_name = map[int32]string{
+		0: "URL_STATUS_UNSPECIFIED",
+		1: "URL_STATUS_ACTIVE",
+		2: "URL_STATUS_DISABLED",
+		3: "URL_STATUS_EXPIRED",
+		4: "URL_STATUS_EXHAUSTED",
+		5: "URL_STATUS_QUARANTINED",
+		6: "URL_STATUS_SCHEDULED",
+	}
+	URLStatus_value = map[string]int32{
+		"URL_STATUS_UNSPECIFIED": 0,
+		"URL_STATUS_ACTIVE":      1,
+		"URL_STATUS_DISABLED":    2,
+		"URL_STATUS_EXPIRED":     3,
+		"URL_STATUS_EXHAUSTED":   4,
+		"URL_STATUS_QUARANTINED": 5,
+		"URL_STATUS_SCHEDULED":   6,
+	}
+)
+
+func (x URLStatus) Enum() *URLStatus {
+	p := new(URLStatus)
+	*p = x
+	return p
+}
+
+func (x URLStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (URLStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_shortlink_proto_enumTypes[0].Descriptor()
+}
+
+func (URLStatus) Type() protoreflect.EnumType {
+	return &file_proto_shortlink_proto_enumTypes[0]
+}
+
+func (x URLStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use URLStatus.Descriptor instead.
+func (URLStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{0}
+}
+
 // ShortenURLRequest contains the original URL to shorten
 type ShortenURLRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	// Optional vanity short ID such as "spring-sale"; 3-32 letters, digits,
+	// '-' or '_'. Aliased links are never shared with other requests.
+	CustomAlias string `protobuf:"bytes,2,opt,name=custom_alias,json=customAlias,proto3" json:"custom_alias,omitempty"`
+	// Optional expiry, either as an absolute time or as a lifetime from now.
+	// At most one may be set; expiring links are never shared with other requests.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Ttl       *durationpb.Duration   `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Optional password; when set, ExpandURL only resolves the link if the same
+	// password is given. Protected links are never shared with other requests.
+	Password string `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	// Optional number of times the link may resolve; 1 makes a one-time link.
+	// 0 means unlimited. Click-limited links are never shared with other requests.
+	MaxClicks int64 `protobuf:"varint,6,opt,name=max_clicks,json=maxClicks,proto3" json:"max_clicks,omitempty"`
+	// Optional tags such as "spring-2024" or "team/growth"; up to 20 of 1-64
+	// letters, digits, '-', '_', '.' or '/', compared case-insensitively.
+	// Tagged links are never shared with other requests.
+	Tags []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Optional campaign whose UTM parameters ExpandURL adds to the destination.
+	// Campaign links are never shared with other requests.
+	CampaignId string `protobuf:"bytes,8,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	// Optional targeting rules that send some visitors to another destination.
+	// Targeted links are never shared with other requests.
+	Targeting []*TargetingRule `protobuf:"bytes,9,rep,name=targeting,proto3" json:"targeting,omitempty"`
+	// Optional weighted destinations that replace original_url for the visitors
+	// no targeting rule matches. Split links are never shared with other requests.
+	Variants []*Variant `protobuf:"bytes,10,rep,name=variants,proto3" json:"variants,omitempty"`
+	// Optional moment the link goes live; together with the expiry it forms the
+	// link's activation window. Before it, ExpandURL fails with FAILED_PRECONDITION
+	// URL_NOT_YET_ACTIVE. A moment already past is ignored. Scheduled links are
+	// never shared with other requests.
+	NotBefore     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,11 +161,83 @@ func (x *ShortenURLRequest) GetOriginalUrl() string {
 	return ""
 }
 
+func (x *ShortenURLRequest) GetCustomAlias() string {
+	if x != nil {
+		return x.CustomAlias
+	}
+	return ""
+}
+
+func (x *ShortenURLRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ShortenURLRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *ShortenURLRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ShortenURLRequest) GetMaxClicks() int64 {
+	if x != nil {
+		return x.MaxClicks
+	}
+	return 0
+}
+
+func (x *ShortenURLRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ShortenURLRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *ShortenURLRequest) GetTargeting() []*TargetingRule {
+	if x != nil {
+		return x.Targeting
+	}
+	return nil
+}
+
+func (x *ShortenURLRequest) GetVariants() []*Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *ShortenURLRequest) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
 // ShortenURLResponse contains the generated short URL ID
 type ShortenURLResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
-	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"` // Full URL including domain
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`    // Full URL including domain
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unset if the link never expires
+	NotBefore     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"` // Unset if the link is live from creation
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -119,17 +286,199 @@ func (x *ShortenURLResponse) GetShortUrl() string {
 	return ""
 }
 
+func (x *ShortenURLResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ShortenURLResponse) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+// BatchShortenURLsRequest contains the original URLs to shorten
+type BatchShortenURLsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrls  []string               `protobuf:"bytes,1,rep,name=original_urls,json=originalUrls,proto3" json:"original_urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchShortenURLsRequest) Reset() {
+	*x = BatchShortenURLsRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchShortenURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchShortenURLsRequest) ProtoMessage() {}
+
+func (x *BatchShortenURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchShortenURLsRequest.ProtoReflect.Descriptor instead.
+func (*BatchShortenURLsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchShortenURLsRequest) GetOriginalUrls() []string {
+	if x != nil {
+		return x.OriginalUrls
+	}
+	return nil
+}
+
+// BatchShortenURLResult is the outcome for one URL of a batch
+type BatchShortenURLResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ShortId       string                 `protobuf:"bytes,2,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,3,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"` // Full URL including domain
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                       // Set instead of short_id if this URL could not be shortened
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`                     // Error reason from the catalog, such as URL_BLOCKED; set with error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchShortenURLResult) Reset() {
+	*x = BatchShortenURLResult{}
+	mi := &file_proto_shortlink_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchShortenURLResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchShortenURLResult) ProtoMessage() {}
+
+func (x *BatchShortenURLResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchShortenURLResult.ProtoReflect.Descriptor instead.
+func (*BatchShortenURLResult) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchShortenURLResult) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *BatchShortenURLResult) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *BatchShortenURLResult) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *BatchShortenURLResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchShortenURLResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// BatchShortenURLsResponse contains one result per requested URL, in request order
+type BatchShortenURLsResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Results       []*BatchShortenURLResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchShortenURLsResponse) Reset() {
+	*x = BatchShortenURLsResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchShortenURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchShortenURLsResponse) ProtoMessage() {}
+
+func (x *BatchShortenURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchShortenURLsResponse.ProtoReflect.Descriptor instead.
+func (*BatchShortenURLsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchShortenURLsResponse) GetResults() []*BatchShortenURLResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 // ExpandURLRequest contains the short URL ID to expand
 type ExpandURLRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"` // Required for password-protected links
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ExpandURLRequest) Reset() {
 	*x = ExpandURLRequest{}
-	mi := &file_proto_shortlink_proto_msgTypes[2]
+	mi := &file_proto_shortlink_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -141,7 +490,7 @@ func (x *ExpandURLRequest) String() string {
 func (*ExpandURLRequest) ProtoMessage() {}
 
 func (x *ExpandURLRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlink_proto_msgTypes[2]
+	mi := &file_proto_shortlink_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -154,7 +503,7 @@ func (x *ExpandURLRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpandURLRequest.ProtoReflect.Descriptor instead.
 func (*ExpandURLRequest) Descriptor() ([]byte, []int) {
-	return file_proto_shortlink_proto_rawDescGZIP(), []int{2}
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ExpandURLRequest) GetShortId() string {
@@ -164,6 +513,13 @@ func (x *ExpandURLRequest) GetShortId() string {
 	return ""
 }
 
+func (x *ExpandURLRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
 // ExpandURLResponse contains the original URL
 type ExpandURLResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -174,7 +530,7 @@ type ExpandURLResponse struct {
 
 func (x *ExpandURLResponse) Reset() {
 	*x = ExpandURLResponse{}
-	mi := &file_proto_shortlink_proto_msgTypes[3]
+	mi := &file_proto_shortlink_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -186,7 +542,7 @@ func (x *ExpandURLResponse) String() string {
 func (*ExpandURLResponse) ProtoMessage() {}
 
 func (x *ExpandURLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_shortlink_proto_msgTypes[3]
+	mi := &file_proto_shortlink_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -199,7 +555,7 @@ func (x *ExpandURLResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExpandURLResponse.ProtoReflect.Descriptor instead.
 func (*ExpandURLResponse) Descriptor() ([]byte, []int) {
-	return file_proto_shortlink_proto_rawDescGZIP(), []int{3}
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ExpandURLResponse) GetOriginalUrl() string {
@@ -209,25 +565,2595 @@ func (x *ExpandURLResponse) GetOriginalUrl() string {
 	return ""
 }
 
-var File_proto_shortlink_proto protoreflect.FileDescriptor
+// BatchExpandURLsRequest contains the short URL IDs to expand
+type BatchExpandURLsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortIds      []string               `protobuf:"bytes,1,rep,name=short_ids,json=shortIds,proto3" json:"short_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_shortlink_proto_rawDesc = "" +
-	"\n" +
-	"\x15proto/shortlink.proto\x12\tshortlink\"6\n" +
-	"\x11ShortenURLRequest\x12!\n" +
-	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\"L\n" +
-	"\x12ShortenURLResponse\x12\x19\n" +
-	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12\x1b\n" +
-	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\"-\n" +
-	"\x10ExpandURLRequest\x12\x19\n" +
-	"\bshort_id\x18\x01 \x01(\tR\ashortId\"6\n" +
-	"\x11ExpandURLResponse\x12!\n" +
-	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl2\x9f\x01\n" +
-	"\n" +
-	"URLService\x12I\n" +
+func (x *BatchExpandURLsRequest) Reset() {
+	*x = BatchExpandURLsRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchExpandURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchExpandURLsRequest) ProtoMessage() {}
+
+func (x *BatchExpandURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchExpandURLsRequest.ProtoReflect.Descriptor instead.
+func (*BatchExpandURLsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchExpandURLsRequest) GetShortIds() []string {
+	if x != nil {
+		return x.ShortIds
+	}
+	return nil
+}
+
+// BatchExpandURLResult is the outcome for one short URL ID of a batch
+type BatchExpandURLResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`   // Set instead of original_url if the short URL does not resolve
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // Error reason from the catalog, such as URL_NOT_FOUND; set with error
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchExpandURLResult) Reset() {
+	*x = BatchExpandURLResult{}
+	mi := &file_proto_shortlink_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchExpandURLResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchExpandURLResult) ProtoMessage() {}
+
+func (x *BatchExpandURLResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchExpandURLResult.ProtoReflect.Descriptor instead.
+func (*BatchExpandURLResult) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchExpandURLResult) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *BatchExpandURLResult) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *BatchExpandURLResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchExpandURLResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// BatchExpandURLsResponse contains one result per requested short URL ID, in request order
+type BatchExpandURLsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Results       []*BatchExpandURLResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchExpandURLsResponse) Reset() {
+	*x = BatchExpandURLsResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchExpandURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchExpandURLsResponse) ProtoMessage() {}
+
+func (x *BatchExpandURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchExpandURLsResponse.ProtoReflect.Descriptor instead.
+func (*BatchExpandURLsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchExpandURLsResponse) GetResults() []*BatchExpandURLResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// ListURLsRequest selects a page of short URLs
+// All filters are optional; time ranges include the start and exclude the end.
+type ListURLsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PageSize       int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Defaults to 50, at most 1000
+	PageToken      string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page, empty for the first page
+	CreatedAfter   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	AccessedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=accessed_after,json=accessedAfter,proto3" json:"accessed_after,omitempty"`
+	AccessedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=accessed_before,json=accessedBefore,proto3" json:"accessed_before,omitempty"`
+	Domain         string                 `protobuf:"bytes,7,opt,name=domain,proto3" json:"domain,omitempty"` // Destination host such as "example.com", case-insensitive
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListURLsRequest) Reset() {
+	*x = ListURLsRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListURLsRequest) ProtoMessage() {}
+
+func (x *ListURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListURLsRequest.ProtoReflect.Descriptor instead.
+func (*ListURLsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListURLsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListURLsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListURLsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListURLsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListURLsRequest) GetAccessedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AccessedAfter
+	}
+	return nil
+}
+
+func (x *ListURLsRequest) GetAccessedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AccessedBefore
+	}
+	return nil
+}
+
+func (x *ListURLsRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// URLInfo describes a stored short URL
+type URLInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ShortId           string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	ShortUrl          string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"` // Full URL including domain
+	OriginalUrl       string                 `protobuf:"bytes,3,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastAccessed      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_accessed,json=lastAccessed,proto3" json:"last_accessed,omitempty"` // Unset if never accessed
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`          // Unset if the link never expires
+	Disabled          bool                   `protobuf:"varint,7,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	ClickCount        int64                  `protobuf:"varint,8,opt,name=click_count,json=clickCount,proto3" json:"click_count,omitempty"`
+	Status            URLStatus              `protobuf:"varint,9,opt,name=status,proto3,enum=shortlink.URLStatus" json:"status,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,10,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	MaxClicks         int64                  `protobuf:"varint,11,opt,name=max_clicks,json=maxClicks,proto3" json:"max_clicks,omitempty"`                     // 0 if unlimited
+	RemainingClicks   int64                  `protobuf:"varint,12,opt,name=remaining_clicks,json=remainingClicks,proto3" json:"remaining_clicks,omitempty"`   // Only meaningful when max_clicks is set
+	CanonicalUrl      string                 `protobuf:"bytes,13,opt,name=canonical_url,json=canonicalUrl,proto3" json:"canonical_url,omitempty"`             // Normalized original_url that dedup compares
+	QuarantineReason  string                 `protobuf:"bytes,14,opt,name=quarantine_reason,json=quarantineReason,proto3" json:"quarantine_reason,omitempty"` // Threat a reputation check flagged; empty unless quarantined
+	Tags              []string               `protobuf:"bytes,15,rep,name=tags,proto3" json:"tags,omitempty"`                                                 // Sorted, lowercase
+	CampaignId        string                 `protobuf:"bytes,16,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`                   // Empty if the link is not attached to a campaign
+	Targeting         []*TargetingRule       `protobuf:"bytes,17,rep,name=targeting,proto3" json:"targeting,omitempty"`                                       // In evaluation order
+	Variants          []*Variant             `protobuf:"bytes,18,rep,name=variants,proto3" json:"variants,omitempty"`                                         // Empty unless the link splits its traffic
+	NotBefore         *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`                      // Unset if the link is live from creation
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *URLInfo) Reset() {
+	*x = URLInfo{}
+	mi := &file_proto_shortlink_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLInfo) ProtoMessage() {}
+
+func (x *URLInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use URLInfo.ProtoReflect.Descriptor instead.
+func (*URLInfo) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *URLInfo) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *URLInfo) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *URLInfo) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *URLInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *URLInfo) GetLastAccessed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAccessed
+	}
+	return nil
+}
+
+func (x *URLInfo) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *URLInfo) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *URLInfo) GetClickCount() int64 {
+	if x != nil {
+		return x.ClickCount
+	}
+	return 0
+}
+
+func (x *URLInfo) GetStatus() URLStatus {
+	if x != nil {
+		return x.Status
+	}
+	return URLStatus_URL_STATUS_UNSPECIFIED
+}
+
+func (x *URLInfo) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *URLInfo) GetMaxClicks() int64 {
+	if x != nil {
+		return x.MaxClicks
+	}
+	return 0
+}
+
+func (x *URLInfo) GetRemainingClicks() int64 {
+	if x != nil {
+		return x.RemainingClicks
+	}
+	return 0
+}
+
+func (x *URLInfo) GetCanonicalUrl() string {
+	if x != nil {
+		return x.CanonicalUrl
+	}
+	return ""
+}
+
+func (x *URLInfo) GetQuarantineReason() string {
+	if x != nil {
+		return x.QuarantineReason
+	}
+	return ""
+}
+
+func (x *URLInfo) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *URLInfo) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *URLInfo) GetTargeting() []*TargetingRule {
+	if x != nil {
+		return x.Targeting
+	}
+	return nil
+}
+
+func (x *URLInfo) GetVariants() []*Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *URLInfo) GetNotBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotBefore
+	}
+	return nil
+}
+
+// GetURLInfoRequest contains the short URL ID to describe
+type GetURLInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetURLInfoRequest) Reset() {
+	*x = GetURLInfoRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetURLInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetURLInfoRequest) ProtoMessage() {}
+
+func (x *GetURLInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetURLInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetURLInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetURLInfoRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+// GetURLInfoResponse contains the full record of the short URL
+type GetURLInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Info          *URLInfo               `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetURLInfoResponse) Reset() {
+	*x = GetURLInfoResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetURLInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetURLInfoResponse) ProtoMessage() {}
+
+func (x *GetURLInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetURLInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetURLInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetURLInfoResponse) GetInfo() *URLInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+// ListURLsResponse contains a page of short URLs ordered by creation time
+type ListURLsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          []*URLInfo             `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListURLsResponse) Reset() {
+	*x = ListURLsResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListURLsResponse) ProtoMessage() {}
+
+func (x *ListURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListURLsResponse.ProtoReflect.Descriptor instead.
+func (*ListURLsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListURLsResponse) GetUrls() []*URLInfo {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *ListURLsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// WatchClicksRequest selects the clicks to stream; empty filters match every click
+type WatchClicksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"` // Destination host such as "example.com", case-insensitive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchClicksRequest) Reset() {
+	*x = WatchClicksRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchClicksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchClicksRequest) ProtoMessage() {}
+
+func (x *WatchClicksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchClicksRequest.ProtoReflect.Descriptor instead.
+func (*WatchClicksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchClicksRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *WatchClicksRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+// ClickEvent describes one resolution of a short URL
+type ClickEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ClickedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=clicked_at,json=clickedAt,proto3" json:"clicked_at,omitempty"`
+	Referrer      string                 `protobuf:"bytes,4,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,6,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	VariantId     string                 `protobuf:"bytes,7,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"` // Variant served by a split link; empty otherwise
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClickEvent) Reset() {
+	*x = ClickEvent{}
+	mi := &file_proto_shortlink_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickEvent) ProtoMessage() {}
+
+func (x *ClickEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickEvent.ProtoReflect.Descriptor instead.
+func (*ClickEvent) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ClickEvent) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetClickedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClickedAt
+	}
+	return nil
+}
+
+func (x *ClickEvent) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+// DeleteURLRequest contains the short URL ID to delete
+type DeleteURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteURLRequest) Reset() {
+	*x = DeleteURLRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteURLRequest) ProtoMessage() {}
+
+func (x *DeleteURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteURLRequest.ProtoReflect.Descriptor instead.
+func (*DeleteURLRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteURLRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+// DeleteURLResponse is returned once the short URL is deleted
+type DeleteURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteURLResponse) Reset() {
+	*x = DeleteURLResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteURLResponse) ProtoMessage() {}
+
+func (x *DeleteURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteURLResponse.ProtoReflect.Descriptor instead.
+func (*DeleteURLResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{18}
+}
+
+// DisableURLRequest contains the short URL ID to disable
+type DisableURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableURLRequest) Reset() {
+	*x = DisableURLRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableURLRequest) ProtoMessage() {}
+
+func (x *DisableURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableURLRequest.ProtoReflect.Descriptor instead.
+func (*DisableURLRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DisableURLRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+// DisableURLResponse is returned once the short URL is disabled
+type DisableURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableURLResponse) Reset() {
+	*x = DisableURLResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableURLResponse) ProtoMessage() {}
+
+func (x *DisableURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableURLResponse.ProtoReflect.Descriptor instead.
+func (*DisableURLResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{20}
+}
+
+// EnableURLRequest contains the short URL ID to enable
+type EnableURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableURLRequest) Reset() {
+	*x = EnableURLRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableURLRequest) ProtoMessage() {}
+
+func (x *EnableURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableURLRequest.ProtoReflect.Descriptor instead.
+func (*EnableURLRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *EnableURLRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+// EnableURLResponse is returned once the short URL is enabled
+type EnableURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnableURLResponse) Reset() {
+	*x = EnableURLResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnableURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnableURLResponse) ProtoMessage() {}
+
+func (x *EnableURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnableURLResponse.ProtoReflect.Descriptor instead.
+func (*EnableURLResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{22}
+}
+
+// UpdateURLRequest contains the short URL ID and its new destination
+type UpdateURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateURLRequest) Reset() {
+	*x = UpdateURLRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateURLRequest) ProtoMessage() {}
+
+func (x *UpdateURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateURLRequest.ProtoReflect.Descriptor instead.
+func (*UpdateURLRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateURLRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *UpdateURLRequest) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+// UpdateURLResponse contains the retargeted short URL
+type UpdateURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"` // Full URL including domain
+	OriginalUrl   string                 `protobuf:"bytes,3,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateURLResponse) Reset() {
+	*x = UpdateURLResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateURLResponse) ProtoMessage() {}
+
+func (x *UpdateURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateURLResponse.ProtoReflect.Descriptor instead.
+func (*UpdateURLResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateURLResponse) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *UpdateURLResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateURLResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+// GetUsageRequest asks for the usage of the caller's tenant
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{25}
+}
+
+// GetUsageResponse reports link creation against the tenant's quota
+// Limits of 0 are unlimited; the daily count resets at midnight UTC
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TenantId      string                 `protobuf:"bytes,1,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	Day           string                 `protobuf:"bytes,2,opt,name=day,proto3" json:"day,omitempty"` // UTC day of links_today, as YYYY-MM-DD
+	LinksToday    int64                  `protobuf:"varint,3,opt,name=links_today,json=linksToday,proto3" json:"links_today,omitempty"`
+	LinksTotal    int64                  `protobuf:"varint,4,opt,name=links_total,json=linksTotal,proto3" json:"links_total,omitempty"`
+	DailyLimit    int64                  `protobuf:"varint,5,opt,name=daily_limit,json=dailyLimit,proto3" json:"daily_limit,omitempty"`
+	TotalLimit    int64                  `protobuf:"varint,6,opt,name=total_limit,json=totalLimit,proto3" json:"total_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetUsageResponse) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *GetUsageResponse) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *GetUsageResponse) GetLinksToday() int64 {
+	if x != nil {
+		return x.LinksToday
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetLinksTotal() int64 {
+	if x != nil {
+		return x.LinksTotal
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetDailyLimit() int64 {
+	if x != nil {
+		return x.DailyLimit
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetTotalLimit() int64 {
+	if x != nil {
+		return x.TotalLimit
+	}
+	return 0
+}
+
+// AddTagsRequest contains the short URL ID and the tags to add
+type AddTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsRequest) Reset() {
+	*x = AddTagsRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsRequest) ProtoMessage() {}
+
+func (x *AddTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AddTagsRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *AddTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// AddTagsResponse contains every tag of the short URL after the change
+type AddTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTagsResponse) Reset() {
+	*x = AddTagsResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTagsResponse) ProtoMessage() {}
+
+func (x *AddTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTagsResponse.ProtoReflect.Descriptor instead.
+func (*AddTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AddTagsResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// RemoveTagsRequest contains the short URL ID and the tags to remove
+type RemoveTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsRequest) Reset() {
+	*x = RemoveTagsRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsRequest) ProtoMessage() {}
+
+func (x *RemoveTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RemoveTagsRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *RemoveTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// RemoveTagsResponse contains every tag of the short URL after the change
+type RemoveTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTagsResponse) Reset() {
+	*x = RemoveTagsResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTagsResponse) ProtoMessage() {}
+
+func (x *RemoveTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTagsResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RemoveTagsResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// ListURLsByTagRequest selects a page of the short URLs carrying a tag
+type ListURLsByTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`                              // Compared case-insensitively
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Defaults to 50, at most 1000
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page, empty for the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListURLsByTagRequest) Reset() {
+	*x = ListURLsByTagRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListURLsByTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListURLsByTagRequest) ProtoMessage() {}
+
+func (x *ListURLsByTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListURLsByTagRequest.ProtoReflect.Descriptor instead.
+func (*ListURLsByTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListURLsByTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListURLsByTagRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListURLsByTagRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListURLsByTagResponse contains a page of short URLs ordered by short ID
+type ListURLsByTagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          []*URLInfo             `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListURLsByTagResponse) Reset() {
+	*x = ListURLsByTagResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListURLsByTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListURLsByTagResponse) ProtoMessage() {}
+
+func (x *ListURLsByTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListURLsByTagResponse.ProtoReflect.Descriptor instead.
+func (*ListURLsByTagResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListURLsByTagResponse) GetUrls() []*URLInfo {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *ListURLsByTagResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Campaign is a named set of UTM parameters shared by many short URLs
+// ExpandURL adds the parameters to the destination of every attached link,
+// except those the destination already has
+type Campaign struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	UtmParams     map[string]string      `protobuf:"bytes,3,rep,name=utm_params,json=utmParams,proto3" json:"utm_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Keyed by lowercase name such as "utm_source"
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Campaign) Reset() {
+	*x = Campaign{}
+	mi := &file_proto_shortlink_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Campaign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Campaign) ProtoMessage() {}
+
+func (x *Campaign) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Campaign.ProtoReflect.Descriptor instead.
+func (*Campaign) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Campaign) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *Campaign) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Campaign) GetUtmParams() map[string]string {
+	if x != nil {
+		return x.UtmParams
+	}
+	return nil
+}
+
+func (x *Campaign) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Campaign) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// CreateCampaignRequest describes the campaign to create
+type CreateCampaignRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"` // 1-64 letters, digits, '-' or '_'
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Up to 10 parameters named utm_ followed by letters, digits or '_'
+	UtmParams     map[string]string `protobuf:"bytes,3,rep,name=utm_params,json=utmParams,proto3" json:"utm_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCampaignRequest) Reset() {
+	*x = CreateCampaignRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignRequest) ProtoMessage() {}
+
+func (x *CreateCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignRequest.ProtoReflect.Descriptor instead.
+func (*CreateCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateCampaignRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCampaignRequest) GetUtmParams() map[string]string {
+	if x != nil {
+		return x.UtmParams
+	}
+	return nil
+}
+
+// CreateCampaignResponse contains the created campaign
+type CreateCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCampaignResponse) Reset() {
+	*x = CreateCampaignResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignResponse) ProtoMessage() {}
+
+func (x *CreateCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignResponse.ProtoReflect.Descriptor instead.
+func (*CreateCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreateCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+// GetCampaignRequest contains the ID of the campaign to return
+type GetCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignRequest) Reset() {
+	*x = GetCampaignRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignRequest) ProtoMessage() {}
+
+func (x *GetCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignRequest.ProtoReflect.Descriptor instead.
+func (*GetCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetCampaignRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+// GetCampaignResponse contains the campaign
+type GetCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignResponse) Reset() {
+	*x = GetCampaignResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignResponse) ProtoMessage() {}
+
+func (x *GetCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignResponse.ProtoReflect.Descriptor instead.
+func (*GetCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+// UpdateCampaignRequest contains the campaign ID and its new name and parameters
+type UpdateCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	UtmParams     map[string]string      `protobuf:"bytes,3,rep,name=utm_params,json=utmParams,proto3" json:"utm_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCampaignRequest) Reset() {
+	*x = UpdateCampaignRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCampaignRequest) ProtoMessage() {}
+
+func (x *UpdateCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCampaignRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UpdateCampaignRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *UpdateCampaignRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCampaignRequest) GetUtmParams() map[string]string {
+	if x != nil {
+		return x.UtmParams
+	}
+	return nil
+}
+
+// UpdateCampaignResponse contains the updated campaign
+type UpdateCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCampaignResponse) Reset() {
+	*x = UpdateCampaignResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCampaignResponse) ProtoMessage() {}
+
+func (x *UpdateCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCampaignResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UpdateCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+// SetURLCampaignRequest contains the short URL ID and the campaign to attach
+// it to; an empty campaign_id detaches it
+type SetURLCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	CampaignId    string                 `protobuf:"bytes,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetURLCampaignRequest) Reset() {
+	*x = SetURLCampaignRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetURLCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetURLCampaignRequest) ProtoMessage() {}
+
+func (x *SetURLCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetURLCampaignRequest.ProtoReflect.Descriptor instead.
+func (*SetURLCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SetURLCampaignRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *SetURLCampaignRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+// SetURLCampaignResponse is returned once the short URL is attached or detached
+type SetURLCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetURLCampaignResponse) Reset() {
+	*x = SetURLCampaignResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetURLCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetURLCampaignResponse) ProtoMessage() {}
+
+func (x *SetURLCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetURLCampaignResponse.ProtoReflect.Descriptor instead.
+func (*SetURLCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{41}
+}
+
+// TargetingRule sends the visitors it matches to another destination
+// ExpandURL reads the visitor from the x-user-agent, x-accept-language and
+// x-country-code metadata and uses the first rule whose every set condition
+// lists one of the visitor's attributes; visitors no rule matches go to the
+// link's original_url. A rule sets at least one condition
+type TargetingRule struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Devices        []string               `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`     // "ios", "android", "mobile" (any phone or tablet) or "desktop"
+	Languages      []string               `protobuf:"bytes,2,rep,name=languages,proto3" json:"languages,omitempty"` // Language tags such as "en" or "zh-TW"; "en" also matches "en-US"
+	Countries      []string               `protobuf:"bytes,3,rep,name=countries,proto3" json:"countries,omitempty"` // ISO 3166-1 alpha-2 codes such as "US"
+	DestinationUrl string                 `protobuf:"bytes,4,opt,name=destination_url,json=destinationUrl,proto3" json:"destination_url,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TargetingRule) Reset() {
+	*x = TargetingRule{}
+	mi := &file_proto_shortlink_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetingRule) ProtoMessage() {}
+
+func (x *TargetingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetingRule.ProtoReflect.Descriptor instead.
+func (*TargetingRule) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TargetingRule) GetDevices() []string {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *TargetingRule) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *TargetingRule) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *TargetingRule) GetDestinationUrl() string {
+	if x != nil {
+		return x.DestinationUrl
+	}
+	return ""
+}
+
+// SetURLTargetingRequest contains the short URL ID and its new targeting
+// rules, up to 20 in evaluation order
+type SetURLTargetingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	Rules         []*TargetingRule       `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetURLTargetingRequest) Reset() {
+	*x = SetURLTargetingRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetURLTargetingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetURLTargetingRequest) ProtoMessage() {}
+
+func (x *SetURLTargetingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetURLTargetingRequest.ProtoReflect.Descriptor instead.
+func (*SetURLTargetingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetURLTargetingRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *SetURLTargetingRequest) GetRules() []*TargetingRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// SetURLTargetingResponse contains the rules as stored
+type SetURLTargetingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*TargetingRule       `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetURLTargetingResponse) Reset() {
+	*x = SetURLTargetingResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetURLTargetingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetURLTargetingResponse) ProtoMessage() {}
+
+func (x *SetURLTargetingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetURLTargetingResponse.ProtoReflect.Descriptor instead.
+func (*SetURLTargetingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SetURLTargetingResponse) GetRules() []*TargetingRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// Variant is one of the weighted destinations of a split short URL
+// ExpandURL serves each variant in proportion to its weight. Visitors that
+// send an x-visitor-id metadata value keep getting the same variant; others
+// get one at random. The served variant is recorded in click events
+type Variant struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VariantId      string                 `protobuf:"bytes,1,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"` // 1-32 letters, digits, '-' or '_', unique within the link
+	DestinationUrl string                 `protobuf:"bytes,2,opt,name=destination_url,json=destinationUrl,proto3" json:"destination_url,omitempty"`
+	Weight         uint32                 `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"` // 1-10000
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Variant) Reset() {
+	*x = Variant{}
+	mi := &file_proto_shortlink_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Variant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Variant) ProtoMessage() {}
+
+func (x *Variant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Variant.ProtoReflect.Descriptor instead.
+func (*Variant) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Variant) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *Variant) GetDestinationUrl() string {
+	if x != nil {
+		return x.DestinationUrl
+	}
+	return ""
+}
+
+func (x *Variant) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// SetURLVariantsRequest contains the short URL ID and its variants; a split
+// has between 2 and 10 variants, and none ends it
+type SetURLVariantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortId       string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	Variants      []*Variant             `protobuf:"bytes,2,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetURLVariantsRequest) Reset() {
+	*x = SetURLVariantsRequest{}
+	mi := &file_proto_shortlink_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetURLVariantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetURLVariantsRequest) ProtoMessage() {}
+
+func (x *SetURLVariantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetURLVariantsRequest.ProtoReflect.Descriptor instead.
+func (*SetURLVariantsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SetURLVariantsRequest) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *SetURLVariantsRequest) GetVariants() []*Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+// SetURLVariantsResponse contains the variants as stored
+type SetURLVariantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variants      []*Variant             `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetURLVariantsResponse) Reset() {
+	*x = SetURLVariantsResponse{}
+	mi := &file_proto_shortlink_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetURLVariantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetURLVariantsResponse) ProtoMessage() {}
+
+func (x *SetURLVariantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_shortlink_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetURLVariantsResponse.ProtoReflect.Descriptor instead.
+func (*SetURLVariantsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_shortlink_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SetURLVariantsResponse) GetVariants() []*Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+var File_proto_shortlink_proto protoreflect.FileDescriptor
+
+const file_proto_shortlink_proto_rawDesc = "" +
+	"\n" +
+	"\x15proto/shortlink.proto\x12\tshortlink\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd4\x03\n" +
+	"\x11ShortenURLRequest\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12!\n" +
+	"\fcustom_alias\x18\x02 \x01(\tR\vcustomAlias\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12+\n" +
+	"\x03ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12\x1d\n" +
+	"\n" +
+	"max_clicks\x18\x06 \x01(\x03R\tmaxClicks\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1f\n" +
+	"\vcampaign_id\x18\b \x01(\tR\n" +
+	"campaignId\x126\n" +
+	"\ttargeting\x18\t \x03(\v2\x18.shortlink.TargetingRuleR\ttargeting\x12.\n" +
+	"\bvariants\x18\n" +
+	" \x03(\v2\x12.shortlink.VariantR\bvariants\x129\n" +
+	"\n" +
+	"not_before\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\"\xc2\x01\n" +
+	"\x12ShortenURLResponse\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"not_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\">\n" +
+	"\x17BatchShortenURLsRequest\x12#\n" +
+	"\roriginal_urls\x18\x01 \x03(\tR\foriginalUrls\"\xa0\x01\n" +
+	"\x15BatchShortenURLResult\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x19\n" +
+	"\bshort_id\x18\x02 \x01(\tR\ashortId\x12\x1b\n" +
+	"\tshort_url\x18\x03 \x01(\tR\bshortUrl\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"V\n" +
+	"\x18BatchShortenURLsResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .shortlink.BatchShortenURLResultR\aresults\"I\n" +
+	"\x10ExpandURLRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"6\n" +
+	"\x11ExpandURLResponse\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\"5\n" +
+	"\x16BatchExpandURLsRequest\x12\x1b\n" +
+	"\tshort_ids\x18\x01 \x03(\tR\bshortIds\"\x82\x01\n" +
+	"\x14BatchExpandURLResult\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"T\n" +
+	"\x17BatchExpandURLsResponse\x129\n" +
+	"\aresults\x18\x01 \x03(\v2\x1f.shortlink.BatchExpandURLResultR\aresults\"\xf1\x02\n" +
+	"\x0fListURLsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12?\n" +
+	"\rcreated_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12A\n" +
+	"\x0eaccessed_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\raccessedAfter\x12C\n" +
+	"\x0faccessed_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0eaccessedBefore\x12\x16\n" +
+	"\x06domain\x18\a \x01(\tR\x06domain\"\xa9\x06\n" +
+	"\aURLInfo\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x03 \x01(\tR\voriginalUrl\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12?\n" +
+	"\rlast_accessed\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\flastAccessed\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1a\n" +
+	"\bdisabled\x18\a \x01(\bR\bdisabled\x12\x1f\n" +
+	"\vclick_count\x18\b \x01(\x03R\n" +
+	"clickCount\x12,\n" +
+	"\x06status\x18\t \x01(\x0e2\x14.shortlink.URLStatusR\x06status\x12-\n" +
+	"\x12password_protected\x18\n" +
+	" \x01(\bR\x11passwordProtected\x12\x1d\n" +
+	"\n" +
+	"max_clicks\x18\v \x01(\x03R\tmaxClicks\x12)\n" +
+	"\x10remaining_clicks\x18\f \x01(\x03R\x0fremainingClicks\x12#\n" +
+	"\rcanonical_url\x18\r \x01(\tR\fcanonicalUrl\x12+\n" +
+	"\x11quarantine_reason\x18\x0e \x01(\tR\x10quarantineReason\x12\x12\n" +
+	"\x04tags\x18\x0f \x03(\tR\x04tags\x12\x1f\n" +
+	"\vcampaign_id\x18\x10 \x01(\tR\n" +
+	"campaignId\x126\n" +
+	"\ttargeting\x18\x11 \x03(\v2\x18.shortlink.TargetingRuleR\ttargeting\x12.\n" +
+	"\bvariants\x18\x12 \x03(\v2\x12.shortlink.VariantR\bvariants\x129\n" +
+	"\n" +
+	"not_before\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tnotBefore\".\n" +
+	"\x11GetURLInfoRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\"<\n" +
+	"\x12GetURLInfoResponse\x12&\n" +
+	"\x04info\x18\x01 \x01(\v2\x12.shortlink.URLInfoR\x04info\"b\n" +
+	"\x10ListURLsResponse\x12&\n" +
+	"\x04urls\x18\x01 \x03(\v2\x12.shortlink.URLInfoR\x04urls\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"G\n" +
+	"\x12WatchClicksRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"\xfc\x01\n" +
+	"\n" +
+	"ClickEvent\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x129\n" +
+	"\n" +
+	"clicked_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tclickedAt\x12\x1a\n" +
+	"\breferrer\x18\x04 \x01(\tR\breferrer\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\x12\x1b\n" +
+	"\tclient_ip\x18\x06 \x01(\tR\bclientIp\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\a \x01(\tR\tvariantId\"-\n" +
+	"\x10DeleteURLRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\"\x13\n" +
+	"\x11DeleteURLResponse\".\n" +
+	"\x11DisableURLRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\"\x14\n" +
+	"\x12DisableURLResponse\"-\n" +
+	"\x10EnableURLRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\"\x13\n" +
+	"\x11EnableURLResponse\"P\n" +
+	"\x10UpdateURLRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\"n\n" +
+	"\x11UpdateURLResponse\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x03 \x01(\tR\voriginalUrl\"\x11\n" +
+	"\x0fGetUsageRequest\"\xc5\x01\n" +
+	"\x10GetUsageResponse\x12\x1b\n" +
+	"\ttenant_id\x18\x01 \x01(\tR\btenantId\x12\x10\n" +
+	"\x03day\x18\x02 \x01(\tR\x03day\x12\x1f\n" +
+	"\vlinks_today\x18\x03 \x01(\x03R\n" +
+	"linksToday\x12\x1f\n" +
+	"\vlinks_total\x18\x04 \x01(\x03R\n" +
+	"linksTotal\x12\x1f\n" +
+	"\vdaily_limit\x18\x05 \x01(\x03R\n" +
+	"dailyLimit\x12\x1f\n" +
+	"\vtotal_limit\x18\x06 \x01(\x03R\n" +
+	"totalLimit\"?\n" +
+	"\x0eAddTagsRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"%\n" +
+	"\x0fAddTagsResponse\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"B\n" +
+	"\x11RemoveTagsRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"(\n" +
+	"\x12RemoveTagsResponse\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"d\n" +
+	"\x14ListURLsByTagRequest\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"g\n" +
+	"\x15ListURLsByTagResponse\x12&\n" +
+	"\x04urls\x18\x01 \x03(\v2\x12.shortlink.URLInfoR\x04urls\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb6\x02\n" +
+	"\bCampaign\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12A\n" +
+	"\n" +
+	"utm_params\x18\x03 \x03(\v2\".shortlink.Campaign.UtmParamsEntryR\tutmParams\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a<\n" +
+	"\x0eUtmParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xda\x01\n" +
+	"\x15CreateCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12N\n" +
+	"\n" +
+	"utm_params\x18\x03 \x03(\v2/.shortlink.CreateCampaignRequest.UtmParamsEntryR\tutmParams\x1a<\n" +
+	"\x0eUtmParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"I\n" +
+	"\x16CreateCampaignResponse\x12/\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x13.shortlink.CampaignR\bcampaign\"5\n" +
+	"\x12GetCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\"F\n" +
+	"\x13GetCampaignResponse\x12/\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x13.shortlink.CampaignR\bcampaign\"\xda\x01\n" +
+	"\x15UpdateCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12N\n" +
+	"\n" +
+	"utm_params\x18\x03 \x03(\v2/.shortlink.UpdateCampaignRequest.UtmParamsEntryR\tutmParams\x1a<\n" +
+	"\x0eUtmParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"I\n" +
+	"\x16UpdateCampaignResponse\x12/\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x13.shortlink.CampaignR\bcampaign\"S\n" +
+	"\x15SetURLCampaignRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\tR\n" +
+	"campaignId\"\x18\n" +
+	"\x16SetURLCampaignResponse\"\x8e\x01\n" +
+	"\rTargetingRule\x12\x18\n" +
+	"\adevices\x18\x01 \x03(\tR\adevices\x12\x1c\n" +
+	"\tlanguages\x18\x02 \x03(\tR\tlanguages\x12\x1c\n" +
+	"\tcountries\x18\x03 \x03(\tR\tcountries\x12'\n" +
+	"\x0fdestination_url\x18\x04 \x01(\tR\x0edestinationUrl\"c\n" +
+	"\x16SetURLTargetingRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12.\n" +
+	"\x05rules\x18\x02 \x03(\v2\x18.shortlink.TargetingRuleR\x05rules\"I\n" +
+	"\x17SetURLTargetingResponse\x12.\n" +
+	"\x05rules\x18\x01 \x03(\v2\x18.shortlink.TargetingRuleR\x05rules\"i\n" +
+	"\aVariant\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x01 \x01(\tR\tvariantId\x12'\n" +
+	"\x0fdestination_url\x18\x02 \x01(\tR\x0edestinationUrl\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\rR\x06weight\"b\n" +
+	"\x15SetURLVariantsRequest\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x12.\n" +
+	"\bvariants\x18\x02 \x03(\v2\x12.shortlink.VariantR\bvariants\"H\n" +
+	"\x16SetURLVariantsResponse\x12.\n" +
+	"\bvariants\x18\x01 \x03(\v2\x12.shortlink.VariantR\bvariants*\xbf\x01\n" +
+	"\tURLStatus\x12\x1a\n" +
+	"\x16URL_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11URL_STATUS_ACTIVE\x10\x01\x12\x17\n" +
+	"\x13URL_STATUS_DISABLED\x10\x02\x12\x16\n" +
+	"\x12URL_STATUS_EXPIRED\x10\x03\x12\x18\n" +
+	"\x14URL_STATUS_EXHAUSTED\x10\x04\x12\x1a\n" +
+	"\x16URL_STATUS_QUARANTINED\x10\x05\x12\x18\n" +
+	"\x14URL_STATUS_SCHEDULED\x10\x062\xfa\f\n" +
+	"\n" +
+	"URLService\x12I\n" +
+	"\n" +
+	"ShortenURL\x12\x1c.shortlink.ShortenURLRequest\x1a\x1d.shortlink.ShortenURLResponse\x12[\n" +
+	"\x10BatchShortenURLs\x12\".shortlink.BatchShortenURLsRequest\x1a#.shortlink.BatchShortenURLsResponse\x12F\n" +
+	"\tExpandURL\x12\x1b.shortlink.ExpandURLRequest\x1a\x1c.shortlink.ExpandURLResponse\x12X\n" +
+	"\x0fBatchExpandURLs\x12!.shortlink.BatchExpandURLsRequest\x1a\".shortlink.BatchExpandURLsResponse\x12I\n" +
+	"\n" +
+	"GetURLInfo\x12\x1c.shortlink.GetURLInfoRequest\x1a\x1d.shortlink.GetURLInfoResponse\x12C\n" +
+	"\bListURLs\x12\x1a.shortlink.ListURLsRequest\x1a\x1b.shortlink.ListURLsResponse\x12E\n" +
+	"\vWatchClicks\x12\x1d.shortlink.WatchClicksRequest\x1a\x15.shortlink.ClickEvent0\x01\x12F\n" +
+	"\tDeleteURL\x12\x1b.shortlink.DeleteURLRequest\x1a\x1c.shortlink.DeleteURLResponse\x12I\n" +
+	"\n" +
+	"DisableURL\x12\x1c.shortlink.DisableURLRequest\x1a\x1d.shortlink.DisableURLResponse\x12F\n" +
+	"\tEnableURL\x12\x1b.shortlink.EnableURLRequest\x1a\x1c.shortlink.EnableURLResponse\x12F\n" +
+	"\tUpdateURL\x12\x1b.shortlink.UpdateURLRequest\x1a\x1c.shortlink.UpdateURLResponse\x12C\n" +
+	"\bGetUsage\x12\x1a.shortlink.GetUsageRequest\x1a\x1b.shortlink.GetUsageResponse\x12@\n" +
+	"\aAddTags\x12\x19.shortlink.AddTagsRequest\x1a\x1a.shortlink.AddTagsResponse\x12I\n" +
 	"\n" +
-	"ShortenURL\x12\x1c.shortlink.ShortenURLRequest\x1a\x1d.shortlink.ShortenURLResponse\x12F\n" +
-	"\tExpandURL\x12\x1b.shortlink.ExpandURLRequest\x1a\x1c.shortlink.ExpandURLResponseB1Z/github.com/yourusername/shortlink-gateway/protob\x06proto3"
+	"RemoveTags\x12\x1c.shortlink.RemoveTagsRequest\x1a\x1d.shortlink.RemoveTagsResponse\x12R\n" +
+	"\rListURLsByTag\x12\x1f.shortlink.ListURLsByTagRequest\x1a .shortlink.ListURLsByTagResponse\x12U\n" +
+	"\x0eCreateCampaign\x12 .shortlink.CreateCampaignRequest\x1a!.shortlink.CreateCampaignResponse\x12L\n" +
+	"\vGetCampaign\x12\x1d.shortlink.GetCampaignRequest\x1a\x1e.shortlink.GetCampaignResponse\x12U\n" +
+	"\x0eUpdateCampaign\x12 .shortlink.UpdateCampaignRequest\x1a!.shortlink.UpdateCampaignResponse\x12U\n" +
+	"\x0eSetURLCampaign\x12 .shortlink.SetURLCampaignRequest\x1a!.shortlink.SetURLCampaignResponse\x12X\n" +
+	"\x0fSetURLTargeting\x12!.shortlink.SetURLTargetingRequest\x1a\".shortlink.SetURLTargetingResponse\x12U\n" +
+	"\x0eSetURLVariants\x12 .shortlink.SetURLVariantsRequest\x1a!.shortlink.SetURLVariantsResponseB-Z+github.com/hohotang/shortlink-gateway/protob\x06proto3"
 
 var (
 	file_proto_shortlink_proto_rawDescOnce sync.Once
@@ -241,23 +3167,148 @@ func file_proto_shortlink_proto_rawDescGZIP() []byte {
 	return file_proto_shortlink_proto_rawDescData
 }
 
-var file_proto_shortlink_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_shortlink_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_shortlink_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_proto_shortlink_proto_goTypes = []any{
-	(*ShortenURLRequest)(nil),  // 0: shortlink.ShortenURLRequest
-	(*ShortenURLResponse)(nil), // 1: shortlink.ShortenURLResponse
-	(*ExpandURLRequest)(nil),   // 2: shortlink.ExpandURLRequest
-	(*ExpandURLResponse)(nil),  // 3: shortlink.ExpandURLResponse
+	(URLStatus)(0),                   // 0: shortlink.URLStatus
+	(*ShortenURLRequest)(nil),        // 1: shortlink.ShortenURLRequest
+	(*ShortenURLResponse)(nil),       // 2: shortlink.ShortenURLResponse
+	(*BatchShortenURLsRequest)(nil),  // 3: shortlink.BatchShortenURLsRequest
+	(*BatchShortenURLResult)(nil),    // 4: shortlink.BatchShortenURLResult
+	(*BatchShortenURLsResponse)(nil), // 5: shortlink.BatchShortenURLsResponse
+	(*ExpandURLRequest)(nil),         // 6: shortlink.ExpandURLRequest
+	(*ExpandURLResponse)(nil),        // 7: shortlink.ExpandURLResponse
+	(*BatchExpandURLsRequest)(nil),   // 8: shortlink.BatchExpandURLsRequest
+	(*BatchExpandURLResult)(nil),     // 9: shortlink.BatchExpandURLResult
+	(*BatchExpandURLsResponse)(nil),  // 10: shortlink.BatchExpandURLsResponse
+	(*ListURLsRequest)(nil),          // 11: shortlink.ListURLsRequest
+	(*URLInfo)(nil),                  // 12: shortlink.URLInfo
+	(*GetURLInfoRequest)(nil),        // 13: shortlink.GetURLInfoRequest
+	(*GetURLInfoResponse)(nil),       // 14: shortlink.GetURLInfoResponse
+	(*ListURLsResponse)(nil),         // 15: shortlink.ListURLsResponse
+	(*WatchClicksRequest)(nil),       // 16: shortlink.WatchClicksRequest
+	(*ClickEvent)(nil),               // 17: shortlink.ClickEvent
+	(*DeleteURLRequest)(nil),         // 18: shortlink.DeleteURLRequest
+	(*DeleteURLResponse)(nil),        // 19: shortlink.DeleteURLResponse
+	(*DisableURLRequest)(nil),        // 20: shortlink.DisableURLRequest
+	(*DisableURLResponse)(nil),       // 21: shortlink.DisableURLResponse
+	(*EnableURLRequest)(nil),         // 22: shortlink.EnableURLRequest
+	(*EnableURLResponse)(nil),        // 23: shortlink.EnableURLResponse
+	(*UpdateURLRequest)(nil),         // 24: shortlink.UpdateURLRequest
+	(*UpdateURLResponse)(nil),        // 25: shortlink.UpdateURLResponse
+	(*GetUsageRequest)(nil),          // 26: shortlink.GetUsageRequest
+	(*GetUsageResponse)(nil),         // 27: shortlink.GetUsageResponse
+	(*AddTagsRequest)(nil),           // 28: shortlink.AddTagsRequest
+	(*AddTagsResponse)(nil),          // 29: shortlink.AddTagsResponse
+	(*RemoveTagsRequest)(nil),        // 30: shortlink.RemoveTagsRequest
+	(*RemoveTagsResponse)(nil),       // 31: shortlink.RemoveTagsResponse
+	(*ListURLsByTagRequest)(nil),     // 32: shortlink.ListURLsByTagRequest
+	(*ListURLsByTagResponse)(nil),    // 33: shortlink.ListURLsByTagResponse
+	(*Campaign)(nil),                 // 34: shortlink.Campaign
+	(*CreateCampaignRequest)(nil),    // 35: shortlink.CreateCampaignRequest
+	(*CreateCampaignResponse)(nil),   // 36: shortlink.CreateCampaignResponse
+	(*GetCampaignRequest)(nil),       // 37: shortlink.GetCampaignRequest
+	(*GetCampaignResponse)(nil),      // 38: shortlink.GetCampaignResponse
+	(*UpdateCampaignRequest)(nil),    // 39: shortlink.UpdateCampaignRequest
+	(*UpdateCampaignResponse)(nil),   // 40: shortlink.UpdateCampaignResponse
+	(*SetURLCampaignRequest)(nil),    // 41: shortlink.SetURLCampaignRequest
+	(*SetURLCampaignResponse)(nil),   // 42: shortlink.SetURLCampaignResponse
+	(*TargetingRule)(nil),            // 43: shortlink.TargetingRule
+	(*SetURLTargetingRequest)(nil),   // 44: shortlink.SetURLTargetingRequest
+	(*SetURLTargetingResponse)(nil),  // 45: shortlink.SetURLTargetingResponse
+	(*Variant)(nil),                  // 46: shortlink.Variant
+	(*SetURLVariantsRequest)(nil),    // 47: shortlink.SetURLVariantsRequest
+	(*SetURLVariantsResponse)(nil),   // 48: shortlink.SetURLVariantsResponse
+	nil,                              // 49: shortlink.Campaign.UtmParamsEntry
+	nil,                              // 50: shortlink.CreateCampaignRequest.UtmParamsEntry
+	nil,                              // 51: shortlink.UpdateCampaignRequest.UtmParamsEntry
+	(*timestamppb.Timestamp)(nil),    // 52: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),      // 53: google.protobuf.Duration
 }
 var file_proto_shortlink_proto_depIdxs = []int32{
-	0, // 0: shortlink.URLService.ShortenURL:input_type -> shortlink.ShortenURLRequest
-	2, // 1: shortlink.URLService.ExpandURL:input_type -> shortlink.ExpandURLRequest
-	1, // 2: shortlink.URLService.ShortenURL:output_type -> shortlink.ShortenURLResponse
-	3, // 3: shortlink.URLService.ExpandURL:output_type -> shortlink.ExpandURLResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	52, // 0: shortlink.ShortenURLRequest.expires_at:type_name -> google.protobuf.Timestamp
+	53, // 1: shortlink.ShortenURLRequest.ttl:type_name -> google.protobuf.Duration
+	43, // 2: shortlink.ShortenURLRequest.targeting:type_name -> shortlink.TargetingRule
+	46, // 3: shortlink.ShortenURLRequest.variants:type_name -> shortlink.Variant
+	52, // 4: shortlink.ShortenURLRequest.not_before:type_name -> google.protobuf.Timestamp
+	52, // 5: shortlink.ShortenURLResponse.expires_at:type_name -> google.protobuf.Timestamp
+	52, // 6: shortlink.ShortenURLResponse.not_before:type_name -> google.protobuf.Timestamp
+	4,  // 7: shortlink.BatchShortenURLsResponse.results:type_name -> shortlink.BatchShortenURLResult
+	9,  // 8: shortlink.BatchExpandURLsResponse.results:type_name -> shortlink.BatchExpandURLResult
+	52, // 9: shortlink.ListURLsRequest.created_after:type_name -> google.protobuf.Timestamp
+	52, // 10: shortlink.ListURLsRequest.created_before:type_name -> google.protobuf.Timestamp
+	52, // 11: shortlink.ListURLsRequest.accessed_after:type_name -> google.protobuf.Timestamp
+	52, // 12: shortlink.ListURLsRequest.accessed_before:type_name -> google.protobuf.Timestamp
+	52, // 13: shortlink.URLInfo.created_at:type_name -> google.protobuf.Timestamp
+	52, // 14: shortlink.URLInfo.last_accessed:type_name -> google.protobuf.Timestamp
+	52, // 15: shortlink.URLInfo.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 16: shortlink.URLInfo.status:type_name -> shortlink.URLStatus
+	43, // 17: shortlink.URLInfo.targeting:type_name -> shortlink.TargetingRule
+	46, // 18: shortlink.URLInfo.variants:type_name -> shortlink.Variant
+	52, // 19: shortlink.URLInfo.not_before:type_name -> google.protobuf.Timestamp
+	12, // 20: shortlink.GetURLInfoResponse.info:type_name -> shortlink.URLInfo
+	12, // 21: shortlink.ListURLsResponse.urls:type_name -> shortlink.URLInfo
+	52, // 22: shortlink.ClickEvent.clicked_at:type_name -> google.protobuf.Timestamp
+	12, // 23: shortlink.ListURLsByTagResponse.urls:type_name -> shortlink.URLInfo
+	49, // 24: shortlink.Campaign.utm_params:type_name -> shortlink.Campaign.UtmParamsEntry
+	52, // 25: shortlink.Campaign.created_at:type_name -> google.protobuf.Timestamp
+	52, // 26: shortlink.Campaign.updated_at:type_name -> google.protobuf.Timestamp
+	50, // 27: shortlink.CreateCampaignRequest.utm_params:type_name -> shortlink.CreateCampaignRequest.UtmParamsEntry
+	34, // 28: shortlink.CreateCampaignResponse.campaign:type_name -> shortlink.Campaign
+	34, // 29: shortlink.GetCampaignResponse.campaign:type_name -> shortlink.Campaign
+	51, // 30: shortlink.UpdateCampaignRequest.utm_params:type_name -> shortlink.UpdateCampaignRequest.UtmParamsEntry
+	34, // 31: shortlink.UpdateCampaignResponse.campaign:type_name -> shortlink.Campaign
+	43, // 32: shortlink.SetURLTargetingRequest.rules:type_name -> shortlink.TargetingRule
+	43, // 33: shortlink.SetURLTargetingResponse.rules:type_name -> shortlink.TargetingRule
+	46, // 34: shortlink.SetURLVariantsRequest.variants:type_name -> shortlink.Variant
+	46, // 35: shortlink.SetURLVariantsResponse.variants:type_name -> shortlink.Variant
+	1,  // 36: shortlink.URLService.ShortenURL:input_type -> shortlink.ShortenURLRequest
+	3,  // 37: shortlink.URLService.BatchShortenURLs:input_type -> shortlink.BatchShortenURLsRequest
+	6,  // 38: shortlink.URLService.ExpandURL:input_type -> shortlink.ExpandURLRequest
+	8,  // 39: shortlink.URLService.BatchExpandURLs:input_type -> shortlink.BatchExpandURLsRequest
+	13, // 40: shortlink.URLService.GetURLInfo:input_type -> shortlink.GetURLInfoRequest
+	11, // 41: shortlink.URLService.ListURLs:input_type -> shortlink.ListURLsRequest
+	16, // 42: shortlink.URLService.WatchClicks:input_type -> shortlink.WatchClicksRequest
+	18, // 43: shortlink.URLService.DeleteURL:input_type -> shortlink.DeleteURLRequest
+	20, // 44: shortlink.URLService.DisableURL:input_type -> shortlink.DisableURLRequest
+	22, // 45: shortlink.URLService.EnableURL:input_type -> shortlink.EnableURLRequest
+	24, // 46: shortlink.URLService.UpdateURL:input_type -> shortlink.UpdateURLRequest
+	26, // 47: shortlink.URLService.GetUsage:input_type -> shortlink.GetUsageRequest
+	28, // 48: shortlink.URLService.AddTags:input_type -> shortlink.AddTagsRequest
+	30, // 49: shortlink.URLService.RemoveTags:input_type -> shortlink.RemoveTagsRequest
+	32, // 50: shortlink.URLService.ListURLsByTag:input_type -> shortlink.ListURLsByTagRequest
+	35, // 51: shortlink.URLService.CreateCampaign:input_type -> shortlink.CreateCampaignRequest
+	37, // 52: shortlink.URLService.GetCampaign:input_type -> shortlink.GetCampaignRequest
+	39, // 53: shortlink.URLService.UpdateCampaign:input_type -> shortlink.UpdateCampaignRequest
+	41, // 54: shortlink.URLService.SetURLCampaign:input_type -> shortlink.SetURLCampaignRequest
+	44, // 55: shortlink.URLService.SetURLTargeting:input_type -> shortlink.SetURLTargetingRequest
+	47, // 56: shortlink.URLService.SetURLVariants:input_type -> shortlink.SetURLVariantsRequest
+	2,  // 57: shortlink.URLService.ShortenURL:output_type -> shortlink.ShortenURLResponse
+	5,  // 58: shortlink.URLService.BatchShortenURLs:output_type -> shortlink.BatchShortenURLsResponse
+	7,  // 59: shortlink.URLService.ExpandURL:output_type -> shortlink.ExpandURLResponse
+	10, // 60: shortlink.URLService.BatchExpandURLs:output_type -> shortlink.BatchExpandURLsResponse
+	14, // 61: shortlink.URLService.GetURLInfo:output_type -> shortlink.GetURLInfoResponse
+	15, // 62: shortlink.URLService.ListURLs:output_type -> shortlink.ListURLsResponse
+	17, // 63: shortlink.URLService.WatchClicks:output_type -> shortlink.ClickEvent
+	19, // 64: shortlink.URLService.DeleteURL:output_type -> shortlink.DeleteURLResponse
+	21, // 65: shortlink.URLService.DisableURL:output_type -> shortlink.DisableURLResponse
+	23, // 66: shortlink.URLService.EnableURL:output_type -> shortlink.EnableURLResponse
+	25, // 67: shortlink.URLService.UpdateURL:output_type -> shortlink.UpdateURLResponse
+	27, // 68: shortlink.URLService.GetUsage:output_type -> shortlink.GetUsageResponse
+	29, // 69: shortlink.URLService.AddTags:output_type -> shortlink.AddTagsResponse
+	31, // 70: shortlink.URLService.RemoveTags:output_type -> shortlink.RemoveTagsResponse
+	33, // 71: shortlink.URLService.ListURLsByTag:output_type -> shortlink.ListURLsByTagResponse
+	36, // 72: shortlink.URLService.CreateCampaign:output_type -> shortlink.CreateCampaignResponse
+	38, // 73: shortlink.URLService.GetCampaign:output_type -> shortlink.GetCampaignResponse
+	40, // 74: shortlink.URLService.UpdateCampaign:output_type -> shortlink.UpdateCampaignResponse
+	42, // 75: shortlink.URLService.SetURLCampaign:output_type -> shortlink.SetURLCampaignResponse
+	45, // 76: shortlink.URLService.SetURLTargeting:output_type -> shortlink.SetURLTargetingResponse
+	48, // 77: shortlink.URLService.SetURLVariants:output_type -> shortlink.SetURLVariantsResponse
+	57, // [57:78] is the sub-list for method output_type
+	36, // [36:57] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_proto_shortlink_proto_init() }
@@ -270,13 +3321,14 @@ func file_proto_shortlink_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_shortlink_proto_rawDesc), len(file_proto_shortlink_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_shortlink_proto_goTypes,
 		DependencyIndexes: file_proto_shortlink_proto_depIdxs,
+		EnumInfos:         file_proto_shortlink_proto_enumTypes,
 		MessageInfos:      file_proto_shortlink_proto_msgTypes,
 	}.Build()
 	File_proto_shortlink_proto = out.File
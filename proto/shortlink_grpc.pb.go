@@ -19,8 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	URLService_ShortenURL_FullMethodName = "/shortlink.URLService/ShortenURL"
-	URLService_ExpandURL_FullMethodName  = "/shortlink.URLService/ExpandURL"
+	URLService_ShortenURL_FullMethodName       = "/shortlink.URLService/ShortenURL"
+	URLService_BatchShortenURLs_FullMethodName = "/shortlink.URLService/BatchShortenURLs"
+	URLService_ExpandURL_FullMethodName        = "/shortlink.URLService/ExpandURL"
+	URLService_BatchExpandURLs_FullMethodName  = "/shortlink.URLService/BatchExpandURLs"
+	URLService_GetURLInfo_FullMethodName       = "/shortlink.URLService/GetURLInfo"
+	URLService_ListURLs_FullMethodName         = "/shortlink.URLService/ListURLs"
+	URLService_WatchClicks_FullMethodName      = "/shortlink.URLService/WatchClicks"
+	URLService_DeleteURL_FullMethodName        = "/shortlink.URLService/DeleteURL"
+	URLService_DisableURL_FullMethodName       = "/shortlink.URLService/DisableURL"
+	URLService_EnableURL_FullMethodName        = "/shortlink.URLService/EnableURL"
+	URLService_UpdateURL_FullMethodName        = "/shortlink.URLService/UpdateURL"
+	URLService_GetUsage_FullMethodName         = "/shortlink.URLService/GetUsage"
+	URLService_AddTags_FullMethodName          = "/shortlink.URLService/AddTags"
+	URLService_RemoveTags_FullMethodName       = "/shortlink.URLService/RemoveTags"
+	URLService_ListURLsByTag_FullMethodName    = "/shortlink.URLService/ListURLsByTag"
+	URLService_CreateCampaign_FullMethodName   = "/shortlink.URLService/CreateCampaign"
+	URLService_GetCampaign_FullMethodName      = "/shortlink.URLService/GetCampaign"
+	URLService_UpdateCampaign_FullMethodName   = "/shortlink.URLService/UpdateCampaign"
+	URLService_SetURLCampaign_FullMethodName   = "/shortlink.URLService/SetURLCampaign"
+	URLService_SetURLTargeting_FullMethodName  = "/shortlink.URLService/SetURLTargeting"
+	URLService_SetURLVariants_FullMethodName   = "/shortlink.URLService/SetURLVariants"
 )
 
 // URLServiceClient is the client API for URLService service.
@@ -31,8 +50,52 @@ const (
 type URLServiceClient interface {
 	// ShortenURL creates a short URL from the original URL
 	ShortenURL(ctx context.Context, in *ShortenURLRequest, opts ...grpc.CallOption) (*ShortenURLResponse, error)
+	// BatchShortenURLs creates short URLs for many original URLs in one call
+	BatchShortenURLs(ctx context.Context, in *BatchShortenURLsRequest, opts ...grpc.CallOption) (*BatchShortenURLsResponse, error)
 	// ExpandURL resolves a short URL to its original URL
+	// Before a link's activation window opens it fails with FAILED_PRECONDITION
+	// URL_NOT_YET_ACTIVE, whose metadata carries not_before and, if the server
+	// has one configured, a "coming soon" fallback_url
 	ExpandURL(ctx context.Context, in *ExpandURLRequest, opts ...grpc.CallOption) (*ExpandURLResponse, error)
+	// BatchExpandURLs resolves many short URLs to their original URLs in one call
+	BatchExpandURLs(ctx context.Context, in *BatchExpandURLsRequest, opts ...grpc.CallOption) (*BatchExpandURLsResponse, error)
+	// GetURLInfo returns the full record of a short URL without counting as an access
+	GetURLInfo(ctx context.Context, in *GetURLInfoRequest, opts ...grpc.CallOption) (*GetURLInfoResponse, error)
+	// ListURLs pages through stored short URLs, oldest first
+	ListURLs(ctx context.Context, in *ListURLsRequest, opts ...grpc.CallOption) (*ListURLsResponse, error)
+	// WatchClicks streams clicks on short URLs as they happen
+	// Streams that fall too far behind are ended with RESOURCE_EXHAUSTED
+	WatchClicks(ctx context.Context, in *WatchClicksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ClickEvent], error)
+	// DeleteURL permanently removes a short URL
+	DeleteURL(ctx context.Context, in *DeleteURLRequest, opts ...grpc.CallOption) (*DeleteURLResponse, error)
+	// DisableURL stops a short URL from resolving without deleting it
+	DisableURL(ctx context.Context, in *DisableURLRequest, opts ...grpc.CallOption) (*DisableURLResponse, error)
+	// EnableURL makes a disabled short URL resolve again
+	EnableURL(ctx context.Context, in *EnableURLRequest, opts ...grpc.CallOption) (*EnableURLResponse, error)
+	// UpdateURL points an existing short URL at a new original URL
+	UpdateURL(ctx context.Context, in *UpdateURLRequest, opts ...grpc.CallOption) (*UpdateURLResponse, error)
+	// GetUsage reports how many links the caller's tenant has created, and its quota
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+	// AddTags adds tags to a short URL; tags it already has are kept
+	AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*AddTagsResponse, error)
+	// RemoveTags removes tags from a short URL; tags it does not have are ignored
+	RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*RemoveTagsResponse, error)
+	// ListURLsByTag pages through the short URLs carrying a tag, by short ID
+	ListURLsByTag(ctx context.Context, in *ListURLsByTagRequest, opts ...grpc.CallOption) (*ListURLsByTagResponse, error)
+	// CreateCampaign saves a named set of UTM parameters that links can be attached to
+	CreateCampaign(ctx context.Context, in *CreateCampaignRequest, opts ...grpc.CallOption) (*CreateCampaignResponse, error)
+	// GetCampaign returns a campaign
+	GetCampaign(ctx context.Context, in *GetCampaignRequest, opts ...grpc.CallOption) (*GetCampaignResponse, error)
+	// UpdateCampaign replaces the name and UTM parameters of a campaign; every
+	// attached link resolves with the new parameters
+	UpdateCampaign(ctx context.Context, in *UpdateCampaignRequest, opts ...grpc.CallOption) (*UpdateCampaignResponse, error)
+	// SetURLCampaign attaches a short URL to a campaign, or detaches it
+	SetURLCampaign(ctx context.Context, in *SetURLCampaignRequest, opts ...grpc.CallOption) (*SetURLCampaignResponse, error)
+	// SetURLTargeting replaces the targeting rules of a short URL; no rules clears them
+	SetURLTargeting(ctx context.Context, in *SetURLTargetingRequest, opts ...grpc.CallOption) (*SetURLTargetingResponse, error)
+	// SetURLVariants splits the traffic of a short URL between weighted
+	// destinations; no variants ends the split
+	SetURLVariants(ctx context.Context, in *SetURLVariantsRequest, opts ...grpc.CallOption) (*SetURLVariantsResponse, error)
 }
 
 type uRLServiceClient struct {
@@ -53,6 +116,16 @@ func (c *uRLServiceClient) ShortenURL(ctx context.Context, in *ShortenURLRequest
 	return out, nil
 }
 
+func (c *uRLServiceClient) BatchShortenURLs(ctx context.Context, in *BatchShortenURLsRequest, opts ...grpc.CallOption) (*BatchShortenURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchShortenURLsResponse)
+	err := c.cc.Invoke(ctx, URLService_BatchShortenURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *uRLServiceClient) ExpandURL(ctx context.Context, in *ExpandURLRequest, opts ...grpc.CallOption) (*ExpandURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ExpandURLResponse)
@@ -63,6 +136,195 @@ func (c *uRLServiceClient) ExpandURL(ctx context.Context, in *ExpandURLRequest,
 	return out, nil
 }
 
+func (c *uRLServiceClient) BatchExpandURLs(ctx context.Context, in *BatchExpandURLsRequest, opts ...grpc.CallOption) (*BatchExpandURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchExpandURLsResponse)
+	err := c.cc.Invoke(ctx, URLService_BatchExpandURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) GetURLInfo(ctx context.Context, in *GetURLInfoRequest, opts ...grpc.CallOption) (*GetURLInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetURLInfoResponse)
+	err := c.cc.Invoke(ctx, URLService_GetURLInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) ListURLs(ctx context.Context, in *ListURLsRequest, opts ...grpc.CallOption) (*ListURLsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListURLsResponse)
+	err := c.cc.Invoke(ctx, URLService_ListURLs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) WatchClicks(ctx context.Context, in *WatchClicksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ClickEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &URLService_ServiceDesc.Streams[0], URLService_WatchClicks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchClicksRequest, ClickEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type URLService_WatchClicksClient = grpc.ServerStreamingClient[ClickEvent]
+
+func (c *uRLServiceClient) DeleteURL(ctx context.Context, in *DeleteURLRequest, opts ...grpc.CallOption) (*DeleteURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteURLResponse)
+	err := c.cc.Invoke(ctx, URLService_DeleteURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) DisableURL(ctx context.Context, in *DisableURLRequest, opts ...grpc.CallOption) (*DisableURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableURLResponse)
+	err := c.cc.Invoke(ctx, URLService_DisableURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) EnableURL(ctx context.Context, in *EnableURLRequest, opts ...grpc.CallOption) (*EnableURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnableURLResponse)
+	err := c.cc.Invoke(ctx, URLService_EnableURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) UpdateURL(ctx context.Context, in *UpdateURLRequest, opts ...grpc.CallOption) (*UpdateURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateURLResponse)
+	err := c.cc.Invoke(ctx, URLService_UpdateURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, URLService_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) AddTags(ctx context.Context, in *AddTagsRequest, opts ...grpc.CallOption) (*AddTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTagsResponse)
+	err := c.cc.Invoke(ctx, URLService_AddTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) RemoveTags(ctx context.Context, in *RemoveTagsRequest, opts ...grpc.CallOption) (*RemoveTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveTagsResponse)
+	err := c.cc.Invoke(ctx, URLService_RemoveTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) ListURLsByTag(ctx context.Context, in *ListURLsByTagRequest, opts ...grpc.CallOption) (*ListURLsByTagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListURLsByTagResponse)
+	err := c.cc.Invoke(ctx, URLService_ListURLsByTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) CreateCampaign(ctx context.Context, in *CreateCampaignRequest, opts ...grpc.CallOption) (*CreateCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCampaignResponse)
+	err := c.cc.Invoke(ctx, URLService_CreateCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) GetCampaign(ctx context.Context, in *GetCampaignRequest, opts ...grpc.CallOption) (*GetCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCampaignResponse)
+	err := c.cc.Invoke(ctx, URLService_GetCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) UpdateCampaign(ctx context.Context, in *UpdateCampaignRequest, opts ...grpc.CallOption) (*UpdateCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCampaignResponse)
+	err := c.cc.Invoke(ctx, URLService_UpdateCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) SetURLCampaign(ctx context.Context, in *SetURLCampaignRequest, opts ...grpc.CallOption) (*SetURLCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetURLCampaignResponse)
+	err := c.cc.Invoke(ctx, URLService_SetURLCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) SetURLTargeting(ctx context.Context, in *SetURLTargetingRequest, opts ...grpc.CallOption) (*SetURLTargetingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetURLTargetingResponse)
+	err := c.cc.Invoke(ctx, URLService_SetURLTargeting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uRLServiceClient) SetURLVariants(ctx context.Context, in *SetURLVariantsRequest, opts ...grpc.CallOption) (*SetURLVariantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetURLVariantsResponse)
+	err := c.cc.Invoke(ctx, URLService_SetURLVariants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // URLServiceServer is the server API for URLService service.
 // All implementations must embed UnimplementedURLServiceServer
 // for forward compatibility.
@@ -71,8 +333,52 @@ func (c *uRLServiceClient) ExpandURL(ctx context.Context, in *ExpandURLRequest,
 type URLServiceServer interface {
 	// ShortenURL creates a short URL from the original URL
 	ShortenURL(context.Context, *ShortenURLRequest) (*ShortenURLResponse, error)
+	// BatchShortenURLs creates short URLs for many original URLs in one call
+	BatchShortenURLs(context.Context, *BatchShortenURLsRequest) (*BatchShortenURLsResponse, error)
 	// ExpandURL resolves a short URL to its original URL
+	// Before a link's activation window opens it fails with FAILED_PRECONDITION
+	// URL_NOT_YET_ACTIVE, whose metadata carries not_before and, if the server
+	// has one configured, a "coming soon" fallback_url
 	ExpandURL(context.Context, *ExpandURLRequest) (*ExpandURLResponse, error)
+	// BatchExpandURLs resolves many short URLs to their original URLs in one call
+	BatchExpandURLs(context.Context, *BatchExpandURLsRequest) (*BatchExpandURLsResponse, error)
+	// GetURLInfo returns the full record of a short URL without counting as an access
+	GetURLInfo(context.Context, *GetURLInfoRequest) (*GetURLInfoResponse, error)
+	// ListURLs pages through stored short URLs, oldest first
+	ListURLs(context.Context, *ListURLsRequest) (*ListURLsResponse, error)
+	// WatchClicks streams clicks on short URLs as they happen
+	// Streams that fall too far behind are ended with RESOURCE_EXHAUSTED
+	WatchClicks(*WatchClicksRequest, grpc.ServerStreamingServer[ClickEvent]) error
+	// DeleteURL permanently removes a short URL
+	DeleteURL(context.Context, *DeleteURLRequest) (*DeleteURLResponse, error)
+	// DisableURL stops a short URL from resolving without deleting it
+	DisableURL(context.Context, *DisableURLRequest) (*DisableURLResponse, error)
+	// EnableURL makes a disabled short URL resolve again
+	EnableURL(context.Context, *EnableURLRequest) (*EnableURLResponse, error)
+	// UpdateURL points an existing short URL at a new original URL
+	UpdateURL(context.Context, *UpdateURLRequest) (*UpdateURLResponse, error)
+	// GetUsage reports how many links the caller's tenant has created, and its quota
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	// AddTags adds tags to a short URL; tags it already has are kept
+	AddTags(context.Context, *AddTagsRequest) (*AddTagsResponse, error)
+	// RemoveTags removes tags from a short URL; tags it does not have are ignored
+	RemoveTags(context.Context, *RemoveTagsRequest) (*RemoveTagsResponse, error)
+	// ListURLsByTag pages through the short URLs carrying a tag, by short ID
+	ListURLsByTag(context.Context, *ListURLsByTagRequest) (*ListURLsByTagResponse, error)
+	// CreateCampaign saves a named set of UTM parameters that links can be attached to
+	CreateCampaign(context.Context, *CreateCampaignRequest) (*CreateCampaignResponse, error)
+	// GetCampaign returns a campaign
+	GetCampaign(context.Context, *GetCampaignRequest) (*GetCampaignResponse, error)
+	// UpdateCampaign replaces the name and UTM parameters of a campaign; every
+	// attached link resolves with the new parameters
+	UpdateCampaign(context.Context, *UpdateCampaignRequest) (*UpdateCampaignResponse, error)
+	// SetURLCampaign attaches a short URL to a campaign, or detaches it
+	SetURLCampaign(context.Context, *SetURLCampaignRequest) (*SetURLCampaignResponse, error)
+	// SetURLTargeting replaces the targeting rules of a short URL; no rules clears them
+	SetURLTargeting(context.Context, *SetURLTargetingRequest) (*SetURLTargetingResponse, error)
+	// SetURLVariants splits the traffic of a short URL between weighted
+	// destinations; no variants ends the split
+	SetURLVariants(context.Context, *SetURLVariantsRequest) (*SetURLVariantsResponse, error)
 	mustEmbedUnimplementedURLServiceServer()
 }
 
@@ -86,9 +392,66 @@ type UnimplementedURLServiceServer struct{}
 func (UnimplementedURLServiceServer) ShortenURL(context.Context, *ShortenURLRequest) (*ShortenURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShortenURL not implemented")
 }
+func (UnimplementedURLServiceServer) BatchShortenURLs(context.Context, *BatchShortenURLsRequest) (*BatchShortenURLsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchShortenURLs not implemented")
+}
 func (UnimplementedURLServiceServer) ExpandURL(context.Context, *ExpandURLRequest) (*ExpandURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExpandURL not implemented")
 }
+func (UnimplementedURLServiceServer) BatchExpandURLs(context.Context, *BatchExpandURLsRequest) (*BatchExpandURLsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchExpandURLs not implemented")
+}
+func (UnimplementedURLServiceServer) GetURLInfo(context.Context, *GetURLInfoRequest) (*GetURLInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetURLInfo not implemented")
+}
+func (UnimplementedURLServiceServer) ListURLs(context.Context, *ListURLsRequest) (*ListURLsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListURLs not implemented")
+}
+func (UnimplementedURLServiceServer) WatchClicks(*WatchClicksRequest, grpc.ServerStreamingServer[ClickEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchClicks not implemented")
+}
+func (UnimplementedURLServiceServer) DeleteURL(context.Context, *DeleteURLRequest) (*DeleteURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteURL not implemented")
+}
+func (UnimplementedURLServiceServer) DisableURL(context.Context, *DisableURLRequest) (*DisableURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableURL not implemented")
+}
+func (UnimplementedURLServiceServer) EnableURL(context.Context, *EnableURLRequest) (*EnableURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableURL not implemented")
+}
+func (UnimplementedURLServiceServer) UpdateURL(context.Context, *UpdateURLRequest) (*UpdateURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateURL not implemented")
+}
+func (UnimplementedURLServiceServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedURLServiceServer) AddTags(context.Context, *AddTagsRequest) (*AddTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTags not implemented")
+}
+func (UnimplementedURLServiceServer) RemoveTags(context.Context, *RemoveTagsRequest) (*RemoveTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (UnimplementedURLServiceServer) ListURLsByTag(context.Context, *ListURLsByTagRequest) (*ListURLsByTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListURLsByTag not implemented")
+}
+func (UnimplementedURLServiceServer) CreateCampaign(context.Context, *CreateCampaignRequest) (*CreateCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCampaign not implemented")
+}
+func (UnimplementedURLServiceServer) GetCampaign(context.Context, *GetCampaignRequest) (*GetCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCampaign not implemented")
+}
+func (UnimplementedURLServiceServer) UpdateCampaign(context.Context, *UpdateCampaignRequest) (*UpdateCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCampaign not implemented")
+}
+func (UnimplementedURLServiceServer) SetURLCampaign(context.Context, *SetURLCampaignRequest) (*SetURLCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetURLCampaign not implemented")
+}
+func (UnimplementedURLServiceServer) SetURLTargeting(context.Context, *SetURLTargetingRequest) (*SetURLTargetingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetURLTargeting not implemented")
+}
+func (UnimplementedURLServiceServer) SetURLVariants(context.Context, *SetURLVariantsRequest) (*SetURLVariantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetURLVariants not implemented")
+}
 func (UnimplementedURLServiceServer) mustEmbedUnimplementedURLServiceServer() {}
 func (UnimplementedURLServiceServer) testEmbeddedByValue()                    {}
 
@@ -128,6 +491,24 @@ func _URLService_ShortenURL_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _URLService_BatchShortenURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchShortenURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).BatchShortenURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_BatchShortenURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).BatchShortenURLs(ctx, req.(*BatchShortenURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _URLService_ExpandURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExpandURLRequest)
 	if err := dec(in); err != nil {
@@ -146,6 +527,323 @@ func _URLService_ExpandURL_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _URLService_BatchExpandURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchExpandURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).BatchExpandURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_BatchExpandURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).BatchExpandURLs(ctx, req.(*BatchExpandURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_GetURLInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetURLInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).GetURLInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_GetURLInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).GetURLInfo(ctx, req.(*GetURLInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_ListURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListURLsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).ListURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_ListURLs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).ListURLs(ctx, req.(*ListURLsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_WatchClicks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchClicksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(URLServiceServer).WatchClicks(m, &grpc.GenericServerStream[WatchClicksRequest, ClickEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type URLService_WatchClicksServer = grpc.ServerStreamingServer[ClickEvent]
+
+func _URLService_DeleteURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).DeleteURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_DeleteURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).DeleteURL(ctx, req.(*DeleteURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_DisableURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).DisableURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_DisableURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).DisableURL(ctx, req.(*DisableURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_EnableURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).EnableURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_EnableURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).EnableURL(ctx, req.(*EnableURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_UpdateURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).UpdateURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_UpdateURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).UpdateURL(ctx, req.(*UpdateURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_AddTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).AddTags(ctx, req.(*AddTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_RemoveTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).RemoveTags(ctx, req.(*RemoveTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_ListURLsByTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListURLsByTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).ListURLsByTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_ListURLsByTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).ListURLsByTag(ctx, req.(*ListURLsByTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_CreateCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).CreateCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_CreateCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).CreateCampaign(ctx, req.(*CreateCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_GetCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).GetCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_GetCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).GetCampaign(ctx, req.(*GetCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_UpdateCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).UpdateCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_UpdateCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).UpdateCampaign(ctx, req.(*UpdateCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_SetURLCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetURLCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).SetURLCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_SetURLCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).SetURLCampaign(ctx, req.(*SetURLCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_SetURLTargeting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetURLTargetingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).SetURLTargeting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_SetURLTargeting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).SetURLTargeting(ctx, req.(*SetURLTargetingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _URLService_SetURLVariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetURLVariantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(URLServiceServer).SetURLVariants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: URLService_SetURLVariants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(URLServiceServer).SetURLVariants(ctx, req.(*SetURLVariantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // URLService_ServiceDesc is the grpc.ServiceDesc for URLService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,11 +855,89 @@ var URLService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ShortenURL",
 			Handler:    _URLService_ShortenURL_Handler,
 		},
+		{
+			MethodName: "BatchShortenURLs",
+			Handler:    _URLService_BatchShortenURLs_Handler,
+		},
 		{
 			MethodName: "ExpandURL",
 			Handler:    _URLService_ExpandURL_Handler,
 		},
+		{
+			MethodName: "BatchExpandURLs",
+			Handler:    _URLService_BatchExpandURLs_Handler,
+		},
+		{
+			MethodName: "GetURLInfo",
+			Handler:    _URLService_GetURLInfo_Handler,
+		},
+		{
+			MethodName: "ListURLs",
+			Handler:    _URLService_ListURLs_Handler,
+		},
+		{
+			MethodName: "DeleteURL",
+			Handler:    _URLService_DeleteURL_Handler,
+		},
+		{
+			MethodName: "DisableURL",
+			Handler:    _URLService_DisableURL_Handler,
+		},
+		{
+			MethodName: "EnableURL",
+			Handler:    _URLService_EnableURL_Handler,
+		},
+		{
+			MethodName: "UpdateURL",
+			Handler:    _URLService_UpdateURL_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _URLService_GetUsage_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _URLService_AddTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _URLService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "ListURLsByTag",
+			Handler:    _URLService_ListURLsByTag_Handler,
+		},
+		{
+			MethodName: "CreateCampaign",
+			Handler:    _URLService_CreateCampaign_Handler,
+		},
+		{
+			MethodName: "GetCampaign",
+			Handler:    _URLService_GetCampaign_Handler,
+		},
+		{
+			MethodName: "UpdateCampaign",
+			Handler:    _URLService_UpdateCampaign_Handler,
+		},
+		{
+			MethodName: "SetURLCampaign",
+			Handler:    _URLService_SetURLCampaign_Handler,
+		},
+		{
+			MethodName: "SetURLTargeting",
+			Handler:    _URLService_SetURLTargeting_Handler,
+		},
+		{
+			MethodName: "SetURLVariants",
+			Handler:    _URLService_SetURLVariants_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchClicks",
+			Handler:       _URLService_WatchClicks_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/shortlink.proto",
 }